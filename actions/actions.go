@@ -1,9 +1,17 @@
 package actions
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+)
 
 type Action interface {
 	Type() Type
+	Fields() []field.Field
 }
 
 var errUnsupportedAction = errors.New("Unsupported action")
@@ -37,17 +45,28 @@ func Decode(t Type, calldata []byte, offset int) (Action, error) {
 	case UNWRAP:
 		return DecodeUnwrap(calldata, offset)
 
-	// Below actions are not handled within V4Router.sol within `_handleAction(action, params)`
-	case INCREASE_LIQUIDITY,
-		DECREASE_LIQUIDITY,
-		BURN_POSITION,
-		INCREASE_LIQUIDITY_FROM_DELTAS,
-		MINT_POSITION_FROM_DELTAS,
-		DONATE,
-		SETTLE_PAIR,
-		TAKE_PAIR,
-		CLOSE_CURRENCY,
-		CLEAR_OR_TAKE,
+	// Below actions are handled within PositionManager.sol `_handleAction(action, params)`
+	case INCREASE_LIQUIDITY:
+		return DecodeIncreaseLiquidity(calldata, offset)
+	case DECREASE_LIQUIDITY:
+		return DecodeDecreaseLiquidity(calldata, offset)
+	case BURN_POSITION:
+		return DecodeBurnPosition(calldata, offset)
+	case INCREASE_LIQUIDITY_FROM_DELTAS:
+		return DecodeIncreaseLiquidityFromDeltas(calldata, offset)
+	case MINT_POSITION_FROM_DELTAS:
+		return DecodeMintPositionFromDeltas(calldata, offset)
+	case SETTLE_PAIR:
+		return DecodeSettlePair(calldata, offset)
+	case TAKE_PAIR:
+		return DecodeTakePair(calldata, offset)
+	case CLOSE_CURRENCY:
+		return DecodeCloseCurrency(calldata, offset)
+	case CLEAR_OR_TAKE:
+		return DecodeClearOrTake(calldata, offset)
+
+	// Below actions are not handled by either V4Router.sol or PositionManager.sol
+	case DONATE,
 		MINT_6909,
 		BURN_6909:
 		return nil, errUnsupportedAction
@@ -63,6 +82,211 @@ func Decode(t Type, calldata []byte, offset int) (Action, error) {
 		return DecodeV3Collect(calldata, offset)
 	case V3_BURN:
 		return DecodeV3Burn(calldata, offset)
+	case V3_CREATE_AND_INITIALIZE_POOL:
+		return DecodeV3CreateAndInitializePool(calldata, offset)
+	case V3_SELF_PERMIT, V3_SELF_PERMIT_IF_NECESSARY:
+		return DecodeV3SelfPermit(calldata, offset, t == V3_SELF_PERMIT_IF_NECESSARY)
+	case V3_SELF_PERMIT_ALLOWED, V3_SELF_PERMIT_ALLOWED_IF_NECESSARY:
+		return DecodeV3SelfPermitAllowed(calldata, offset, t == V3_SELF_PERMIT_ALLOWED_IF_NECESSARY)
+	}
+	return nil, errInvalidType
+}
+
+// Unmarshal unmarshals an action encoded as JSON by its MarshalJSON, of the type named by its "type".
+func Unmarshal(data []byte) (Action, error) {
+	var v struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	t, err := ParseName(v.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case MINT_POSITION:
+		return unmarshal[MintPosition](data)
+	case SWAP_EXACT_IN_SINGLE:
+		return unmarshal[SwapExactInSingle](data)
+	case SWAP_EXACT_IN:
+		return unmarshal[SwapExactIn](data)
+	case SWAP_EXACT_OUT_SINGLE:
+		return unmarshal[SwapExactOutSingle](data)
+	case SWAP_EXACT_OUT:
+		return unmarshal[SwapExactOut](data)
+	case SETTLE:
+		return unmarshal[Settle](data)
+	case SETTLE_ALL:
+		return unmarshal[SettleAll](data)
+	case TAKE:
+		return unmarshal[Take](data)
+	case TAKE_ALL:
+		return unmarshal[TakeAll](data)
+	case TAKE_PORTION:
+		return unmarshal[TakePortion](data)
+	case SWEEP:
+		return unmarshal[Sweep](data)
+	case WRAP:
+		return unmarshal[Wrap](data)
+	case UNWRAP:
+		return unmarshal[Unwrap](data)
+
+	// Below actions are handled within PositionManager.sol `_handleAction(action, params)`
+	case INCREASE_LIQUIDITY:
+		return unmarshal[IncreaseLiquidity](data)
+	case DECREASE_LIQUIDITY:
+		return unmarshal[DecreaseLiquidity](data)
+	case BURN_POSITION:
+		return unmarshal[BurnPosition](data)
+	case INCREASE_LIQUIDITY_FROM_DELTAS:
+		return unmarshal[IncreaseLiquidityFromDeltas](data)
+	case MINT_POSITION_FROM_DELTAS:
+		return unmarshal[MintPositionFromDeltas](data)
+	case SETTLE_PAIR:
+		return unmarshal[SettlePair](data)
+	case TAKE_PAIR:
+		return unmarshal[TakePair](data)
+	case CLOSE_CURRENCY:
+		return unmarshal[CloseCurrency](data)
+	case CLEAR_OR_TAKE:
+		return unmarshal[ClearOrTake](data)
+
+	// Below actions are not handled by either V4Router.sol or PositionManager.sol
+	case DONATE,
+		MINT_6909,
+		BURN_6909:
+		return nil, errUnsupportedAction
+
+	// - V3 --------------------
+	case V3_MINT:
+		return unmarshal[V3Mint](data)
+	case V3_INCREASE_LIQUIDITY:
+		return unmarshal[V3IncreaseLiquidity](data)
+	case V3_DECREASE_LIQUIDITY:
+		return unmarshal[V3DecreaseLiquidity](data)
+	case V3_COLLECT:
+		return unmarshal[V3Collect](data)
+	case V3_BURN:
+		return unmarshal[V3Burn](data)
+	case V3_CREATE_AND_INITIALIZE_POOL:
+		return unmarshal[V3CreateAndInitializePool](data)
+	case V3_SELF_PERMIT, V3_SELF_PERMIT_IF_NECESSARY:
+		return unmarshal[V3SelfPermit](data)
+	case V3_SELF_PERMIT_ALLOWED, V3_SELF_PERMIT_ALLOWED_IF_NECESSARY:
+		return unmarshal[V3SelfPermitAllowed](data)
 	}
 	return nil, errInvalidType
 }
+
+// UnmarshalMany unmarshals a JSON array of actions, as encoded by V4Router and PositionManager commands.
+func UnmarshalMany(data []byte) ([]Action, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	var actions []Action
+	for i, b := range raw {
+		a, err := Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid action %d; %w", i, err)
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
+
+func unmarshal[T Action](data []byte) (Action, error) {
+	var a T
+	err := json.Unmarshal(data, &a)
+	return a, err
+}
+
+// Layout is where each part of the ABI encoded `(bytes actions, bytes[] params)` pair is within calldata.
+type Layout struct {
+	// Offset is the location of the pair, whose first two words are the offsets of its actions and params.
+	Offset int
+	// ActionsAt is the location of the actions length, followed by the type of each action.
+	ActionsAt int
+	// ParamsAt is the location of the params length, followed by the offset of each param.
+	ParamsAt int
+	Types    []Type
+	// Params is the location of each param, its length followed by its bytes.
+	Params []int
+}
+
+// DecodeLayout decodes the layout of the actions whose head starts at offset. When a param is out of bounds, the layout
+// holds the params before it along with the error.
+func DecodeLayout(calldata []byte, offset int) (Layout, error) {
+	l := Layout{Offset: offset}
+	if offset < 0 || offset > len(calldata)-0x40 {
+		return l, fmt.Errorf("actions exceed calldata bounds")
+	}
+
+	actionStart, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return l, fmt.Errorf("invalid action start memory location; %w", err)
+	}
+	paramsStart, err := hex.Int(calldata[offset+0x20 : offset+0x40])
+	if err != nil {
+		return l, fmt.Errorf("invalid params start memory location; %w", err)
+	}
+	if actionStart > len(calldata)-offset-0x20 || paramsStart > len(calldata)-offset-0x20 {
+		return l, fmt.Errorf("actions exceed calldata bounds")
+	}
+	l.ActionsAt = actionStart + offset
+	l.ParamsAt = paramsStart + offset
+
+	actionLen, err := hex.Int(calldata[l.ActionsAt : l.ActionsAt+0x20])
+	if err != nil {
+		return l, fmt.Errorf("invalid action length value; %w", err)
+	} else if actionLen > len(calldata)-l.ActionsAt-0x20 {
+		return l, fmt.Errorf("invalid action length value; %d exceeds calldata bounds", actionLen)
+	}
+	l.Types, err = DecodeType(calldata[l.ActionsAt+0x20 : l.ActionsAt+0x20+actionLen])
+	if err != nil {
+		return l, fmt.Errorf("invalid actions; %w", err)
+	}
+
+	paramsLen, err := hex.Int(calldata[l.ParamsAt : l.ParamsAt+0x20])
+	if err != nil {
+		return l, fmt.Errorf("invalid params length; %w", err)
+	} else if paramsLen != actionLen {
+		return l, fmt.Errorf("params length mismatch; got %d but expected %d", paramsLen, actionLen)
+	}
+	offset = l.ParamsAt + 0x20
+	if paramsLen > (len(calldata)-offset)/0x20 {
+		return l, fmt.Errorf("params exceed calldata bounds")
+	}
+
+	for i := range l.Types {
+		paramOffset := offset + i*0x20
+		loc, err := hex.Int(calldata[paramOffset : paramOffset+0x20])
+		if err != nil {
+			return l, fmt.Errorf("invalid param location for param %d; %w", i, err)
+		} else if loc < 0 || loc > len(calldata)-offset-0x20 {
+			return l, fmt.Errorf("param %d exceeds calldata bounds", i)
+		} else if n, err := hex.Int(calldata[offset+loc : offset+loc+0x20]); err != nil || n < 0 || n > len(calldata)-offset-loc-0x20 {
+			return l, fmt.Errorf("param %d length exceeds calldata bounds", i)
+		}
+		l.Params = append(l.Params, offset+loc)
+	}
+	return l, nil
+}
+
+// DecodeMany decodes the `(bytes actions, bytes[] params)` pair whose head starts at offset.
+//
+// This is the encoding of both V4Router and PositionManager unlock data.
+func DecodeMany(calldata []byte, offset int) ([]Action, error) {
+	l, layoutErr := DecodeLayout(calldata, offset)
+	var as []Action
+	for i, loc := range l.Params {
+		a, err := Decode(l.Types[i], calldata, loc)
+		if err != nil {
+			return as, fmt.Errorf("invalid action data at index %d for %s; %w", i, l.Types[i], err)
+		}
+		as = append(as, a)
+	}
+	return as, layoutErr
+}
@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+type CloseCurrency struct {
+	Currency common.Address `json:"currency"`
+}
+
+func (CloseCurrency) Type() Type {
+	return CLOSE_CURRENCY
+}
+
+func (c CloseCurrency) MarshalJSON() ([]byte, error) {
+	type Alias CloseCurrency
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(c), CLOSE_CURRENCY.String()})
+}
+
+func (c *CloseCurrency) UnmarshalJSON(data []byte) error {
+	type Alias CloseCurrency
+	return jsonx.Unmarshal(data, (*Alias)(c))
+}
+
+func (c CloseCurrency) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: c.Currency},
+	}
+}
+
+func DecodeCloseCurrency(calldata []byte, offset int) (CloseCurrency, error) {
+	var c CloseCurrency
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return c, fmt.Errorf("invalid %s input count; %w", CLOSE_CURRENCY, err)
+	} else if count != 0x20 {
+		return c, fmt.Errorf("invalid %s input count; expected 0x20 but got 0x%x", CLOSE_CURRENCY, count)
+	}
+
+	c = CloseCurrency{
+		Currency: common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+	}
+	return c, nil
+}
+
+type ClearOrTake struct {
+	Currency  common.Address `json:"currency"`
+	AmountMax *big.Int       `json:"amountMax"`
+}
+
+func (ClearOrTake) Type() Type {
+	return CLEAR_OR_TAKE
+}
+
+func (c ClearOrTake) MarshalJSON() ([]byte, error) {
+	type Alias ClearOrTake
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(c), CLEAR_OR_TAKE.String()})
+}
+
+func (c *ClearOrTake) UnmarshalJSON(data []byte) error {
+	type Alias ClearOrTake
+	return jsonx.Unmarshal(data, (*Alias)(c))
+}
+
+func (c ClearOrTake) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: c.Currency},
+		{Name: "AmountMax", Value: c.AmountMax},
+	}
+}
+
+func DecodeClearOrTake(calldata []byte, offset int) (ClearOrTake, error) {
+	var c ClearOrTake
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return c, fmt.Errorf("invalid %s input count; %w", CLEAR_OR_TAKE, err)
+	} else if count != 0x40 {
+		return c, fmt.Errorf("invalid %s input count; expected 0x40 but got 0x%x", CLEAR_OR_TAKE, count)
+	}
+
+	c = ClearOrTake{
+		Currency:  common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		AmountMax: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+	}
+	return c, nil
+}
@@ -0,0 +1,300 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/pool"
+)
+
+// IncreaseLiquidity Solidity representation
+//
+// see: v4-periphery/src/PositionManager.sol:_handleAction
+type IncreaseLiquidity struct {
+	TokenID    *big.Int `json:"tokenId"`
+	Liquidity  *big.Int `json:"liquidity"`
+	Amount0Max *big.Int `json:"amount0Max"`
+	Amount1Max *big.Int `json:"amount1Max"`
+	HookData   []byte   `json:"hookData"`
+}
+
+func (IncreaseLiquidity) Type() Type {
+	return INCREASE_LIQUIDITY
+}
+
+func (l IncreaseLiquidity) MarshalJSON() ([]byte, error) {
+	type Alias IncreaseLiquidity
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(l), INCREASE_LIQUIDITY.String()})
+}
+
+func (l *IncreaseLiquidity) UnmarshalJSON(data []byte) error {
+	type Alias IncreaseLiquidity
+	return jsonx.Unmarshal(data, (*Alias)(l))
+}
+
+func (l IncreaseLiquidity) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: l.TokenID},
+		{Name: "Liquidity", Value: l.Liquidity},
+		{Name: "Amount0Max", Value: l.Amount0Max},
+		{Name: "Amount1Max", Value: l.Amount1Max},
+		{Name: "HookData", Value: l.HookData},
+	}
+}
+
+func DecodeIncreaseLiquidity(calldata []byte, offset int) (IncreaseLiquidity, error) {
+	var l IncreaseLiquidity
+	offset += 0x20
+	hookData, err := dynamicBytes(calldata, offset, 0x80)
+	if err != nil {
+		return l, err
+	}
+
+	l = IncreaseLiquidity{
+		TokenID:    new(big.Int).SetBytes(calldata[offset : offset+0x20]),
+		Liquidity:  new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		Amount0Max: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		Amount1Max: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
+		HookData:   hookData,
+	}
+	return l, nil
+}
+
+type DecreaseLiquidity struct {
+	TokenID    *big.Int `json:"tokenId"`
+	Liquidity  *big.Int `json:"liquidity"`
+	Amount0Min *big.Int `json:"amount0Min"`
+	Amount1Min *big.Int `json:"amount1Min"`
+	HookData   []byte   `json:"hookData"`
+}
+
+func (DecreaseLiquidity) Type() Type {
+	return DECREASE_LIQUIDITY
+}
+
+func (l DecreaseLiquidity) MarshalJSON() ([]byte, error) {
+	type Alias DecreaseLiquidity
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(l), DECREASE_LIQUIDITY.String()})
+}
+
+func (l *DecreaseLiquidity) UnmarshalJSON(data []byte) error {
+	type Alias DecreaseLiquidity
+	return jsonx.Unmarshal(data, (*Alias)(l))
+}
+
+func (l DecreaseLiquidity) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: l.TokenID},
+		{Name: "Liquidity", Value: l.Liquidity},
+		{Name: "Amount0Min", Value: l.Amount0Min},
+		{Name: "Amount1Min", Value: l.Amount1Min},
+		{Name: "HookData", Value: l.HookData},
+	}
+}
+
+func DecodeDecreaseLiquidity(calldata []byte, offset int) (DecreaseLiquidity, error) {
+	var l DecreaseLiquidity
+	offset += 0x20
+	hookData, err := dynamicBytes(calldata, offset, 0x80)
+	if err != nil {
+		return l, err
+	}
+
+	l = DecreaseLiquidity{
+		TokenID:    new(big.Int).SetBytes(calldata[offset : offset+0x20]),
+		Liquidity:  new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		Amount0Min: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		Amount1Min: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
+		HookData:   hookData,
+	}
+	return l, nil
+}
+
+type IncreaseLiquidityFromDeltas struct {
+	TokenID    *big.Int `json:"tokenId"`
+	Amount0Max *big.Int `json:"amount0Max"`
+	Amount1Max *big.Int `json:"amount1Max"`
+	HookData   []byte   `json:"hookData"`
+}
+
+func (IncreaseLiquidityFromDeltas) Type() Type {
+	return INCREASE_LIQUIDITY_FROM_DELTAS
+}
+
+func (l IncreaseLiquidityFromDeltas) MarshalJSON() ([]byte, error) {
+	type Alias IncreaseLiquidityFromDeltas
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(l), INCREASE_LIQUIDITY_FROM_DELTAS.String()})
+}
+
+func (l *IncreaseLiquidityFromDeltas) UnmarshalJSON(data []byte) error {
+	type Alias IncreaseLiquidityFromDeltas
+	return jsonx.Unmarshal(data, (*Alias)(l))
+}
+
+func (l IncreaseLiquidityFromDeltas) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: l.TokenID},
+		{Name: "Amount0Max", Value: l.Amount0Max},
+		{Name: "Amount1Max", Value: l.Amount1Max},
+		{Name: "HookData", Value: l.HookData},
+	}
+}
+
+func DecodeIncreaseLiquidityFromDeltas(calldata []byte, offset int) (IncreaseLiquidityFromDeltas, error) {
+	var l IncreaseLiquidityFromDeltas
+	offset += 0x20
+	hookData, err := dynamicBytes(calldata, offset, 0x60)
+	if err != nil {
+		return l, err
+	}
+
+	l = IncreaseLiquidityFromDeltas{
+		TokenID:    new(big.Int).SetBytes(calldata[offset : offset+0x20]),
+		Amount0Max: new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		Amount1Max: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		HookData:   hookData,
+	}
+	return l, nil
+}
+
+type MintPositionFromDeltas struct {
+	PoolKey    pool.Key       `json:"poolKey"`
+	TickLower  *big.Int       `json:"tickLower"`
+	TickUpper  *big.Int       `json:"tickUpper"`
+	Amount0Max *big.Int       `json:"amount0Max"`
+	Amount1Max *big.Int       `json:"amount1Max"`
+	Owner      common.Address `json:"owner"`
+	HookData   []byte         `json:"hookData"`
+}
+
+func (MintPositionFromDeltas) Type() Type {
+	return MINT_POSITION_FROM_DELTAS
+}
+
+func (m MintPositionFromDeltas) MarshalJSON() ([]byte, error) {
+	type Alias MintPositionFromDeltas
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(m), MINT_POSITION_FROM_DELTAS.String()})
+}
+
+func (m *MintPositionFromDeltas) UnmarshalJSON(data []byte) error {
+	type Alias MintPositionFromDeltas
+	return jsonx.Unmarshal(data, (*Alias)(m))
+}
+
+func (m MintPositionFromDeltas) Fields() []field.Field {
+	return []field.Field{
+		{Name: "PoolKey", Value: m.PoolKey.ID(), Fields: m.PoolKey.Fields()},
+		{Name: "TickLower", Value: m.TickLower},
+		{Name: "TickUpper", Value: m.TickUpper},
+		{Name: "Amount0Max", Value: m.Amount0Max},
+		{Name: "Amount1Max", Value: m.Amount1Max},
+		{Name: "Owner", Value: m.Owner},
+		{Name: "HookData", Value: m.HookData},
+	}
+}
+
+func DecodeMintPositionFromDeltas(calldata []byte, offset int) (MintPositionFromDeltas, error) {
+	var m MintPositionFromDeltas
+	offset += 0x20
+	hookData, err := dynamicBytes(calldata, offset, 0x140)
+	if err != nil {
+		return m, err
+	}
+
+	m = MintPositionFromDeltas{
+		PoolKey: pool.Key{
+			Currency0:   common.BytesToAddress(calldata[offset : offset+0x20]),
+			Currency1:   common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+			Fee:         new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+			TickSpacing: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
+			Hooks:       common.BytesToAddress(calldata[offset+0x80 : offset+0xa0]),
+		},
+		TickLower:  hex.SignedInt(calldata[offset+0xa0 : offset+0xc0]),
+		TickUpper:  hex.SignedInt(calldata[offset+0xc0 : offset+0xe0]),
+		Amount0Max: new(big.Int).SetBytes(calldata[offset+0xe0 : offset+0x100]),
+		Amount1Max: new(big.Int).SetBytes(calldata[offset+0x100 : offset+0x120]),
+		Owner:      common.BytesToAddress(calldata[offset+0x120 : offset+0x140]),
+		HookData:   hookData,
+	}
+	return m, nil
+}
+
+type BurnPosition struct {
+	TokenID    *big.Int `json:"tokenId"`
+	Amount0Min *big.Int `json:"amount0Min"`
+	Amount1Min *big.Int `json:"amount1Min"`
+	HookData   []byte   `json:"hookData"`
+}
+
+func (BurnPosition) Type() Type {
+	return BURN_POSITION
+}
+
+func (b BurnPosition) MarshalJSON() ([]byte, error) {
+	type Alias BurnPosition
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(b), BURN_POSITION.String()})
+}
+
+func (b *BurnPosition) UnmarshalJSON(data []byte) error {
+	type Alias BurnPosition
+	return jsonx.Unmarshal(data, (*Alias)(b))
+}
+
+func (b BurnPosition) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: b.TokenID},
+		{Name: "Amount0Min", Value: b.Amount0Min},
+		{Name: "Amount1Min", Value: b.Amount1Min},
+		{Name: "HookData", Value: b.HookData},
+	}
+}
+
+func DecodeBurnPosition(calldata []byte, offset int) (BurnPosition, error) {
+	var b BurnPosition
+	offset += 0x20
+	hookData, err := dynamicBytes(calldata, offset, 0x60)
+	if err != nil {
+		return b, err
+	}
+
+	b = BurnPosition{
+		TokenID:    new(big.Int).SetBytes(calldata[offset : offset+0x20]),
+		Amount0Min: new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		Amount1Min: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		HookData:   hookData,
+	}
+	return b, nil
+}
+
+// dynamicBytes returns the `bytes` value whose location, relative to start, is stored at start+head.
+//
+// Empty values are returned as a single zero byte, matching `hookDataFrom`.
+func dynamicBytes(calldata []byte, start, head int) ([]byte, error) {
+	b, err := hex.Bytes(calldata, start, head)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hook-data; %w", err)
+	} else if len(b) == 0 {
+		return []byte{0x0}, nil
+	}
+	return b, nil
+}
@@ -1,23 +1,24 @@
 package actions
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 	"github.com/juztin/unidecode/pool"
 )
 
 type MintPosition struct {
 	PoolKey    pool.Key       `json:"poolKey"`
-	TickLower  int64          `json:"tickLower"`
-	TickUpper  int64          `json:"tickUpper"`
+	TickLower  *big.Int       `json:"tickLower"`
+	TickUpper  *big.Int       `json:"tickUpper"`
 	Liquidity  *big.Int       `json:"liquidity"`
-	Amount0Max *big.Int       `json:"amount0max"`
-	Amount1Max *big.Int       `json:"amount1max"`
+	Amount0Max *big.Int       `json:"amount0Max"`
+	Amount1Max *big.Int       `json:"amount1Max"`
 	Owner      common.Address `json:"owner"`
 	HookData   []byte         `json:"hookData"`
 }
@@ -28,29 +29,54 @@ func (MintPosition) Type() Type {
 
 func (m MintPosition) MarshalJSON() ([]byte, error) {
 	type Alias MintPosition
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
-		HookData string `json:"hookData"`
-		Type     string `json:"type"`
-	}{(Alias)(m), fmt.Sprintf("0x%x", m.HookData), MINT_POSITION.String()})
+		Type string `json:"type"`
+	}{(Alias)(m), MINT_POSITION.String()})
+}
+
+func (m *MintPosition) UnmarshalJSON(data []byte) error {
+	type Alias MintPosition
+	return jsonx.Unmarshal(data, (*Alias)(m))
+}
+
+func (m MintPosition) Fields() []field.Field {
+	return []field.Field{
+		{Name: "PoolKey", Value: m.PoolKey.ID(), Fields: m.PoolKey.Fields()},
+		{Name: "TickLower", Value: m.TickLower},
+		{Name: "TickUpper", Value: m.TickUpper},
+		{Name: "Liquidity", Value: m.Liquidity},
+		{Name: "Amount0Max", Value: m.Amount0Max},
+		{Name: "Amount1Max", Value: m.Amount1Max},
+		{Name: "Owner", Value: m.Owner},
+		{Name: "HookData", Value: m.HookData},
+	}
 }
 
 func DecodeMintPosition(calldata []byte, offset int) (MintPosition, error) {
 	var p MintPosition
-	length, _ := hex.Int(calldata[offset : offset+0x20])
-	required := len(calldata) - offset + 0x20
-	if length > required {
+	length, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid action data length; %w", err)
+	} else if required := len(calldata) - offset - 0x20; length > required {
 		return p, fmt.Errorf("action data exceeds calldata bounds; expected at-least %d but got %d", length, required)
 	}
 
 	offset += 0x20
+	if offset+0x180 > len(calldata) {
+		return p, fmt.Errorf("action data exceeds calldata bounds")
+	}
 	hookDataOffset, err := hex.Int(calldata[offset+0x160 : offset+0x180])
 	if err != nil {
 		return p, fmt.Errorf("invalid hookData offset; %w", err)
+	} else if hookDataOffset > len(calldata)-offset-0x20 {
+		return p, fmt.Errorf("hookData exceeds calldata bounds; offset 0x%x", hookDataOffset)
 	}
 	hookDataLen, err := hex.Int(calldata[offset+hookDataOffset : offset+hookDataOffset+0x20])
 	if err != nil {
 		return p, fmt.Errorf("invalid hookData length; %w", err)
+	} else if hookDataLen > len(calldata)-offset-hookDataOffset-0x20 {
+		return p, fmt.Errorf("hookData exceeds calldata bounds; length 0x%x", hookDataLen)
 	}
 
 	p = MintPosition{
@@ -59,17 +85,16 @@ func DecodeMintPosition(calldata []byte, offset int) (MintPosition, error) {
 			Currency1:   common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
 			Fee:         new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
 			TickSpacing: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
-			Hooks:       common.BytesToAddress(calldata[offset+0xa0 : offset+0xa0]),
+			Hooks:       common.BytesToAddress(calldata[offset+0x80 : offset+0xa0]),
 		},
-		// Tick values appear to have a FF mask applied to the first 29bytes of the block.
-		// Both values are int24 in size, so we only want the last 3 bytes—exclude the first 29bytes(0x1d)
-		TickLower:  new(big.Int).SetBytes(calldata[offset+0xa0+0x1d : offset+0xc0]).Int64(),
-		TickUpper:  new(big.Int).SetBytes(calldata[offset+0xc0+0x1d : offset+0xe0]).Int64(),
+		// Tick values are sign extended int24 values
+		TickLower:  hex.SignedInt(calldata[offset+0xa0 : offset+0xc0]),
+		TickUpper:  hex.SignedInt(calldata[offset+0xc0 : offset+0xe0]),
 		Liquidity:  new(big.Int).SetBytes(calldata[offset+0xe0 : offset+0x100]),
 		Amount0Max: new(big.Int).SetBytes(calldata[offset+0x100 : offset+0x120]),
 		Amount1Max: new(big.Int).SetBytes(calldata[offset+0x120 : offset+0x140]),
 		Owner:      common.BytesToAddress(calldata[offset+0x140 : offset+0x160]),
-		HookData:   calldata[offset+hookDataOffset : offset+hookDataOffset+hookDataLen],
+		HookData:   calldata[offset+hookDataOffset+0x20 : offset+hookDataOffset+0x20+hookDataLen],
 	}
 	return p, nil
 }
@@ -1,13 +1,14 @@
 package actions
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type Settle struct {
@@ -22,17 +23,30 @@ func (Settle) Type() Type {
 
 func (s Settle) MarshalJSON() ([]byte, error) {
 	type Alias Settle
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SETTLE.String()})
 }
 
+func (s *Settle) UnmarshalJSON(data []byte) error {
+	type Alias Settle
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s Settle) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: s.Currency},
+		{Name: "Amount", Value: s.Amount},
+		{Name: "PayerIsUser", Value: s.PayerIsUser},
+	}
+}
+
 func DecodeSettle(calldata []byte, offset int) (Settle, error) {
 	var s Settle
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return s, fmt.Errorf("invalid %s input count; %w", err)
+		return s, fmt.Errorf("invalid %s input count; %w", SETTLE, err)
 	} else if count != 0x60 {
 		return s, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", SETTLE, count)
 	}
@@ -61,17 +75,29 @@ func (s SettleAll) Type() Type {
 
 func (s SettleAll) MarshalJSON() ([]byte, error) {
 	type Alias SettleAll
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SETTLE_ALL.String()})
 }
 
+func (s *SettleAll) UnmarshalJSON(data []byte) error {
+	type Alias SettleAll
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s SettleAll) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: s.Currency},
+		{Name: "MaxAmount", Value: s.MaxAmount},
+	}
+}
+
 func DecodeSettleAll(calldata []byte, offset int) (SettleAll, error) {
 	var s SettleAll
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return s, fmt.Errorf("invalid %s input count; %w", err)
+		return s, fmt.Errorf("invalid %s input count; %w", SETTLE_ALL, err)
 	} else if count != 0x40 {
 		return s, fmt.Errorf("invalid %s input count; expected 0x40 but got 0x%x", SETTLE, count)
 	}
@@ -82,3 +108,48 @@ func DecodeSettleAll(calldata []byte, offset int) (SettleAll, error) {
 	}
 	return s, nil
 }
+
+type SettlePair struct {
+	Currency0 common.Address `json:"currency0"`
+	Currency1 common.Address `json:"currency1"`
+}
+
+func (SettlePair) Type() Type {
+	return SETTLE_PAIR
+}
+
+func (s SettlePair) MarshalJSON() ([]byte, error) {
+	type Alias SettlePair
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(s), SETTLE_PAIR.String()})
+}
+
+func (s *SettlePair) UnmarshalJSON(data []byte) error {
+	type Alias SettlePair
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s SettlePair) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency0", Value: s.Currency0},
+		{Name: "Currency1", Value: s.Currency1},
+	}
+}
+
+func DecodeSettlePair(calldata []byte, offset int) (SettlePair, error) {
+	var s SettlePair
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return s, fmt.Errorf("invalid %s input count; %w", SETTLE_PAIR, err)
+	} else if count != 0x40 {
+		return s, fmt.Errorf("invalid %s input count; expected 0x40 but got 0x%x", SETTLE_PAIR, count)
+	}
+
+	s = SettlePair{
+		Currency0: common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		Currency1: common.BytesToAddress(calldata[offset+0x40 : offset+0x60]),
+	}
+	return s, nil
+}
@@ -1,13 +1,14 @@
 package actions
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 	"github.com/juztin/unidecode/path"
 	"github.com/juztin/unidecode/pool"
 )
@@ -28,24 +29,41 @@ func (SwapExactOut) Type() Type {
 
 func (s SwapExactOut) MarshalJSON() ([]byte, error) {
 	type Alias SwapExactOut
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SWAP_EXACT_OUT.String()})
 }
 
+func (s *SwapExactOut) UnmarshalJSON(data []byte) error {
+	type Alias SwapExactOut
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s SwapExactOut) Fields() []field.Field {
+	return []field.Field{
+		{Name: "CurrencyOut", Value: s.CurrencyOut},
+		field.List("Path", s.Path),
+		{Name: "AmountOut", Value: s.AmountOut},
+		{Name: "AmountInMaximum", Value: s.AmountInMaximum},
+	}
+}
+
 func DecodeSwapExactOut(calldata []byte, offset int) (SwapExactOut, error) {
 	var s SwapExactOut
-	length, _ := hex.Int(calldata[offset : offset+0x20])
-	required := len(calldata) - offset + 0x20
-	if length > required {
+	length, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return s, fmt.Errorf("invalid action data length; %w", err)
+	} else if required := len(calldata) - offset - 0x20; length > required {
 		return s, fmt.Errorf("action data exceeds calldata bounds; expected at-least %d but got %d", length, required)
 	}
 
 	offset += 0x20
 	start, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return s, fmt.Errorf("invalid start value; %w", calldata[offset:offset+0x20], err)
+		return s, fmt.Errorf("invalid start value: 0x%x; %w", calldata[offset:offset+0x20], err)
+	} else if start > len(calldata)-offset-0x80 {
+		return s, fmt.Errorf("action data exceeds calldata bounds; start 0x%x", start)
 	}
 	offset += start
 
@@ -57,6 +75,8 @@ func DecodeSwapExactOut(calldata []byte, offset int) (SwapExactOut, error) {
 	pathsStart, err := hex.Int(calldata[offset+0x20 : offset+0x40])
 	if err != nil {
 		return s, fmt.Errorf("invalid path start loc; %w", err)
+	} else if pathsStart > len(calldata)-offset {
+		return s, fmt.Errorf("path exceeds calldata bounds; start 0x%x", pathsStart)
 	}
 
 	s.Path, err = path.DecodeMany(calldata, offset+pathsStart)
@@ -83,17 +103,33 @@ func (s SwapExactOutSingle) Type() Type {
 
 func (s SwapExactOutSingle) MarshalJSON() ([]byte, error) {
 	type Alias SwapExactOutSingle
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SWAP_EXACT_OUT_SINGLE.String()})
 }
 
+func (s *SwapExactOutSingle) UnmarshalJSON(data []byte) error {
+	type Alias SwapExactOutSingle
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s SwapExactOutSingle) Fields() []field.Field {
+	return []field.Field{
+		{Name: "PoolKey", Value: s.PoolKey.ID(), Fields: s.PoolKey.Fields()},
+		{Name: "ZeroForOne", Value: s.ZeroForOne},
+		{Name: "AmountOut", Value: s.AmountOut},
+		{Name: "AmountInMaximum", Value: s.AmountInMaximum},
+		{Name: "HookData", Value: s.HookData},
+	}
+}
+
 func DecodeSwapExactOutSingle(calldata []byte, offset int) (SwapExactOutSingle, error) {
 	var s SwapExactOutSingle
-	length, _ := hex.Int(calldata[offset : offset+0x20])
-	required := len(calldata) - offset + 0x20
-	if length > required {
+	length, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return s, fmt.Errorf("invalid action data length; %w", err)
+	} else if required := len(calldata) - offset - 0x20; length > required {
 		return s, fmt.Errorf("action data exceeds calldata bounds; expected at-least %d but got %d", length, required)
 	}
 
@@ -101,6 +137,8 @@ func DecodeSwapExactOutSingle(calldata []byte, offset int) (SwapExactOutSingle,
 	start, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
 		return s, fmt.Errorf("invalid start value: 0x%x; %w", calldata[offset:offset+0x20], err)
+	} else if start > len(calldata)-offset-0x120 {
+		return s, fmt.Errorf("action data exceeds calldata bounds; start 0x%x", start)
 	}
 	offset += start
 
@@ -114,7 +152,7 @@ func DecodeSwapExactOutSingle(calldata []byte, offset int) (SwapExactOutSingle,
 	}
 	s.ZeroForOne, err = hex.Bool(calldata[offset+0xa0 : offset+0xc0])
 	if err != nil {
-		fmt.Println("invalid zeroForOne value; %w", err)
+		return s, fmt.Errorf("invalid zeroForOne value; %w", err)
 	}
 
 	s.AmountOut = new(big.Int).SetBytes(calldata[offset+0xc0 : offset+0xe0])
@@ -139,17 +177,32 @@ func (SwapExactIn) Type() Type {
 
 func (s SwapExactIn) MarshalJSON() ([]byte, error) {
 	type Alias SwapExactIn
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SWAP_EXACT_IN.String()})
 }
 
+func (s *SwapExactIn) UnmarshalJSON(data []byte) error {
+	type Alias SwapExactIn
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s SwapExactIn) Fields() []field.Field {
+	return []field.Field{
+		{Name: "CurrencyIn", Value: s.CurrencyIn},
+		field.List("Path", s.Path),
+		{Name: "AmountIn", Value: s.AmountIn},
+		{Name: "AmountOutMinimum", Value: s.AmountOutMinimum},
+	}
+}
+
 func DecodeSwapExactIn(calldata []byte, offset int) (SwapExactIn, error) {
 	var s SwapExactIn
-	length, _ := hex.Int(calldata[offset : offset+0x20])
-	required := len(calldata) - offset + 0x20
-	if length > required {
+	length, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return s, fmt.Errorf("invalid action data length; %w", err)
+	} else if required := len(calldata) - offset - 0x20; length > required {
 		return s, fmt.Errorf("action data exceeds calldata bounds; expected at-least %d but got %d", length, required)
 	}
 
@@ -157,6 +210,8 @@ func DecodeSwapExactIn(calldata []byte, offset int) (SwapExactIn, error) {
 	start, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
 		return s, fmt.Errorf("invalid start value: 0x%x; %w", calldata[offset:offset+0x20], err)
+	} else if start > len(calldata)-offset-0x80 {
+		return s, fmt.Errorf("action data exceeds calldata bounds; start 0x%x", start)
 	}
 	offset += start
 
@@ -168,6 +223,8 @@ func DecodeSwapExactIn(calldata []byte, offset int) (SwapExactIn, error) {
 	pathsStart, err := hex.Int(calldata[offset+0x20 : offset+0x40])
 	if err != nil {
 		return s, fmt.Errorf("invalid path start loc; %w", err)
+	} else if pathsStart > len(calldata)-offset {
+		return s, fmt.Errorf("path exceeds calldata bounds; start 0x%x", pathsStart)
 	}
 
 	s.Path, err = path.DecodeMany(calldata, offset+pathsStart)
@@ -183,7 +240,7 @@ func DecodeSwapExactIn(calldata []byte, offset int) (SwapExactIn, error) {
 type SwapExactInSingle struct {
 	PoolKey          pool.Key `json:"poolKey"`
 	ZeroForOne       bool     `json:"zeroForOne"`
-	AmountIn         *big.Int `json:"amountInt"`
+	AmountIn         *big.Int `json:"amountIn"`
 	AmountOutMinimum *big.Int `json:"amountOutMinimum"`
 	HookData         []byte   `json:"hookData"`
 }
@@ -194,17 +251,33 @@ func (SwapExactInSingle) Type() Type {
 
 func (s SwapExactInSingle) MarshalJSON() ([]byte, error) {
 	type Alias SwapExactInSingle
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
-		HookData string `json:"hookData"`
-	}{(Alias)(s), fmt.Sprintf("0x%x", s.HookData)})
+		Type string `json:"type"`
+	}{(Alias)(s), SWAP_EXACT_IN_SINGLE.String()})
+}
+
+func (s *SwapExactInSingle) UnmarshalJSON(data []byte) error {
+	type Alias SwapExactInSingle
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s SwapExactInSingle) Fields() []field.Field {
+	return []field.Field{
+		{Name: "PoolKey", Value: s.PoolKey.ID(), Fields: s.PoolKey.Fields()},
+		{Name: "ZeroForOne", Value: s.ZeroForOne},
+		{Name: "AmountIn", Value: s.AmountIn},
+		{Name: "AmountOutMinimum", Value: s.AmountOutMinimum},
+		{Name: "HookData", Value: s.HookData},
+	}
 }
 
 func DecodeSwapExactInSingle(calldata []byte, offset int) (SwapExactInSingle, error) {
 	var s SwapExactInSingle
-	length, _ := hex.Int(calldata[offset : offset+0x20])
-	required := len(calldata) - offset + 0x20
-	if length > required {
+	length, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return s, fmt.Errorf("invalid action data length; %w", err)
+	} else if required := len(calldata) - offset - 0x20; length > required {
 		return s, fmt.Errorf("action data exceeds calldata bounds; expected at-least %d but got %d", length, required)
 	}
 
@@ -212,6 +285,8 @@ func DecodeSwapExactInSingle(calldata []byte, offset int) (SwapExactInSingle, er
 	start, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
 		return s, fmt.Errorf("invalid start value: 0x%x; %w", calldata[offset:offset+0x20], err)
+	} else if start > len(calldata)-offset-0x120 {
+		return s, fmt.Errorf("action data exceeds calldata bounds; start 0x%x", start)
 	}
 	offset += start
 
@@ -225,7 +300,7 @@ func DecodeSwapExactInSingle(calldata []byte, offset int) (SwapExactInSingle, er
 	}
 	s.ZeroForOne, err = hex.Bool(calldata[offset+0xa0 : offset+0xc0])
 	if err != nil {
-		fmt.Println("invalid zeroForOne value; %w", err)
+		return s, fmt.Errorf("invalid zeroForOne value; %w", err)
 	}
 
 	s.AmountIn = new(big.Int).SetBytes(calldata[offset+0xc0 : offset+0xe0])
@@ -238,15 +313,19 @@ func hookDataFrom(calldata []byte, offset int) ([]byte, error) {
 	hookDataOffset, err := hex.Int(calldata[offset+0x100 : offset+0x120])
 	if err != nil {
 		return nil, fmt.Errorf("invalid hook-data start value: 0x%x", calldata[offset+0x100:offset+0x120])
+	} else if hookDataOffset > len(calldata)-offset-0x20 {
+		return nil, fmt.Errorf("hook-data exceeds calldata bounds; start 0x%x", hookDataOffset)
 	}
 	hookDataLen, err := hex.Int(calldata[offset+hookDataOffset : offset+hookDataOffset+0x20])
 	var hookData []byte
 	if err != nil {
 		err = fmt.Errorf("invalid hook-data length: 0x%x; %w", calldata[offset+hookDataOffset:offset+hookDataOffset+0x20], err)
+	} else if hookDataLen > len(calldata)-offset-hookDataOffset-0x20 {
+		err = fmt.Errorf("hook-data exceeds calldata bounds; length 0x%x", hookDataLen)
 	} else if hookDataLen == 0 {
 		hookData = []byte{0x0}
 	} else {
 		hookData = calldata[offset+hookDataOffset+0x20 : offset+hookDataOffset+0x20+hookDataLen]
 	}
-	return hookData, nil
+	return hookData, err
 }
@@ -1,12 +1,13 @@
 package actions
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type Sweep struct {
@@ -20,17 +21,29 @@ func (Sweep) Type() Type {
 
 func (s Sweep) MarshalJSON() ([]byte, error) {
 	type Alias Sweep
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SWEEP.String()})
 }
 
+func (s *Sweep) UnmarshalJSON(data []byte) error {
+	type Alias Sweep
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s Sweep) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: s.Currency},
+		{Name: "Recipient", Value: s.Recipient},
+	}
+}
+
 func DecodeSweep(calldata []byte, offset int) (Sweep, error) {
 	var s Sweep
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return s, fmt.Errorf("invalid %s input count; %w", err)
+		return s, fmt.Errorf("invalid %s input count; %w", SWEEP, err)
 	} else if count != 0x40 {
 		return s, fmt.Errorf("invalid %s input count; expected 0x40 but got 0x%x", SWEEP, count)
 	}
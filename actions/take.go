@@ -1,13 +1,14 @@
 package actions
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type Take struct {
@@ -22,17 +23,30 @@ func (Take) Type() Type {
 
 func (t Take) MarshalJSON() ([]byte, error) {
 	type Alias Take
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(t), TAKE.String()})
 }
 
+func (t *Take) UnmarshalJSON(data []byte) error {
+	type Alias Take
+	return jsonx.Unmarshal(data, (*Alias)(t))
+}
+
+func (t Take) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: t.Currency},
+		{Name: "Recipient", Value: t.Recipient},
+		{Name: "Amount", Value: t.Amount},
+	}
+}
+
 func DecodeTake(calldata []byte, offset int) (Take, error) {
 	var t Take
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return t, fmt.Errorf("invalid %s input count; %w", err)
+		return t, fmt.Errorf("invalid %s input count; %w", TAKE, err)
 	} else if count != 0x60 {
 		return t, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", TAKE, count)
 	}
@@ -56,17 +70,29 @@ func (t TakeAll) Type() Type {
 
 func (t TakeAll) MarshalJSON() ([]byte, error) {
 	type Alias TakeAll
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(t), TAKE_ALL.String()})
 }
 
+func (t *TakeAll) UnmarshalJSON(data []byte) error {
+	type Alias TakeAll
+	return jsonx.Unmarshal(data, (*Alias)(t))
+}
+
+func (t TakeAll) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: t.Currency},
+		{Name: "MinAmount", Value: t.MinAmount},
+	}
+}
+
 func DecodeTakeAll(calldata []byte, offset int) (TakeAll, error) {
 	var t TakeAll
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return t, fmt.Errorf("invalid %s input count; %w", err)
+		return t, fmt.Errorf("invalid %s input count; %w", TAKE_ALL, err)
 	} else if count != 0x40 {
 		return t, fmt.Errorf("invalid %s input count; expected 0x40 but got 0x%x", TAKE, count)
 	}
@@ -90,17 +116,30 @@ func (t TakePortion) Type() Type {
 
 func (t TakePortion) MarshalJSON() ([]byte, error) {
 	type Alias TakePortion
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(t), TAKE_PORTION.String()})
 }
 
+func (t *TakePortion) UnmarshalJSON(data []byte) error {
+	type Alias TakePortion
+	return jsonx.Unmarshal(data, (*Alias)(t))
+}
+
+func (t TakePortion) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency", Value: t.Currency},
+		{Name: "Recipient", Value: t.Recipient},
+		{Name: "BIPs", Value: t.BIPs},
+	}
+}
+
 func DecodeTakePortion(calldata []byte, offset int) (TakePortion, error) {
 	var t TakePortion
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return t, fmt.Errorf("invalid %s input count; %w", err)
+		return t, fmt.Errorf("invalid %s input count; %w", TAKE_PORTION, err)
 	} else if count != 0x60 {
 		return t, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", TAKE, count)
 	}
@@ -112,3 +151,51 @@ func DecodeTakePortion(calldata []byte, offset int) (TakePortion, error) {
 	}
 	return t, nil
 }
+
+type TakePair struct {
+	Currency0 common.Address `json:"currency0"`
+	Currency1 common.Address `json:"currency1"`
+	Recipient common.Address `json:"recipient"`
+}
+
+func (TakePair) Type() Type {
+	return TAKE_PAIR
+}
+
+func (t TakePair) MarshalJSON() ([]byte, error) {
+	type Alias TakePair
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(t), TAKE_PAIR.String()})
+}
+
+func (t *TakePair) UnmarshalJSON(data []byte) error {
+	type Alias TakePair
+	return jsonx.Unmarshal(data, (*Alias)(t))
+}
+
+func (t TakePair) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency0", Value: t.Currency0},
+		{Name: "Currency1", Value: t.Currency1},
+		{Name: "Recipient", Value: t.Recipient},
+	}
+}
+
+func DecodeTakePair(calldata []byte, offset int) (TakePair, error) {
+	var t TakePair
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return t, fmt.Errorf("invalid %s input count; %w", TAKE_PAIR, err)
+	} else if count != 0x60 {
+		return t, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", TAKE_PAIR, count)
+	}
+
+	t = TakePair{
+		Currency0: common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		Currency1: common.BytesToAddress(calldata[offset+0x40 : offset+0x60]),
+		Recipient: common.BytesToAddress(calldata[offset+0x60 : offset+0x80]),
+	}
+	return t, nil
+}
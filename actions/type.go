@@ -41,6 +41,12 @@ const (
 	V3_DECREASE_LIQUIDITY Type = 0x0c49ccbe // decreaseLiquidity((uint256,uint128,uint256,uint256,uint256))
 	V3_COLLECT            Type = 0xfc6f7865 // collect((uint256,address,uint128,uint128))
 	V3_BURN               Type = 0x42966c68 // burn(uint256)
+	// Handlers found within `v3-periphery/contracts/base/PoolInitializer.sol` and `SelfPermit.sol`
+	V3_CREATE_AND_INITIALIZE_POOL       Type = 0x13ead562 // createAndInitializePoolIfNecessary(address,address,uint24,uint160)
+	V3_SELF_PERMIT                      Type = 0xf3995c67 // selfPermit(address,uint256,uint256,uint8,bytes32,bytes32)
+	V3_SELF_PERMIT_IF_NECESSARY         Type = 0xc2e3140a // selfPermitIfNecessary(address,uint256,uint256,uint8,bytes32,bytes32)
+	V3_SELF_PERMIT_ALLOWED              Type = 0x4659a494 // selfPermitAllowed(address,uint256,uint256,uint8,bytes32,bytes32)
+	V3_SELF_PERMIT_ALLOWED_IF_NECESSARY Type = 0xa4a78f0c // selfPermitAllowedIfNecessary(address,uint256,uint256,uint8,bytes32,bytes32)
 )
 
 var errInvalidType = errors.New("invalid action type")
@@ -117,6 +123,16 @@ func (a Type) String() string {
 		return "V3_COLLECT"
 	case 0x42966c68:
 		return "V3_BURN"
+	case 0x13ead562:
+		return "V3_CREATE_AND_INITIALIZE_POOL"
+	case 0xf3995c67:
+		return "V3_SELF_PERMIT"
+	case 0xc2e3140a:
+		return "V3_SELF_PERMIT_IF_NECESSARY"
+	case 0x4659a494:
+		return "V3_SELF_PERMIT_ALLOWED"
+	case 0xa4a78f0c:
+		return "V3_SELF_PERMIT_ALLOWED_IF_NECESSARY"
 	}
 	return "UNKNOWN"
 }
@@ -180,6 +196,85 @@ func Parse(b byte) (t Type, err error) {
 	return
 }
 
+// ParseName parses the action type named name, as returned by String.
+func ParseName(name string) (t Type, err error) {
+	switch name {
+	case "INCREASE_LIQUIDITY":
+		t = INCREASE_LIQUIDITY
+	case "DECREASE_LIQUIDITY":
+		t = DECREASE_LIQUIDITY
+	case "MINT_POSITION":
+		t = MINT_POSITION
+	case "BURN_POSITION":
+		t = BURN_POSITION
+	case "INCREASE_LIQUIDITY_FROM_DELTAS":
+		t = INCREASE_LIQUIDITY_FROM_DELTAS
+	case "MINT_POSITION_FROM_DELTAS":
+		t = MINT_POSITION_FROM_DELTAS
+	case "SWAP_EXACT_IN_SINGLE":
+		t = SWAP_EXACT_IN_SINGLE
+	case "SWAP_EXACT_IN":
+		t = SWAP_EXACT_IN
+	case "SWAP_EXACT_OUT_SINGLE":
+		t = SWAP_EXACT_OUT_SINGLE
+	case "SWAP_EXACT_OUT":
+		t = SWAP_EXACT_OUT
+	case "DONATE":
+		t = DONATE
+	case "SETTLE":
+		t = SETTLE
+	case "SETTLE_ALL":
+		t = SETTLE_ALL
+	case "SETTLE_PAIR":
+		t = SETTLE_PAIR
+	case "TAKE":
+		t = TAKE
+	case "TAKE_ALL":
+		t = TAKE_ALL
+	case "TAKE_PORTION":
+		t = TAKE_PORTION
+	case "TAKE_PAIR":
+		t = TAKE_PAIR
+	case "CLOSE_CURRENCY":
+		t = CLOSE_CURRENCY
+	case "CLEAR_OR_TAKE":
+		t = CLEAR_OR_TAKE
+	case "SWEEP":
+		t = SWEEP
+	case "WRAP":
+		t = WRAP
+	case "UNWRAP":
+		t = UNWRAP
+	case "MINT_6909":
+		t = MINT_6909
+	case "BURN_6909":
+		t = BURN_6909
+	case "V3_MINT":
+		t = V3_MINT
+	case "V3_INCREASE_LIQUIDITY":
+		t = V3_INCREASE_LIQUIDITY
+	case "V3_DECREASE_LIQUIDITY":
+		t = V3_DECREASE_LIQUIDITY
+	case "V3_COLLECT":
+		t = V3_COLLECT
+	case "V3_BURN":
+		t = V3_BURN
+	case "V3_CREATE_AND_INITIALIZE_POOL":
+		t = V3_CREATE_AND_INITIALIZE_POOL
+	case "V3_SELF_PERMIT":
+		t = V3_SELF_PERMIT
+	case "V3_SELF_PERMIT_IF_NECESSARY":
+		t = V3_SELF_PERMIT_IF_NECESSARY
+	case "V3_SELF_PERMIT_ALLOWED":
+		t = V3_SELF_PERMIT_ALLOWED
+	case "V3_SELF_PERMIT_ALLOWED_IF_NECESSARY":
+		t = V3_SELF_PERMIT_ALLOWED_IF_NECESSARY
+	default:
+		err = fmt.Errorf("invalid action type %q", name)
+	}
+	return
+}
+
 func ParseV3(i int64) (t Type, err error) {
 	switch i {
 	case 0x88316456:
@@ -192,6 +287,16 @@ func ParseV3(i int64) (t Type, err error) {
 		t = V3_COLLECT
 	case 0x42966c68:
 		t = V3_BURN
+	case 0x13ead562:
+		t = V3_CREATE_AND_INITIALIZE_POOL
+	case 0xf3995c67:
+		t = V3_SELF_PERMIT
+	case 0xc2e3140a:
+		t = V3_SELF_PERMIT_IF_NECESSARY
+	case 0x4659a494:
+		t = V3_SELF_PERMIT_ALLOWED
+	case 0xa4a78f0c:
+		t = V3_SELF_PERMIT_ALLOWED_IF_NECESSARY
 	default:
 		err = fmt.Errorf("invalid action type 0x%x", i)
 	}
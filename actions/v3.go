@@ -2,27 +2,28 @@ package actions
 
 import (
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type V3Mint struct {
-	Token0         common.Address
-	Token1         common.Address
-	Fee            uint
-	TickLower      int
-	TickUpper      int
-	Amount0Desired *big.Int
-	Amount1Desired *big.Int
-	Amount0Min     *big.Int
-	Amount1Min     *big.Int
-	Recipient      common.Address
-	Deadline       time.Time
+	Token0         common.Address `json:"token0"`
+	Token1         common.Address `json:"token1"`
+	Fee            *big.Int       `json:"fee"`
+	TickLower      *big.Int       `json:"tickLower"`
+	TickUpper      *big.Int       `json:"tickUpper"`
+	Amount0Desired *big.Int       `json:"amount0Desired"`
+	Amount1Desired *big.Int       `json:"amount1Desired"`
+	Amount0Min     *big.Int       `json:"amount0Min"`
+	Amount1Min     *big.Int       `json:"amount1Min"`
+	Recipient      common.Address `json:"recipient"`
+	Deadline       time.Time      `json:"deadline"`
 }
 
 func (V3Mint) Type() Type {
@@ -31,39 +32,56 @@ func (V3Mint) Type() Type {
 
 func (m V3Mint) MarshalJSON() ([]byte, error) {
 	type Alias V3Mint
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(m), V3_MINT.String()})
 }
 
+func (m *V3Mint) UnmarshalJSON(data []byte) error {
+	type Alias V3Mint
+	return jsonx.Unmarshal(data, (*Alias)(m))
+}
+
+func (m V3Mint) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token0", Value: m.Token0},
+		{Name: "Token1", Value: m.Token1},
+		{Name: "Fee", Value: m.Fee},
+		{Name: "TickLower", Value: m.TickLower},
+		{Name: "TickUpper", Value: m.TickUpper},
+		{Name: "Amount0Desired", Value: m.Amount0Desired},
+		{Name: "Amount1Desired", Value: m.Amount1Desired},
+		{Name: "Amount0Min", Value: m.Amount0Min},
+		{Name: "Amount1Min", Value: m.Amount1Min},
+		{Name: "Recipient", Value: m.Recipient},
+		{Name: "Deadline", Value: m.Deadline},
+	}
+}
+
 func DecodeV3Mint(calldata []byte, offset int) (V3Mint, error) {
 	a := V3Mint{
 		Token0:         common.BytesToAddress(calldata[offset : offset+0x20]),
 		Token1:         common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
-		Fee:            uint(new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]).Uint64()),
-		TickLower:      int(new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]).Int64()),
-		TickUpper:      int(new(big.Int).SetBytes(calldata[offset+0x80 : offset+0xa0]).Int64()),
+		Fee:            new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		TickLower:      hex.SignedInt(calldata[offset+0x60 : offset+0x80]),
+		TickUpper:      hex.SignedInt(calldata[offset+0x80 : offset+0xa0]),
 		Amount0Desired: new(big.Int).SetBytes(calldata[offset+0xa0 : offset+0xc0]),
 		Amount1Desired: new(big.Int).SetBytes(calldata[offset+0xc0 : offset+0xe0]),
 		Amount0Min:     new(big.Int).SetBytes(calldata[offset+0xe0 : offset+0x100]),
 		Amount1Min:     new(big.Int).SetBytes(calldata[offset+0x100 : offset+0x120]),
 		Recipient:      common.BytesToAddress(calldata[offset+0x120 : offset+0x140]),
 	}
-	deadline, err := hex.Int64(calldata[offset+0x140 : offset+0x160])
-	if err != nil {
-		return a, fmt.Errorf("invalid deadline; %w", err)
-	}
-	a.Deadline = time.Unix(deadline, 0)
+	a.Deadline = hex.Time(calldata[offset+0x140 : offset+0x160])
 	return a, nil
 }
 
 type V3IncreaseLiquidity struct {
 	TokenID        *big.Int  `json:"tokenId"`
-	Amount0Desired *big.Int  `json:"amount0desired"`
-	Amount1Desired *big.Int  `json:"amount1desired"`
-	Amount0Min     *big.Int  `json:"amount0min"`
-	Amount1Min     *big.Int  `json:"amount1min"`
+	Amount0Desired *big.Int  `json:"amount0Desired"`
+	Amount1Desired *big.Int  `json:"amount1Desired"`
+	Amount0Min     *big.Int  `json:"amount0Min"`
+	Amount1Min     *big.Int  `json:"amount1Min"`
 	Deadline       time.Time `json:"deadline"`
 }
 
@@ -73,12 +91,28 @@ func (V3IncreaseLiquidity) Type() Type {
 
 func (l V3IncreaseLiquidity) MarshalJSON() ([]byte, error) {
 	type Alias V3IncreaseLiquidity
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(l), V3_INCREASE_LIQUIDITY.String()})
 }
 
+func (l *V3IncreaseLiquidity) UnmarshalJSON(data []byte) error {
+	type Alias V3IncreaseLiquidity
+	return jsonx.Unmarshal(data, (*Alias)(l))
+}
+
+func (l V3IncreaseLiquidity) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: l.TokenID},
+		{Name: "Amount0Desired", Value: l.Amount0Desired},
+		{Name: "Amount1Desired", Value: l.Amount1Desired},
+		{Name: "Amount0Min", Value: l.Amount0Min},
+		{Name: "Amount1Min", Value: l.Amount1Min},
+		{Name: "Deadline", Value: l.Deadline},
+	}
+}
+
 func DecodeV3IncreaseLiquidity(calldata []byte, offset int) (V3IncreaseLiquidity, error) {
 	a := V3IncreaseLiquidity{
 		TokenID:        new(big.Int).SetBytes(calldata[offset : offset+0x20]),
@@ -87,19 +121,15 @@ func DecodeV3IncreaseLiquidity(calldata []byte, offset int) (V3IncreaseLiquidity
 		Amount0Min:     new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
 		Amount1Min:     new(big.Int).SetBytes(calldata[offset+0x80 : offset+0xa0]),
 	}
-	deadline, err := hex.Int64(calldata[offset+0x140 : offset+0x160])
-	if err != nil {
-		return a, fmt.Errorf("invalid deadline; %w", err)
-	}
-	a.Deadline = time.Unix(deadline, 0)
+	a.Deadline = hex.Time(calldata[offset+0xa0 : offset+0xc0])
 	return a, nil
 }
 
 type V3DecreaseLiquidity struct {
 	TokenID    *big.Int  `json:"tokenId"`
 	Liquidity  *big.Int  `json:"liquidity"`
-	Amount0Min *big.Int  `json:"amount0min"`
-	Amount1Min *big.Int  `json:"amount1min"`
+	Amount0Min *big.Int  `json:"amount0Min"`
+	Amount1Min *big.Int  `json:"amount1Min"`
 	Deadline   time.Time `json:"deadline"`
 }
 
@@ -109,12 +139,27 @@ func (V3DecreaseLiquidity) Type() Type {
 
 func (l V3DecreaseLiquidity) MarshalJSON() ([]byte, error) {
 	type Alias V3DecreaseLiquidity
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(l), V3_DECREASE_LIQUIDITY.String()})
 }
 
+func (l *V3DecreaseLiquidity) UnmarshalJSON(data []byte) error {
+	type Alias V3DecreaseLiquidity
+	return jsonx.Unmarshal(data, (*Alias)(l))
+}
+
+func (l V3DecreaseLiquidity) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: l.TokenID},
+		{Name: "Liquidity", Value: l.Liquidity},
+		{Name: "Amount0Min", Value: l.Amount0Min},
+		{Name: "Amount1Min", Value: l.Amount1Min},
+		{Name: "Deadline", Value: l.Deadline},
+	}
+}
+
 func DecodeV3DecreaseLiquidity(calldata []byte, offset int) (V3DecreaseLiquidity, error) {
 	a := V3DecreaseLiquidity{
 		TokenID:    new(big.Int).SetBytes(calldata[offset : offset+0x20]),
@@ -122,14 +167,15 @@ func DecodeV3DecreaseLiquidity(calldata []byte, offset int) (V3DecreaseLiquidity
 		Amount0Min: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
 		Amount1Min: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
 	}
+	a.Deadline = hex.Time(calldata[offset+0x80 : offset+0xa0])
 	return a, nil
 }
 
 type V3Collect struct {
 	TokenID    *big.Int       `json:"tokenId"`
 	Recipient  common.Address `json:"recipient"`
-	Amount0Max *big.Int       `json:"amount0max"`
-	Amount1Max *big.Int       `json:"amount1max"`
+	Amount0Max *big.Int       `json:"amount0Max"`
+	Amount1Max *big.Int       `json:"amount1Max"`
 }
 
 func (V3Collect) Type() Type {
@@ -138,12 +184,26 @@ func (V3Collect) Type() Type {
 
 func (c V3Collect) MarshalJSON() ([]byte, error) {
 	type Alias V3Collect
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(c), V3_COLLECT.String()})
 }
 
+func (c *V3Collect) UnmarshalJSON(data []byte) error {
+	type Alias V3Collect
+	return jsonx.Unmarshal(data, (*Alias)(c))
+}
+
+func (c V3Collect) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: c.TokenID},
+		{Name: "Recipient", Value: c.Recipient},
+		{Name: "Amount0Max", Value: c.Amount0Max},
+		{Name: "Amount1Max", Value: c.Amount1Max},
+	}
+}
+
 func DecodeV3Collect(calldata []byte, offset int) (V3Collect, error) {
 	a := V3Collect{
 		TokenID:    new(big.Int).SetBytes(calldata[offset : offset+0x20]),
@@ -164,15 +224,204 @@ func (V3Burn) Type() Type {
 
 func (c V3Burn) MarshalJSON() ([]byte, error) {
 	type Alias V3Burn
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(c), V3_BURN.String()})
 }
 
+func (c *V3Burn) UnmarshalJSON(data []byte) error {
+	type Alias V3Burn
+	return jsonx.Unmarshal(data, (*Alias)(c))
+}
+
+func (c V3Burn) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenID", Value: c.TokenID},
+	}
+}
+
 func DecodeV3Burn(calldata []byte, offset int) (V3Burn, error) {
 	a := V3Burn{
 		TokenID: new(big.Int).SetBytes(calldata[offset : offset+0x20]),
 	}
 	return a, nil
 }
+
+// V3CreateAndInitializePool creates the pool of its tokens and fee, initializing it at its price unless it's already
+// initialized.
+//
+// see: v3-periphery/contracts/base/PoolInitializer.sol
+type V3CreateAndInitializePool struct {
+	Token0       common.Address `json:"token0"`
+	Token1       common.Address `json:"token1"`
+	Fee          *big.Int       `json:"fee"`
+	SqrtPriceX96 *big.Int       `json:"sqrtPriceX96"`
+}
+
+func (V3CreateAndInitializePool) Type() Type {
+	return V3_CREATE_AND_INITIALIZE_POOL
+}
+
+func (p V3CreateAndInitializePool) MarshalJSON() ([]byte, error) {
+	type Alias V3CreateAndInitializePool
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), V3_CREATE_AND_INITIALIZE_POOL.String()})
+}
+
+func (p *V3CreateAndInitializePool) UnmarshalJSON(data []byte) error {
+	type Alias V3CreateAndInitializePool
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p V3CreateAndInitializePool) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token0", Value: p.Token0},
+		{Name: "Token1", Value: p.Token1},
+		{Name: "Fee", Value: p.Fee},
+		{Name: "SqrtPriceX96", Value: p.SqrtPriceX96},
+	}
+}
+
+func DecodeV3CreateAndInitializePool(calldata []byte, offset int) (V3CreateAndInitializePool, error) {
+	a := V3CreateAndInitializePool{
+		Token0:       common.BytesToAddress(calldata[offset : offset+0x20]),
+		Token1:       common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		Fee:          new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		SqrtPriceX96: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
+	}
+	return a, nil
+}
+
+// V3SelfPermit approves the NonfungiblePositionManager to spend Value of a token with its EIP-2612 permit, only when
+// its allowance is insufficient when IfNecessary is set.
+//
+// see: v3-periphery/contracts/base/SelfPermit.sol
+type V3SelfPermit struct {
+	Token    common.Address `json:"token"`
+	Value    *big.Int       `json:"value"`
+	Deadline time.Time      `json:"deadline"`
+	// Sig is the 65 byte signature, R || S || V.
+	Sig         []byte `json:"sig"`
+	IfNecessary bool   `json:"-"`
+}
+
+func (p V3SelfPermit) Type() Type {
+	if p.IfNecessary {
+		return V3_SELF_PERMIT_IF_NECESSARY
+	}
+	return V3_SELF_PERMIT
+}
+
+func (p V3SelfPermit) MarshalJSON() ([]byte, error) {
+	type Alias V3SelfPermit
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), p.Type().String()})
+}
+
+func (p *V3SelfPermit) UnmarshalJSON(data []byte) error {
+	type Alias V3SelfPermit
+	var t struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	} else if err := jsonx.Unmarshal(data, (*Alias)(p)); err != nil {
+		return err
+	}
+	p.IfNecessary = t.Type == V3_SELF_PERMIT_IF_NECESSARY.String()
+	return nil
+}
+
+func (p V3SelfPermit) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Value", Value: p.Value},
+		{Name: "Deadline", Value: p.Deadline},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
+func DecodeV3SelfPermit(calldata []byte, offset int, ifNecessary bool) (V3SelfPermit, error) {
+	a := V3SelfPermit{
+		Token:       common.BytesToAddress(calldata[offset : offset+0x20]),
+		Value:       new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		Deadline:    hex.Time(calldata[offset+0x40 : offset+0x60]),
+		IfNecessary: ifNecessary,
+	}
+	a.Sig = selfPermitSig(calldata[offset+0x60 : offset+0xc0])
+	return a, nil
+}
+
+// V3SelfPermitAllowed approves the NonfungiblePositionManager to spend any amount of a token with its DAI style permit,
+// only when its allowance is insufficient when IfNecessary is set.
+//
+// see: v3-periphery/contracts/base/SelfPermit.sol
+type V3SelfPermitAllowed struct {
+	Token  common.Address `json:"token"`
+	Nonce  *big.Int       `json:"nonce"`
+	Expiry time.Time      `json:"expiry"`
+	// Sig is the 65 byte signature, R || S || V.
+	Sig         []byte `json:"sig"`
+	IfNecessary bool   `json:"-"`
+}
+
+func (p V3SelfPermitAllowed) Type() Type {
+	if p.IfNecessary {
+		return V3_SELF_PERMIT_ALLOWED_IF_NECESSARY
+	}
+	return V3_SELF_PERMIT_ALLOWED
+}
+
+func (p V3SelfPermitAllowed) MarshalJSON() ([]byte, error) {
+	type Alias V3SelfPermitAllowed
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), p.Type().String()})
+}
+
+func (p *V3SelfPermitAllowed) UnmarshalJSON(data []byte) error {
+	type Alias V3SelfPermitAllowed
+	var t struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	} else if err := jsonx.Unmarshal(data, (*Alias)(p)); err != nil {
+		return err
+	}
+	p.IfNecessary = t.Type == V3_SELF_PERMIT_ALLOWED_IF_NECESSARY.String()
+	return nil
+}
+
+func (p V3SelfPermitAllowed) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Nonce", Value: p.Nonce},
+		{Name: "Expiry", Value: p.Expiry},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
+func DecodeV3SelfPermitAllowed(calldata []byte, offset int, ifNecessary bool) (V3SelfPermitAllowed, error) {
+	a := V3SelfPermitAllowed{
+		Token:       common.BytesToAddress(calldata[offset : offset+0x20]),
+		Nonce:       new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		Expiry:      hex.Time(calldata[offset+0x40 : offset+0x60]),
+		IfNecessary: ifNecessary,
+	}
+	a.Sig = selfPermitSig(calldata[offset+0x60 : offset+0xc0])
+	return a, nil
+}
+
+// selfPermitSig packs the ABI encoded (uint8 v, bytes32 r, bytes32 s) of a self permit as R || S || V.
+func selfPermitSig(b []byte) []byte {
+	sig := make([]byte, 0, 65)
+	sig = append(sig, b[0x20:0x60]...)
+	return append(sig, b[0x1f])
+}
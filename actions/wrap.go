@@ -1,13 +1,14 @@
 package actions
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 var (
@@ -27,17 +28,30 @@ func (Wrap) Type() Type {
 
 func (w Wrap) MarshalJSON() ([]byte, error) {
 	type Alias Wrap
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(w), WRAP.String()})
 }
 
+func (w *Wrap) UnmarshalJSON(data []byte) error {
+	type Alias Wrap
+	return jsonx.Unmarshal(data, (*Alias)(w))
+}
+
+func (w Wrap) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Amount", Value: w.Amount},
+		{Name: "Currency", Value: w.Currency},
+		{Name: "Wrapped", Value: w.Wrapped},
+	}
+}
+
 func DecodeWrap(calldata []byte, offset int) (Wrap, error) {
 	var w Wrap
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return w, fmt.Errorf("invalid %s input count; %w", err)
+		return w, fmt.Errorf("invalid %s input count; %w", WRAP, err)
 	} else if count != 0x20 {
 		return w, fmt.Errorf("invalid %s input count; expected 0x20 but got 0x%x", WRAP, count)
 	}
@@ -52,9 +66,9 @@ func DecodeWrap(calldata []byte, offset int) (Wrap, error) {
 }
 
 type Unwrap struct {
-	Amount   *big.Int
-	Currency common.Address
-	Wrapped  common.Address
+	Amount   *big.Int       `json:"amount"`
+	Currency common.Address `json:"currency"`
+	Wrapped  common.Address `json:"wrapped"`
 }
 
 func (Unwrap) Type() Type {
@@ -63,17 +77,30 @@ func (Unwrap) Type() Type {
 
 func (w Unwrap) MarshalJSON() ([]byte, error) {
 	type Alias Unwrap
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(w), UNWRAP.String()})
 }
 
+func (w *Unwrap) UnmarshalJSON(data []byte) error {
+	type Alias Unwrap
+	return jsonx.Unmarshal(data, (*Alias)(w))
+}
+
+func (w Unwrap) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Amount", Value: w.Amount},
+		{Name: "Currency", Value: w.Currency},
+		{Name: "Wrapped", Value: w.Wrapped},
+	}
+}
+
 func DecodeUnwrap(calldata []byte, offset int) (Unwrap, error) {
 	var w Unwrap
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return w, fmt.Errorf("invalid %s input count; %w", err)
+		return w, fmt.Errorf("invalid %s input count; %w", UNWRAP, err)
 	} else if count != 0x20 {
 		return w, fmt.Errorf("invalid %s input count; expected 0x20 but got 0x%x", UNWRAP, count)
 	}
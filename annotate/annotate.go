@@ -0,0 +1,662 @@
+// Package annotate maps the bytes of calldata to the fields decoded from them, for debugging decoding.
+//
+// The words of an execute call's plan, and of the actions of its V4 swaps, which hold offsets and lengths or the types
+// of commands and actions, are labelled from the layouts the decoders read. The words of each command input and action
+// param are then changed, one at a time, and just that command or action decoded again; the fields which change are
+// those decoded from the word. Other calls are annotated the same way as a whole, decoding the whole call again as each
+// word changes.
+//
+// A word changing no field wasn't consumed by the decoders, while a word which fails decoding when changed holds the
+// layout of a value. Within command inputs and action params, the offset and length words of dynamic values, the lists
+// and bytes of fields, are recognised from the values they hold, as the lengths of values following them, or the
+// locations of those lengths or of tuples, while any other word failing decoding is labelled "layout". Words are
+// counted from the method selector, so calls nested within calldata, such as those of a multicall, may not be aligned
+// with them, and a word may hold parts of neighbouring fields.
+package annotate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/diff"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+)
+
+// Word is the method selector, or a 32 byte word following it, along with the fields decoded from it. The last word
+// may be shorter when the calldata isn't a whole number of words.
+type Word struct {
+	Offset int
+	Data   []byte
+	// Fields are the paths of the fields decoded from the word, such as
+	// Commands[1].V4_SWAP.Actions[2].SETTLE_ALL.MaxAmount, where list items are followed by their type.
+	Fields []string
+	// Layout describes a word holding the layout of values rather than a value, such as "inputs offset",
+	// "Commands[1].V4_SWAP actions: SETTLE_ALL, TAKE_ALL" or "Commands[0].V2_SWAP_EXACT_IN.Path length", or is
+	// "layout" when decoding fails once the word changes.
+	Layout string
+}
+
+// Consumed reports whether the word was read by the decoders, which the selector always is.
+func (w Word) Consumed() bool {
+	return w.Offset == 0 || w.Layout != "" || len(w.Fields) > 0
+}
+
+// Label returns the fields of the word, relative to their common path when there are more than two, such as the
+// packed hops of a V3 path, just that path when there are more than six, or its layout.
+func (w Word) Label() string {
+	switch {
+	case w.Offset == 0:
+		return "selector"
+	case len(w.Fields) == 0:
+		return w.Layout
+	case len(w.Fields) > 6:
+		return fmt.Sprintf("%s (%d fields)", commonPath(w.Fields), len(w.Fields))
+	case len(w.Fields) > 2:
+		common := commonPath(w.Fields)
+		if common == "call" {
+			break
+		}
+		fields := make([]string, len(w.Fields))
+		for i, f := range w.Fields {
+			fields[i] = strings.TrimPrefix(f, common+".")
+		}
+		return fmt.Sprintf("%s.{%s}", common, strings.Join(fields, ", "))
+	}
+	return strings.Join(w.Fields, ", ")
+}
+
+// Calldata decodes calldata of any supported call, using unidecode.DecodeCall, returning its words along with the
+// fields decoded from each.
+func Calldata(calldata []byte) ([]Word, error) {
+	v, err := decode(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	a := annotator{calldata: calldata, changed: append([]byte(nil), calldata...)}
+	a.words = []Word{{Offset: 0, Data: calldata[:4]}}
+	for offset := 4; offset < len(calldata); offset += 0x20 {
+		a.words = append(a.words, Word{Offset: offset, Data: calldata[offset:min(offset+0x20, len(calldata))]})
+	}
+
+	if e, ok := v.(unidecode.Execute); ok && unidecode.MessageType(calldata) == unidecode.ExecuteMessage {
+		// The plan is the arguments following the selector, with the deadline after its offsets
+		if e.Deadline != nil {
+			a.word(0x44).Fields = []string{"Deadline"}
+		}
+		if err := a.plan("", 4, e.Commands); err != nil {
+			return nil, err
+		}
+		return a.words, nil
+	}
+	a.fields("", 4, len(calldata), func(calldata []byte) ([]field.Field, error) {
+		v, err := decode(calldata)
+		return diff.CallFields(v), err
+	})
+	return a.words, nil
+}
+
+// annotator labels the words of calldata, decoding changed, a copy of calldata, as each word of it changes.
+type annotator struct {
+	calldata []byte
+	changed  []byte
+	words    []Word
+}
+
+// word returns the word holding the byte at offset.
+func (a *annotator) word(offset int) *Word {
+	if offset < 4 {
+		return &a.words[0]
+	}
+	return &a.words[min((offset-4)/0x20+1, len(a.words)-1)]
+}
+
+// layout describes the word at offset, of the value at path, unless it's already described.
+func (a *annotator) layout(offset int, path, layout string) {
+	if offset < 0 || offset >= len(a.calldata) {
+		return
+	}
+	if path != "" {
+		layout = path + " " + layout
+	}
+	if w := a.word(offset); w.Layout == "" {
+		w.Layout = layout
+	}
+}
+
+// types describes the words holding the types at offset, such as those of the commands of a plan.
+func (a *annotator) types(offset int, path, name string, types []string) {
+	for i := 0; i < len(types); {
+		w := a.word(offset + i)
+		n := min(w.Offset+len(w.Data)-offset-i, len(types)-i)
+		if n <= 0 {
+			return
+		}
+		a.layout(offset+i, path, fmt.Sprintf("%s: %s", name, strings.Join(types[i:i+n], ", ")))
+		i += n
+	}
+}
+
+// plan labels the words of the plan whose first word is at offset, holding cmds, and those of each command input.
+func (a *annotator) plan(path string, offset int, cmds []commands.Command) error {
+	l, err := commands.DecodePlanLayout(a.calldata, offset)
+	if err != nil {
+		return err
+	}
+	a.layout(l.Offset, path, "commands offset")
+	a.layout(l.Offset+0x20, path, "inputs offset")
+	a.layout(l.CommandsAt, path, "commands length")
+	types := make([]string, len(l.Types))
+	for i, t := range l.Types {
+		types[i] = t.String()
+	}
+	a.types(l.CommandsAt+0x20, path, "commands", types)
+	a.layout(l.InputsAt, path, "inputs length")
+
+	for i, t := range l.Types {
+		p := join(path, fmt.Sprintf("Commands[%d].%s", i, t))
+		at := l.Inputs[i]
+		a.layout(l.InputsAt+0x20+i*0x20, p, "input offset")
+		a.layout(at, p, "input length")
+
+		switch c := cmds[i].(type) {
+		case commands.ExecuteSubPlan:
+			if err := a.plan(p, at+0x20, c.Commands); err != nil {
+				return err
+			}
+		case commands.V4Swap:
+			if err := a.actions(p, at+0x20); err != nil {
+				return err
+			}
+		default:
+			a.fields(p, at+0x20, at+0x20+a.length(at), func(calldata []byte) ([]field.Field, error) {
+				c, err := commands.Decode(t, calldata, at)
+				if err != nil {
+					return nil, err
+				}
+				return commandFields(c), nil
+			})
+		}
+	}
+	return nil
+}
+
+// actions labels the words of the actions whose head starts at offset, and those of each action param.
+func (a *annotator) actions(path string, offset int) error {
+	l, err := actions.DecodeLayout(a.calldata, offset)
+	if err != nil {
+		return err
+	}
+	a.layout(l.Offset, path, "actions offset")
+	a.layout(l.Offset+0x20, path, "params offset")
+	a.layout(l.ActionsAt, path, "actions length")
+	types := make([]string, len(l.Types))
+	for i, t := range l.Types {
+		types[i] = t.String()
+	}
+	a.types(l.ActionsAt+0x20, path, "actions", types)
+	a.layout(l.ParamsAt, path, "params length")
+
+	for i, t := range l.Types {
+		p := join(path, fmt.Sprintf("Actions[%d].%s", i, t))
+		at := l.Params[i]
+		a.layout(l.ParamsAt+0x20+i*0x20, p, "param offset")
+		a.layout(at, p, "param length")
+		a.fields(p, at+0x20, at+0x20+a.length(at), func(calldata []byte) ([]field.Field, error) {
+			act, err := actions.Decode(t, calldata, at)
+			if err != nil {
+				return nil, err
+			}
+			return act.Fields(), nil
+		})
+	}
+	return nil
+}
+
+// length returns the length word at offset, which the layout decoders have checked.
+func (a *annotator) length(offset int) int {
+	n, _ := hex.Int(a.calldata[offset : offset+0x20])
+	return n
+}
+
+// fields labels the words from start to end with the fields, prefixed by path, which change as each word changes,
+// where decodeFields decodes the fields of the value holding the words.
+func (a *annotator) fields(path string, start, end int, decodeFields func(calldata []byte) ([]field.Field, error)) {
+	decodeFields = recovered(decodeFields)
+	fields, err := decodeFields(a.calldata)
+	if err != nil {
+		return
+	}
+	types := make(map[string]string)
+	itemTypes("", fields, types)
+	dyn, order := make(map[string]int), make(map[string]int)
+	walkFields(path, "", fields, types, dyn, order)
+
+	failed := make(map[int]bool)
+	for i := range a.words[1:] {
+		w := &a.words[i+1]
+		if w.Offset+len(w.Data) <= start || w.Offset >= end || w.Layout != "" {
+			continue
+		}
+		b := a.changed[w.Offset : w.Offset+len(w.Data)]
+
+		// The last byte catches words holding a single value, such as a bool which fails decoding when other bytes
+		// are set, while every byte catches packed values, such as the hops of a path, which may not reach it.
+		seen := make(map[string]bool)
+		layout := false
+		for _, change := range []func(b []byte){lastByte, everyByte} {
+			change(b)
+			// Decoded values may hold slices of the changed calldata, so the word is restored once they're compared
+			if changed, err := decodeFields(a.changed); err != nil {
+				layout = true
+			} else {
+				for _, c := range diff.Fields(fields, changed) {
+					if p := join(path, typedPath(c.Path, types)); !seen[p] {
+						seen[p] = true
+						w.Fields = append(w.Fields, p)
+					}
+				}
+			}
+			copy(b, w.Data)
+		}
+		if len(w.Fields) > 0 {
+			w.Fields = leaves(w.Fields)
+			sort.SliceStable(w.Fields, func(i, j int) bool { return order[w.Fields[i]] < order[w.Fields[j]] })
+		} else if layout {
+			failed[w.Offset] = true
+		}
+	}
+
+	// Whole calls are decoded into the commands of an execute call, whose fields don't follow the calldata's layout
+	if path != "" {
+		a.dynamic(path, start, end, dyn, failed)
+	}
+	for offset := range failed {
+		a.layout(offset, path, "layout")
+	}
+}
+
+// dynamic describes the offset and length words of the dynamic values between start and end, the lists and bytes of
+// dyn, where failed holds the words which fail decoding when changed.
+//
+// A length word is followed by the words of its value, or the offsets of its items, and holds its number of items or
+// bytes. Where a list is decoded from packed bytes, such as a V3 path, the length is of the bytes, which the words of
+// the list span. An offset word holds the location of a value from the start of the tuple or list holding it, being
+// where the value starts or its length word.
+func (a *annotator) dynamic(path string, start, end int, dyn map[string]int, failed map[int]bool) {
+	var words []*Word
+	for i := range a.words[1:] {
+		w := &a.words[i+1]
+		if w.Offset >= start && w.Offset+0x20 <= end && len(w.Data) == 0x20 && w.Layout == "" {
+			words = append(words, w)
+		}
+	}
+	// under reports whether the fields of w are all within the value at p
+	under := func(w *Word, p string) bool {
+		for _, f := range w.Fields {
+			if !within(f, p) {
+				return false
+			}
+		}
+		return true
+	}
+
+	lengths := make(map[int]string)
+	for i, w := range words {
+		n, err := hex.Int(w.Data)
+		if err != nil {
+			continue
+		}
+		// The items of lists of dynamic values, such as V4 path keys, are preceded by their offsets
+		next := -1
+		for j := i + 1; j < len(words) && j <= i+1+n; j++ {
+			if len(words[j].Fields) > 0 {
+				next = j
+				break
+			} else if !failed[words[j].Offset] {
+				break
+			}
+		}
+
+		var candidates []string
+		if next >= 0 {
+			for p := words[next].Fields[0]; len(p) > len(path); p = parent(p) {
+				if _, ok := dyn[p]; ok {
+					candidates = append(candidates, p)
+				}
+			}
+		}
+		if n == 0 && len(w.Fields) > 0 {
+			// Empty values have no words, but gain some when their length changes
+			if m, ok := dyn[w.Fields[0]]; ok && m == 0 {
+				candidates = append(candidates, w.Fields[0])
+			} else if m, ok := dyn[parent(w.Fields[0])]; ok && m == 0 {
+				candidates = append(candidates, parent(w.Fields[0]))
+			}
+		}
+
+		for _, p := range candidates {
+			m, ok := dyn[p]
+			packed := m > 0 && (n+0x1f)/0x20 == spanned(words[i+1:], p)
+			if !ok || (m != n && !packed) || !under(w, p) {
+				continue
+			}
+			// Words of the value before its length can only be the offsets locating it
+			before := false
+			for _, o := range words[:i] {
+				if under(o, p) && len(o.Fields) > 0 && !offsetLike(o, end-start) {
+					before = true
+					break
+				}
+			}
+			if !before {
+				lengths[w.Offset] = p
+				break
+			}
+		}
+	}
+
+	// Each offset is from the start of the value holding it, whose path the bases are mapped to
+	bases := map[int]string{start: path}
+	for offset, p := range lengths {
+		if _, ok := dyn[p]; ok {
+			bases[offset+0x20] = p
+		}
+	}
+	describe := func(w *Word, p, layout string) {
+		w.Fields = nil
+		a.layout(w.Offset, p, layout)
+	}
+	for _, w := range words {
+		if p, ok := lengths[w.Offset]; ok {
+			describe(w, p, "length")
+			delete(failed, w.Offset)
+			continue
+		}
+		v, err := hex.Int(w.Data)
+		if err != nil || v == 0 || v%0x20 != 0 {
+			continue
+		}
+
+		// The innermost value holding the word, being the nearest base before it, is tried first
+		var locations []int
+		for b := range bases {
+			if b <= w.Offset && b+v > w.Offset && b+v < end {
+				locations = append(locations, b)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(locations)))
+
+		located := false
+		for _, b := range locations {
+			if p, ok := lengths[b+v]; ok && under(w, p) {
+				describe(w, p, "offset")
+				delete(failed, w.Offset)
+				located = true
+				break
+			}
+		}
+		if located || !failed[w.Offset] {
+			continue
+		}
+		for _, b := range locations {
+			at := a.word(b + v)
+			if at.Layout != "" || at.Offset != b+v {
+				continue
+			}
+			if n, err := hex.Int(at.Data); err == nil && n == 0 && failed[at.Offset] {
+				// An empty value of the tuple holding the word, located by its zero length
+				if p, ok := emptyChild(bases[b], dyn, words); ok {
+					describe(w, p, "offset")
+					describe(at, p, "length")
+					delete(failed, w.Offset)
+					delete(failed, at.Offset)
+					break
+				}
+			}
+			// A tuple, starting with a field or the offset of one, which is the whole value when located by its first
+			// word, such as a param of a struct holding bytes
+			if len(at.Fields) > 0 || failed[at.Offset] {
+				p, ok := bases[b], b == start && w.Offset == start
+				if !ok {
+					p, ok = tupleAt(words, at.Offset, bases[b])
+				}
+				if ok {
+					describe(w, p, "offset")
+					delete(failed, w.Offset)
+					bases[at.Offset] = p
+					break
+				}
+			}
+		}
+	}
+}
+
+// tupleAt returns the path of the tuple starting at offset, directly within the value at path, holding the fields of
+// the first word from it with any.
+func tupleAt(words []*Word, offset int, path string) (string, bool) {
+	for _, w := range words {
+		if w.Offset >= offset && len(w.Fields) > 0 {
+			for p := w.Fields[0]; len(p) > len(path); p = parent(p) {
+				if parent(p) == path {
+					return p, true
+				}
+			}
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// emptyChild returns the path of the only dynamic value directly within that at path without words.
+func emptyChild(path string, dyn map[string]int, words []*Word) (string, bool) {
+	var empty []string
+	for p := range dyn {
+		if parent(p) == path && !held(words, p) {
+			empty = append(empty, p)
+		}
+	}
+	if len(empty) != 1 {
+		return "", false
+	}
+	return empty[0], true
+}
+
+// spanned returns the number of words from the first of words whose fields are all within the value at p.
+func spanned(words []*Word, p string) int {
+	n := 0
+	for _, w := range words {
+		if len(w.Fields) == 0 {
+			break
+		}
+		for _, f := range w.Fields {
+			if !within(f, p) {
+				return n
+			}
+		}
+		n++
+	}
+	return n
+}
+
+// held reports whether any of words has a field within the value at p.
+func held(words []*Word, p string) bool {
+	for _, w := range words {
+		for _, f := range w.Fields {
+			if within(f, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// offsetLike reports whether w could hold the offset of a value within size bytes.
+func offsetLike(w *Word, size int) bool {
+	v, err := hex.Int(w.Data)
+	return err == nil && v > 0 && v%0x20 == 0 && v < size
+}
+
+// within reports whether the field at f is, or is held by, the value at p.
+func within(f, p string) bool {
+	return p == "" || f == p || strings.HasPrefix(f, p+".") || strings.HasPrefix(f, p+"[")
+}
+
+// parent returns the path of the value holding that at p, being the list of an item or the value of a field.
+func parent(p string) string {
+	if strings.HasSuffix(p, "]") {
+		return p[:strings.LastIndex(p, "[")]
+	} else if i := strings.LastIndex(p, "."); i >= 0 {
+		return p[:i]
+	}
+	return ""
+}
+
+// walkFields records, by their path within the value at path, the position of each of fields in decoding order, and
+// the number of items of each list, or bytes of each bytes value, where raw is the untyped path of fields.
+func walkFields(path, raw string, fields []field.Field, types map[string]string, dyn, order map[string]int) {
+	for _, f := range fields {
+		p := f.Name
+		if raw != "" {
+			p = raw + "." + f.Name
+		}
+		typed := join(path, typedPath(p, types))
+		order[typed] = len(order)
+		if f.Items != nil {
+			dyn[typed] = len(f.Items)
+		} else if b, ok := f.Value.([]byte); ok {
+			dyn[typed] = len(b)
+		}
+		walkFields(path, p, f.Fields, types, dyn, order)
+		for i, item := range f.Items {
+			walkFields(path, fmt.Sprintf("%s[%d]", p, i), item.Fields, types, dyn, order)
+		}
+	}
+}
+
+// recovered returns decodeFields, returning an error rather than panicking on malformed calldata.
+func recovered(decodeFields func(calldata []byte) ([]field.Field, error)) func(calldata []byte) ([]field.Field, error) {
+	return func(calldata []byte) (fields []field.Field, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%w; %v", unidecode.ErrInvalidCallData, r)
+			}
+		}()
+		return decodeFields(calldata)
+	}
+}
+
+// commandFields returns the fields of c followed by its actions, as diff.CallFields returns those of each command.
+func commandFields(c commands.Command) []field.Field {
+	fields := c.Fields()
+	if as := c.Actions(); len(as) > 0 {
+		actions := field.Field{Name: "Actions", Items: make([]field.Field, len(as))}
+		for i, a := range as {
+			actions.Items[i] = field.Field{Value: a.Type().String(), Fields: a.Fields()}
+		}
+		fields = append(fields, actions)
+	}
+	return fields
+}
+
+// join returns the path of the field at p within that at path.
+func join(path, p string) string {
+	if path == "" {
+		return p
+	}
+	return path + "." + p
+}
+
+// decode decodes calldata, returning an error rather than panicking on malformed calldata.
+func decode(calldata []byte) (v interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w; %v", unidecode.ErrInvalidCallData, r)
+		}
+	}()
+	return unidecode.DecodeCall(calldata)
+}
+
+func lastByte(b []byte) {
+	b[len(b)-1] ^= 0x01
+}
+
+func everyByte(b []byte) {
+	for i := range b {
+		b[i] ^= 0x01
+	}
+}
+
+// itemTypes records the type of each list item of fields, such as commands and actions, by its path.
+func itemTypes(path string, fields []field.Field, types map[string]string) {
+	for _, f := range fields {
+		p := f.Name
+		if path != "" {
+			p = path + "." + f.Name
+		}
+		itemTypes(p, f.Fields, types)
+		for i, item := range f.Items {
+			ip := fmt.Sprintf("%s[%d]", p, i)
+			if t, ok := item.Value.(string); ok {
+				types[ip] = t
+			}
+			itemTypes(ip, item.Fields, types)
+		}
+	}
+}
+
+// typedPath returns path with the type of each list item following it.
+func typedPath(path string, types map[string]string) string {
+	var b strings.Builder
+	steps := strings.Split(path, ".")
+	for i, s := range steps {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+		if t, ok := types[strings.Join(steps[:i+1], ".")]; ok {
+			b.WriteString("." + t)
+		}
+	}
+	return b.String()
+}
+
+// leaves returns paths without those of fields holding others of paths, such as a pool key along with its fee.
+func leaves(paths []string) []string {
+	var l []string
+	for _, p := range paths {
+		parent := false
+		for _, o := range paths {
+			if strings.HasPrefix(o, p+".") || strings.HasPrefix(o, p+"[") {
+				parent = true
+				break
+			}
+		}
+		if !parent {
+			l = append(l, p)
+		}
+	}
+	return l
+}
+
+// commonPath returns the steps all paths start with.
+func commonPath(paths []string) string {
+	common := strings.Split(paths[0], ".")
+	for _, p := range paths[1:] {
+		steps := strings.Split(p, ".")
+		n := 0
+		for n < len(common) && n < len(steps) && common[n] == steps[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 {
+		return "call"
+	}
+	return strings.Join(common, ".")
+}
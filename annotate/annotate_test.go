@@ -0,0 +1,182 @@
+package annotate
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// swaps executes a V2 swap, a V3 swap along a path of two hops and a V4 swap along a path of two keys, the last with
+// hook data, written one word per line.
+const swaps = `
+	3593564c
+	0000000000000000000000000000000000000000000000000000000000000060
+	00000000000000000000000000000000000000000000000000000000000000a0
+	000000000000000000000000000000000000000000000000000000006553f100
+	0000000000000000000000000000000000000000000000000000000000000003
+	0800100000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000003
+	0000000000000000000000000000000000000000000000000000000000000060
+	0000000000000000000000000000000000000000000000000000000000000180
+	00000000000000000000000000000000000000000000000000000000000002c0
+	0000000000000000000000000000000000000000000000000000000000000100
+	00000000000000000000000000000000000000000000000000000000000000aa
+	0000000000000000000000000000000000000000000000000de0b6b3a7640000
+	00000000000000000000000000000000000000000000000000000000b2d05e00
+	00000000000000000000000000000000000000000000000000000000000000a0
+	0000000000000000000000000000000000000000000000000000000000000001
+	0000000000000000000000000000000000000000000000000000000000000002
+	000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2
+	000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48
+	0000000000000000000000000000000000000000000000000000000000000120
+	00000000000000000000000000000000000000000000000000000000000000aa
+	0000000000000000000000000000000000000000000000000de0b6b3a7640000
+	00000000000000000000000000000000000000000000000029a2241af62c0000
+	00000000000000000000000000000000000000000000000000000000000000a0
+	0000000000000000000000000000000000000000000000000000000000000001
+	0000000000000000000000000000000000000000000000000000000000000042
+	c02aaa39b223fe8d0a0e5c4f27ead9083c756cc20001f4a0b86991c6218b36c1
+	d19d4a2e9eb0ce3606eb480000646b175474e89094c44da98b954eedeac49527
+	1d0f000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000480
+	0000000000000000000000000000000000000000000000000000000000000040
+	0000000000000000000000000000000000000000000000000000000000000080
+	0000000000000000000000000000000000000000000000000000000000000003
+	070c0f0000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000003
+	0000000000000000000000000000000000000000000000000000000000000060
+	0000000000000000000000000000000000000000000000000000000000000320
+	0000000000000000000000000000000000000000000000000000000000000380
+	00000000000000000000000000000000000000000000000000000000000002a0
+	0000000000000000000000000000000000000000000000000000000000000020
+	0000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000080
+	0000000000000000000000000000000000000000000000000de0b6b3a7640000
+	00000000000000000000000000000000000000000000000029a2241af62c0000
+	0000000000000000000000000000000000000000000000000000000000000002
+	0000000000000000000000000000000000000000000000000000000000000040
+	0000000000000000000000000000000000000000000000000000000000000100
+	000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48
+	00000000000000000000000000000000000000000000000000000000000001f4
+	000000000000000000000000000000000000000000000000000000000000000a
+	0000000000000000000000000000000000000000000000000000000000000000
+	00000000000000000000000000000000000000000000000000000000000000a0
+	0000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000006b175474e89094c44da98b954eedeac495271d0f
+	0000000000000000000000000000000000000000000000000000000000000064
+	0000000000000000000000000000000000000000000000000000000000000001
+	0000000000000000000000000000000000000000000000000000000000000000
+	00000000000000000000000000000000000000000000000000000000000000a0
+	0000000000000000000000000000000000000000000000000000000000000001
+	ab00000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000040
+	0000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000de0b6b3a7640000
+	0000000000000000000000000000000000000000000000000000000000000040
+	0000000000000000000000006b175474e89094c44da98b954eedeac495271d0f
+	00000000000000000000000000000000000000000000000029a2241af62c0000
+`
+
+// single executes a V4 swap of 1 ETH for at least 3000 USDC in a single pool, without hook data.
+const single = `
+	3593564c
+	0000000000000000000000000000000000000000000000000000000000000060
+	00000000000000000000000000000000000000000000000000000000000000a0
+	000000000000000000000000000000000000000000000000000000006b49d200
+	0000000000000000000000000000000000000000000000000000000000000001
+	1000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000001
+	0000000000000000000000000000000000000000000000000000000000000020
+	0000000000000000000000000000000000000000000000000000000000000340
+	0000000000000000000000000000000000000000000000000000000000000040
+	0000000000000000000000000000000000000000000000000000000000000080
+	0000000000000000000000000000000000000000000000000000000000000003
+	060c0f0000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000003
+	0000000000000000000000000000000000000000000000000000000000000060
+	00000000000000000000000000000000000000000000000000000000000001e0
+	0000000000000000000000000000000000000000000000000000000000000240
+	0000000000000000000000000000000000000000000000000000000000000160
+	0000000000000000000000000000000000000000000000000000000000000020
+	0000000000000000000000000000000000000000000000000000000000000000
+	000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48
+	00000000000000000000000000000000000000000000000000000000000001f4
+	000000000000000000000000000000000000000000000000000000000000000a
+	0000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000001
+	0000000000000000000000000000000000000000000000000de0b6b3a7640000
+	00000000000000000000000000000000000000000000000000000000b2d05e00
+	0000000000000000000000000000000000000000000000000000000000000120
+	0000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000000000000000040
+	0000000000000000000000000000000000000000000000000000000000000000
+	0000000000000000000000000000000000000000000000000de0b6b3a7640000
+	0000000000000000000000000000000000000000000000000000000000000040
+	000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48
+	00000000000000000000000000000000000000000000000000000000b2d05e00
+`
+
+func TestCalldata(t *testing.T) {
+	tests := []struct {
+		name     string
+		calldata string
+		want     map[int]string
+	}{
+		{"V2 path", swaps, map[int]string{
+			0x01a4: "Commands[0].V2_SWAP_EXACT_IN.Path offset",
+			0x01c4: "Commands[0].V2_SWAP_EXACT_IN.PayerIsUser",
+			0x01e4: "Commands[0].V2_SWAP_EXACT_IN.Path length",
+			0x0204: "Commands[0].V2_SWAP_EXACT_IN.Path[0]",
+			0x0224: "Commands[0].V2_SWAP_EXACT_IN.Path[1]",
+		}},
+		{"packed V3 path", swaps, map[int]string{
+			0x02c4: "Commands[1].V3_SWAP_EXACT_IN.Path offset",
+			0x0304: "Commands[1].V3_SWAP_EXACT_IN.Path length",
+			0x0324: "Commands[1].V3_SWAP_EXACT_IN.{Path[0].TokenIn, Path[0].Fee, Path[0].TokenOut, Path[1].TokenIn}",
+			0x0344: "Commands[1].V3_SWAP_EXACT_IN.{Path[0].TokenOut, Path[1].TokenIn, Path[1].Fee, Path[1].TokenOut}",
+			0x0364: "Commands[1].V3_SWAP_EXACT_IN.Path[1].TokenOut",
+		}},
+		{"V4 path keys", swaps, map[int]string{
+			0x04c4: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN offset",
+			0x04e4: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.CurrencyIn",
+			0x0504: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path offset",
+			0x0564: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path length",
+			0x0584: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[0] offset",
+			0x05a4: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[1] offset",
+			0x05c4: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[0].IntermediateCurrency",
+			0x0684: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[1].IntermediateCurrency",
+		}},
+		{"V4 hook data", swaps, map[int]string{
+			0x0644: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[0].HookData offset",
+			0x0664: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[0].HookData length",
+			0x0704: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[1].HookData offset",
+			0x0724: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[1].HookData length",
+			0x0744: "Commands[2].V4_SWAP.Actions[0].SWAP_EXACT_IN.Path[1].HookData",
+		}},
+		{"V4 single pool", single, map[int]string{
+			0x0224: "Commands[0].V4_SWAP.Actions[0].SWAP_EXACT_IN_SINGLE offset",
+			0x0244: "Commands[0].V4_SWAP.Actions[0].SWAP_EXACT_IN_SINGLE.PoolKey.Currency0",
+			0x0344: "Commands[0].V4_SWAP.Actions[0].SWAP_EXACT_IN_SINGLE.HookData offset",
+			0x0364: "Commands[0].V4_SWAP.Actions[0].SWAP_EXACT_IN_SINGLE.HookData length",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calldata, err := hex.DecodeString(strings.Join(strings.Fields(tt.calldata), ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			words, err := Calldata(calldata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range words {
+				if !w.Consumed() || strings.HasSuffix(w.Label(), "layout") {
+					t.Errorf("0x%04x: got %q, want a field or layout", w.Offset, w.Label())
+				} else if want, ok := tt.want[w.Offset]; ok && w.Label() != want {
+					t.Errorf("0x%04x: got %q, want %q", w.Offset, w.Label(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/juztin/unidecode/annotate"
+)
+
+var annotateFlag bool
+
+// annotation is calldata split into words, each with the fields decoded from it, as output by `calldata -annotate`.
+type annotation struct {
+	Words []annotatedWord `json:"words"`
+}
+
+// annotatedWord is the method selector, or a 32 byte word, of calldata.
+type annotatedWord struct {
+	Offset   int      `json:"offset"`
+	Data     string   `json:"data"`
+	Fields   []string `json:"fields,omitempty"`
+	Layout   bool     `json:"layout,omitempty"`
+	Consumed bool     `json:"consumed"`
+	Label    string   `json:"label,omitempty"`
+}
+
+func (a annotation) text(w io.Writer) {
+	for _, word := range a.Words {
+		label := word.Label
+		if !word.Consumed {
+			label = "!! unconsumed"
+		}
+		fmt.Fprintf(w, "0x%04x: %-64s  %s\n", word.Offset, strings.TrimPrefix(word.Data, "0x"), label)
+	}
+}
+
+func (a annotation) records() [][]string {
+	records := [][]string{{"offset", "data", "label", "consumed"}}
+	for _, word := range a.Words {
+		records = append(records, []string{
+			fmt.Sprintf("0x%04x", word.Offset), word.Data, word.Label, strconv.FormatBool(word.Consumed),
+		})
+	}
+	return records
+}
+
+// annotateCmd writes the words of calldata, each labelled with the fields decoded from it, and highlights words which
+// weren't consumed.
+func annotateCmd(out format, calldata []byte) {
+	// Decoding first reports unsupported calldata along with the supported messages
+	_, _, err := decodeMessage(calldata)
+	checkErr("", err)
+	words, err := annotate.Calldata(calldata)
+	checkErr("", err)
+
+	a := annotation{Words: make([]annotatedWord, len(words))}
+	for i, w := range words {
+		a.Words[i] = annotatedWord{w.Offset, hexutil.Encode(w.Data), w.Fields, w.Layout != "", w.Consumed(), w.Label()}
+	}
+	checkErr("", out(os.Stdout, a))
+}
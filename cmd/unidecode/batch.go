@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juztin/unidecode/filter"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/registry"
+)
+
+var (
+	batchFlag        bool
+	batchColumn      string
+	batchConcurrency int
+)
+
+// maxBatchLine bounds the length of an input line, as calldata of large multi-hop or batched calls may be long.
+const maxBatchLine = 16 << 20
+
+// batchInput is an input of a batch, numbered by its position.
+type batchInput struct {
+	index int
+	data  string
+}
+
+// batchOutput is the JSON line written for an input of a batch.
+type batchOutput struct {
+	index int
+	line  []byte
+}
+
+// batchResult is a decoded input of a batch, or the error which stopped it being decoded.
+type batchResult struct {
+	Index   int         `json:"index"`
+	Message string      `json:"message,omitempty"`
+	Call    interface{} `json:"call,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// decodeBatchInput decodes the hex calldata of in as a JSON line, or nil when where is set and the call doesn't match.
+// An input which panics while decoded or matched is written as an error, rather than stopping the batch.
+func decodeBatchInput(in batchInput, where *filter.Filter) (line []byte) {
+	defer func() {
+		if p := recover(); p != nil {
+			err := fmt.Errorf("%w; %v", errMalformedCallData, p)
+			line, _ = jsonx.Marshal(batchResult{Index: in.index, Error: err.Error()})
+			line = append(line, '\n')
+		}
+	}()
+
+	r := batchResult{Index: in.index}
+	if b, err := parseHex([]byte(in.data)); err != nil {
+		r.Error = fmt.Sprintf("invalid calldata; %s", err)
+	} else if t, v, err := decodeMessage(b); err != nil {
+		r.Message, r.Error = t.String(), err.Error()
+	} else {
+		r.Message, r.Call = t.String(), v
+	}
+	if where != nil && (r.Call == nil || !matchWhere(where, r.Call)) {
+		return nil
+	}
+
+	line, err := jsonx.Marshal(r)
+	if err != nil {
+		line, _ = jsonx.Marshal(batchResult{Index: in.index, Message: r.Message, Error: err.Error()})
+	}
+	return append(line, '\n')
+}
+
+// readLines sends each non-blank line of r.
+func readLines(r io.Reader, send func(batchInput)) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), maxBatchLine)
+	i := 0
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			send(batchInput{i, line})
+			i++
+		}
+	}
+	return s.Err()
+}
+
+// readColumn sends the column of each CSV record of r. column is either a zero based column index, where
+// every record is an input, or the name of a column within the header record.
+func readColumn(r io.Reader, column string, send func(batchInput)) error {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	c.ReuseRecord = true
+
+	idx, err := strconv.Atoi(column)
+	if err != nil {
+		header, err := c.Read()
+		if err != nil {
+			return fmt.Errorf("unable to read the CSV header; %w", err)
+		}
+		idx = -1
+		for i, name := range header {
+			if strings.TrimSpace(name) == column {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("missing column %q in the CSV header", column)
+		}
+	} else if idx < 0 {
+		return fmt.Errorf("invalid column index: %d", idx)
+	}
+
+	for i := 0; ; i++ {
+		record, err := c.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var data string
+		if idx < len(record) {
+			data = record[idx]
+		}
+		send(batchInput{i, data})
+	}
+}
+
+// batchCmd decodes each hex calldata input of path, or stdin when empty, writing a JSON line per input in input order.
+//
+// Inputs are newline delimited, or the column of a CSV when column is set, and decoded by up to concurrency workers.
+// When where is set, only inputs whose call matches it are written.
+func batchCmd(path, column string, concurrency int, where *filter.Filter) {
+	if chainID == 0 {
+		useChain(registry.Mainnet)
+	} else {
+		useChain(chainID)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		checkErr("", err)
+		defer f.Close()
+		in = f
+	}
+
+	// window bounds how far workers may run ahead of the next input to be written
+	window := make(chan struct{}, concurrency*64)
+	inputs := make(chan batchInput, concurrency)
+	outputs := make(chan batchOutput, concurrency)
+	send := func(input batchInput) {
+		window <- struct{}{}
+		inputs <- input
+	}
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(inputs)
+		if column != "" {
+			readErr <- readColumn(in, column, send)
+		} else {
+			readErr <- readLines(in, send)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for input := range inputs {
+				outputs <- batchOutput{input.index, decodeBatchInput(input, where)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outputs)
+	}()
+
+	w := bufio.NewWriter(os.Stdout)
+	pending := make(map[int][]byte)
+	next := 0
+	for out := range outputs {
+		pending[out.index] = out.line
+		for line, ok := pending[next]; ok; line, ok = pending[next] {
+			if line != nil {
+				_, err := w.Write(line)
+				checkErr("", err)
+			}
+			delete(pending, next)
+			<-window
+			next++
+		}
+	}
+	checkErr("", w.Flush())
+	checkErr("unable to read batch input; %s", <-readErr)
+}
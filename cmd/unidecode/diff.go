@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/juztin/unidecode/diff"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/registry"
+)
+
+// diffResult is the changes between two decoded calls, as output by `diff -json`.
+type diffResult struct {
+	Changes []diffChange `json:"changes"`
+}
+
+// diffChange is a field added, removed or changed, with its values as text; addresses and bytes as hex, amounts in
+// decimal and times in RFC 3339.
+type diffChange struct {
+	Kind diff.Kind `json:"kind"`
+	Path string    `json:"path"`
+	From *string   `json:"from,omitempty"`
+	To   *string   `json:"to,omitempty"`
+
+	change diff.Change
+}
+
+// changeValue returns the JSON text of a field value, or nil when there's no value.
+func changeValue(v interface{}) *string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		s = hexutil.Encode(v)
+	case common.Address:
+		s = v.Hex()
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		s = v.String()
+	case time.Time:
+		s = v.UTC().Format(time.RFC3339)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return &s
+}
+
+func newDiffResult(changes []diff.Change) diffResult {
+	r := diffResult{Changes: make([]diffChange, len(changes))}
+	for i, c := range changes {
+		r.Changes[i] = diffChange{c.Kind, c.Path, changeValue(c.From), changeValue(c.To), c}
+	}
+	return r
+}
+
+func (r diffResult) text(w io.Writer) {
+	for _, c := range r.Changes {
+		switch c.Kind {
+		case diff.Added:
+			fmt.Fprintf(w, "+ %s: %s\n", c.Path, fieldValue(c.change.To))
+		case diff.Removed:
+			fmt.Fprintf(w, "- %s: %s\n", c.Path, fieldValue(c.change.From))
+		default:
+			fmt.Fprintf(w, "~ %s: %s -> %s\n", c.Path, fieldValue(c.change.From), fieldValue(c.change.To))
+		}
+	}
+}
+
+func (r diffResult) records() [][]string {
+	records := [][]string{{"kind", "path", "from", "to"}}
+	for _, c := range r.Changes {
+		var from, to string
+		if c.From != nil {
+			from = *c.From
+		}
+		if c.To != nil {
+			to = *c.To
+		}
+		records = append(records, []string{string(c.Kind), c.Path, from, to})
+	}
+	return records
+}
+
+// isTxHash reports whether s is a transaction hash rather than calldata, which is never 32 bytes.
+func isTxHash(s string) bool {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "0x")
+	if len(s) != 64 {
+		return false
+	}
+	_, err := parseHex([]byte(s))
+	return err == nil
+}
+
+// diffInput decodes s, a transaction hash or calldata, returning the fields it's compared by. Transactions include
+// their recipient and value.
+func diffInput(ctx context.Context, client *ethclient.Client, s string) ([]field.Field, error) {
+	if isTxHash(s) {
+		r, err := decodeTx(ctx, client, common.HexToHash(s), false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode transaction %s; %w", s, err)
+		}
+		fields := []field.Field{
+			{Name: "To"},
+			{Name: "Value", Value: r.tx.Value()},
+			{Name: "Message", Value: r.message.String()},
+		}
+		if to := r.tx.To(); to != nil {
+			fields[0].Value = *to
+		}
+		return append(fields, diff.CallFields(r.Call)...), nil
+	}
+
+	b, err := parseHex([]byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid calldata; %w", err)
+	}
+	t, v, err := decodeMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	return append([]field.Field{{Name: "Message", Value: t.String()}}, diff.CallFields(v)...), nil
+}
+
+// diffCmd writes the changes from a to b, each either calldata or a transaction hash fetched using the RPC at rpcURL.
+func diffCmd(ctx context.Context, rpcURL string, out format, a, b string) {
+	var client *ethclient.Client
+	if isTxHash(a) || isTxHash(b) {
+		var err error
+		client, err = ethclient.DialContext(ctx, rpcURL)
+		checkErr("", err)
+		if chainID == 0 {
+			id, err := client.ChainID(ctx)
+			checkErr("", err)
+			chainID = id.Uint64()
+		}
+	} else if chainID == 0 {
+		chainID = registry.Mainnet
+	}
+	useChain(chainID)
+
+	from, err := diffInput(ctx, client, a)
+	checkErr("", err)
+	to, err := diffInput(ctx, client, b)
+	checkErr("", err)
+
+	// Calldata has no recipient or value to compare with those of a transaction
+	if isTxHash(a) != isTxHash(b) {
+		from, to = withoutTx(from), withoutTx(to)
+	}
+	checkErr("", out(os.Stdout, newDiffResult(diff.Fields(from, to))))
+}
+
+// withoutTx returns fields without the recipient and value of a transaction.
+func withoutTx(fields []field.Field) []field.Field {
+	if len(fields) > 0 && fields[0].Name == "To" {
+		return fields[2:]
+	}
+	return fields
+}
@@ -4,28 +4,34 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/juztin/unidecode"
 	"github.com/juztin/unidecode/actions"
 	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/events"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/filter"
+	"github.com/juztin/unidecode/permit2"
+	"github.com/juztin/unidecode/registry"
+	"github.com/juztin/unidecode/uniswapx"
 )
 
-const (
-	actionArgFmt       = "%9s %s: %s\n"
-	actionArgSubFmt    = "%11s %s: %s\n"
-	actionArgSubIdxFmt = "%11s [%d] %s\n"
-	actionArgSubSubFmt = "%13s %s: %s\n"
-)
+const actionArgFmt = "%9s %s: %s\n"
 
 func checkErr(errFmt string, err error) {
 	if err != nil {
@@ -39,324 +45,437 @@ func checkErr(errFmt string, err error) {
 	}
 }
 
-func printActions(cmd commands.Command) {
-	for _, action := range cmd.Actions() {
-		actionType := action.Type()
-		fmt.Fprintf(os.Stdout, "      - %s:\n", strings.ToUpper(actionType.String()))
-
-		switch actionType {
-		case actions.MINT_POSITION:
-			a := action.(actions.MintPosition)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PoolKey", a.PoolKey.ID())
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency0", a.PoolKey.Currency0)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency1", a.PoolKey.Currency1)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Fee", a.PoolKey.Fee)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "TickSpacing", a.PoolKey.TickSpacing)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Hooks", a.PoolKey.Hooks)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TickLower", a.TickLower)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TickUpper", a.TickUpper)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Liquidity", a.Liquidity)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Max", a.Amount0Max)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Max", a.Amount1Max)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Owner", a.Owner)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "HookData", fmt.Sprintf("%x", a.HookData))
-		case actions.SWAP_EXACT_IN_SINGLE:
-			a := action.(actions.SwapExactInSingle)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PoolKey", a.PoolKey.ID())
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency0", a.PoolKey.Currency0)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency1", a.PoolKey.Currency1)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Fee", a.PoolKey.Fee)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "TickSpacing", a.PoolKey.TickSpacing)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Hooks", a.PoolKey.Hooks)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "ZeroForOne", strconv.FormatBool(a.ZeroForOne))
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountIn", a.AmountIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOutMinimum", a.AmountOutMinimum)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "HookData", fmt.Sprintf("%x", a.HookData))
-		case actions.SWAP_EXACT_IN:
-			a := action.(actions.SwapExactIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "CurrencyIn", a.CurrencyIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Path", "")
-			for i, p := range a.Path {
-				fmt.Fprintf(os.Stdout, actionArgSubIdxFmt, "", i, "")
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "IntermediateCurrency", p.IntermediateCurrency)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Fee", p.Fee)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "TickSpacing", p.TickSpacing)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Hooks", p.Hooks)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "HookData", fmt.Sprintf("%x", p.HookData))
-			}
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountIn", a.AmountIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOutMinimum", a.AmountOutMinimum)
-		case actions.SWAP_EXACT_OUT_SINGLE:
-			a := action.(actions.SwapExactOutSingle)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PoolKey", a.PoolKey.ID())
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency0", a.PoolKey.Currency0)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency1", a.PoolKey.Currency1)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Fee", a.PoolKey.Fee)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "TickSpacing", a.PoolKey.TickSpacing)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Hooks", a.PoolKey.Hooks)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "ZeroForOne", strconv.FormatBool(a.ZeroForOne))
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOut", a.AmountOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountInMaximum", a.AmountInMaximum)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "HookData", fmt.Sprintf("%x", a.HookData))
-		case actions.SWAP_EXACT_OUT:
-			a := action.(actions.SwapExactOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "CurrencyOut", a.CurrencyOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Path", "")
-			for i, p := range a.Path {
-				fmt.Fprintf(os.Stdout, actionArgSubIdxFmt, "", i, "")
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "IntermediateCurrency", p.IntermediateCurrency)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Fee", p.Fee)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "TickSpacing", p.TickSpacing)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Hooks", p.Hooks)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "HookData", fmt.Sprintf("%x", p.HookData))
+// label returns the address suffixed with its contract name when known on the selected chain.
+func label(addr common.Address) string {
+	if name := chains.Label(chainID, addr); name != "" {
+		return fmt.Sprintf("%s (%s)", addr, name)
+	}
+	return addr.String()
+}
+
+// signedBy describes the signer of a permit, and whether it matches the transaction sender when known.
+func signedBy(signer common.Address, err error) string {
+	if err != nil {
+		return fmt.Sprintf("(unable to recover signer; %s)", err)
+	} else if txSender == nil {
+		return fmt.Sprintf("(signed by %s)", signer)
+	} else if signer == *txSender {
+		return fmt.Sprintf("(signed by %s, the transaction sender)", signer)
+	}
+	return fmt.Sprintf("(signed by %s, NOT the transaction sender %s)", signer, *txSender)
+}
+
+// permit2SignedBy describes the signer of the Permit2 permit v.
+func permit2SignedBy(v interface{}) string {
+	addr := registry.Permit2
+	if d, ok := chains.Get(chainID); ok && d.Permit2 != (common.Address{}) {
+		addr = d.Permit2
+	}
+	return signedBy(unidecode.Signer(v, chainID, addr))
+}
+
+// v3SignedBy describes the signer of the NonfungiblePositionManager permit p.
+func v3SignedBy(p commands.V3PositionManagerPermit) string {
+	d, ok := chains.Get(chainID)
+	if !ok || d.V3PositionManager == (common.Address{}) {
+		return "(unable to recover signer; unknown NonfungiblePositionManager)"
+	} else if v3Nonce == nil {
+		return "(unable to recover signer; the position nonce is only available with the `tx` command)"
+	}
+	nonce, err := v3Nonce(d.V3PositionManager, p.Amount)
+	if err != nil {
+		return signedBy(common.Address{}, err)
+	}
+	return signedBy(unidecode.V3PermitSigner(p, chainID, d.V3PositionManager, nonce))
+}
+
+// useChain selects the deployment used for labeling and WETH resolution.
+func useChain(id uint64) {
+	chainID = id
+}
+
+// weth returns the WETH of the selected chain, or the mainnet WETH9 when unknown.
+func weth() common.Address {
+	if d, ok := chains.Get(chainID); ok && d.WETH != (common.Address{}) {
+		return d.WETH
+	}
+	return actions.WETH9
+}
+
+// deployment returns the deployment of the selected chain, with the WETH returned by weth.
+func deployment() registry.Deployment {
+	d, _ := chains.Get(chainID)
+	d.WETH = weth()
+	return d
+}
+
+// decodeCall decodes calldata sent to any of the supported contracts, using the WETH of the selected chain. Malformed
+// calldata which panics while decoding is returned as an error.
+func decodeCall(calldata []byte) (v interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			v, err = nil, fmt.Errorf("%w; %v", errMalformedCallData, r)
+		}
+	}()
+	v, err = unidecode.DecodeCall(calldata)
+	if err == nil {
+		unidecode.SetWETH(v, weth())
+	}
+	return v, err
+}
+
+// fieldValue returns the text of a field value, labelling addresses.
+func fieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case common.Address:
+		return label(v)
+	case []byte:
+		return fmt.Sprintf("%x", v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return "none"
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// fieldSigner returns who signed the signature of owner, or an empty string when it can't be recovered.
+func fieldSigner(owner interface{}) string {
+	switch o := owner.(type) {
+	case permit2.Permit, permit2.PermitBatch, commands.Permit2Permit, commands.Permit2PermitBatch:
+		return permit2SignedBy(o)
+	case commands.V3PositionManagerPermit:
+		return v3SignedBy(o)
+	}
+	return ""
+}
+
+// printFields prints the fields of owner, a command, action, Permit2 call or signed order, depth levels below the
+// argument column.
+func printFields(w io.Writer, fields []field.Field, depth int, owner interface{}) {
+	indent := strings.Repeat(" ", 10+2*depth)
+	for _, f := range fields {
+		value := fieldValue(f.Value)
+		if f.Name == "Sig" && depth == 0 {
+			if signer := fieldSigner(owner); signer != "" {
+				value += " " + signer
 			}
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOut", a.AmountOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountInMaximum", a.AmountInMaximum)
-		case actions.SETTLE:
-			a := action.(actions.Settle)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount", a.Amount)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PayerIsUser", strconv.FormatBool(a.PayerIsUser))
-		case actions.SETTLE_ALL:
-			a := action.(actions.SettleAll)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "MaxAmount", a.MaxAmount)
-		case actions.TAKE:
-			a := action.(actions.Take)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", a.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount", a.Amount)
-		case actions.TAKE_ALL:
-			a := action.(actions.TakeAll)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "MinAmount", a.MinAmount)
-		case actions.TAKE_PORTION:
-			a := action.(actions.TakePortion)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", a.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "BIPs", a.BIPs)
-		case actions.SWEEP:
-			a := action.(actions.Sweep)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", a.Recipient)
-		case actions.WRAP:
-			a := action.(actions.Wrap)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount", a.Amount)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Wrapped", a.Wrapped)
-		case actions.UNWRAP:
-			a := action.(actions.Unwrap)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount", a.Amount)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Currency", a.Currency)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Wrapped", a.Wrapped)
-		case actions.V3_MINT:
-			a := action.(actions.V3Mint)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token0", a.Token0)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token1", a.Token1)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Fee", a.Fee)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TickLower", a.TickLower)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TickUpper", a.TickUpper)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Desired", a.Amount0Desired)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Desired", a.Amount1Desired)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Min", a.Amount0Min)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Min", a.Amount1Min)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", a.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Deadline", a.Deadline)
-		case actions.V3_INCREASE_LIQUIDITY:
-			a := action.(actions.V3IncreaseLiquidity)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TokenID", a.TokenID)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Desired", a.Amount0Desired)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Desired", a.Amount1Desired)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Min", a.Amount0Min)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Min", a.Amount1Min)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Deadline", a.Deadline)
-		case actions.V3_DECREASE_LIQUIDITY:
-			a := action.(actions.V3DecreaseLiquidity)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TokenID", a.TokenID)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Liquidity", a.Liquidity)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Min", a.Amount0Min)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Min", a.Amount1Min)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Deadline", a.Deadline)
-		case actions.V3_COLLECT:
-			a := action.(actions.V3Collect)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TokenID", a.TokenID)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", a.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount0Max", a.Amount0Max)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount1Max", a.Amount1Max)
-		case actions.V3_BURN:
-			a := action.(actions.V3Burn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "TokenID", a.TokenID)
-
-		// Not Implemented
-		case actions.INCREASE_LIQUIDITY,
-			actions.DECREASE_LIQUIDITY,
-			actions.BURN_POSITION,
-			actions.INCREASE_LIQUIDITY_FROM_DELTAS,
-			actions.MINT_POSITION_FROM_DELTAS,
-			actions.DONATE,
-			actions.SETTLE_PAIR,
-			actions.TAKE_PAIR,
-			actions.CLOSE_CURRENCY,
-			actions.CLEAR_OR_TAKE,
-			actions.MINT_6909,
-			actions.BURN_6909:
 		}
+		fmt.Fprintf(w, "%s%s: %s\n", indent, f.Name, value)
+		printFields(w, f.Fields, depth+1, owner)
+		for i, item := range f.Items {
+			fmt.Fprintf(w, "%s  [%d] %s\n", indent, i, fieldValue(item.Value))
+			printFields(w, item.Fields, depth+2, owner)
+		}
+	}
+}
+
+func printActions(w io.Writer, cmd commands.Command) {
+	for _, action := range cmd.Actions() {
+		fmt.Fprintf(w, "      - %s:\n", strings.ToUpper(action.Type().String()))
+		printFields(w, action.Fields(), 0, action)
+	}
+}
+
+func printPermit2(w io.Writer, t fmt.Stringer, call permit2.Call) {
+	fmt.Fprintf(w, "%s:\n", t)
+	fmt.Fprintf(w, "    - %s:\n", call.Type())
+	printFields(w, call.Fields(), 0, call)
+}
+
+func printUniswapX(w io.Writer, t fmt.Stringer, execute uniswapx.Execute) {
+	fmt.Fprintf(w, "%s:\n  Method: %s\n", t, execute.Method)
+	if execute.CallbackData != nil {
+		fmt.Fprintf(w, "  CallbackData: %x\n", execute.CallbackData)
+	}
+	for _, signed := range execute.Orders {
+		fmt.Fprintf(w, "    - %s:\n", signed.Order.Type())
+		printFields(w, signed.Fields(), 0, signed)
 	}
 }
 
-func printExecute(execute unidecode.Execute) {
-	if execute.Deadline.Unix() == math.MaxInt64 {
-		fmt.Fprintf(os.Stdout, "EXECUTE:\n  Deadline: none\n")
+func printExecute(w io.Writer, t fmt.Stringer, execute unidecode.Execute) {
+	if execute.Deadline == nil || execute.Deadline.Unix() == math.MaxInt64 {
+		fmt.Fprintf(w, "%s:\n  Deadline: none\n", t)
 	} else {
-		fmt.Fprintf(os.Stdout, "EXECUTE:\n  Deadline: %s\n", execute.Deadline)
+		fmt.Fprintf(w, "%s:\n  Deadline: %s\n", t, execute.Deadline)
 	}
 	for _, cmd := range execute.Commands {
-		cmdType := cmd.Type()
-		fmt.Fprintf(os.Stdout, "    - %s:\n", strings.ToUpper(cmdType.String()))
-
-		switch cmdType {
-		case commands.V4_SWAP:
-			swap := cmd.(commands.V4Swap)
-			printActions(swap)
-		case commands.V3_SWAP_EXACT_IN:
-			c := cmd.(commands.V3SwapExactIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountIn", c.AmountIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOutMin", c.AmountOutMin)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PayerIsUser", c.PayerIsUser)
-		case commands.V3_SWAP_EXACT_OUT:
-			c := cmd.(commands.V3SwapExactOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOut", c.AmountOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountInMin", c.AmountInMin)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PayerIsUser", c.PayerIsUser)
-		case commands.PERMIT2_TRANSFER_FROM:
-			c := cmd.(commands.Permit2TransferFrom)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token", c.Token)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount", c.Amount)
-		case commands.PERMIT2_PERMIT_BATCH:
-			c := cmd.(commands.Permit2PermitBatch)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Details", "")
-			for i, p := range c.Details {
-				fmt.Fprintf(os.Stdout, actionArgSubIdxFmt, "", i, "")
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Token", p.Token)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Amount", p.Amount)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Expiration", p.Expiration)
-				fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Nonce", fmt.Sprintf("%d", p.Nonce))
-			}
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Spender", c.Spender)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "SigDeadline", c.SigDeadline)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Sig", fmt.Sprintf("%x", c.Sig))
-		case commands.SWEEP:
-			c := cmd.(commands.Sweep)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token", c.Token)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountMin", c.AmountMin)
-		case commands.TRANSFER:
-			c := cmd.(commands.Transfer)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token", c.Token)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Value", c.Value)
-		case commands.PAY_PORTION:
-			c := cmd.(commands.PayPortion)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token", c.Token)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "BIPs", c.BIPs)
-		case commands.V2_SWAP_EXACT_IN:
-			c := cmd.(commands.V2SwapExactIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountIn", c.AmountIn)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOutMin", c.AmountOutMin)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PayerIsUser", strconv.FormatBool(c.PayerIsUser))
-		case commands.V2_SWAP_EXACT_OUT:
-			c := cmd.(commands.V2SwapExactOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountOut", c.AmountOut)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountInMin", c.AmountInMin)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "PayerIsUser", strconv.FormatBool(c.PayerIsUser))
-		case commands.PERMIT2_PERMIT:
-			c := cmd.(commands.Permit2Permit)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Details", "")
-			fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Token", c.Details.Token)
-			fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Amount", c.Details.Amount)
-			fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Expiration", c.Details.Expiration)
-			fmt.Fprintf(os.Stdout, actionArgSubSubFmt, "", "Nonce", fmt.Sprintf("%d", c.Details.Nonce))
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Spender", c.Spender)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "SigDeadline", c.SigDeadline)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Sig", fmt.Sprintf("%x", c.Sig))
-		case commands.WRAP_ETH:
-			c := cmd.(commands.WrapWETH)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountMin", c.AmountMin)
-		case commands.UNWRAP_WETH:
-			c := cmd.(commands.UnwrapWETH)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Recipient", c.Recipient)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "AmountMin", c.AmountMin)
-		case commands.PERMIT2_TRANSFER_FROM_BATCH:
-			// TODO Implement
-		case commands.BALANCE_CHECK_ERC20:
-			c := cmd.(commands.BalanceCheckERC20)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Owner", c.Owner)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Token", c.Token)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "MinBalance", c.MinBalance)
-		case commands.V3_POSITION_MANAGER_PERMIT:
-			c := cmd.(commands.V3PositionManagerPermit)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Spender", c.Spender)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Amount", c.Amount)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Deadline", c.Deadline)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Sig", fmt.Sprintf("%x", c.Sig))
-		case commands.V3_POSITION_MANAGER_CALL:
-			c := cmd.(commands.V3PositionManagerCall)
-			printActions(c)
-		case commands.V4_INITIALIZE_POOL:
-			c := cmd.(commands.V4InitializePool)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Key", c.Key.ID())
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency0", c.Key.Currency0)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Currency1", c.Key.Currency1)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Fee", c.Key.Fee)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "TickSpacing", c.Key.TickSpacing)
-			fmt.Fprintf(os.Stdout, actionArgSubFmt, "", "Hooks", c.Key.Hooks)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "SqrtPrice", c.SqrtPrice)
-		case commands.V4_POSITION_MANAGER_CALL:
-			c := cmd.(commands.V4PositionManagerCall)
-			fmt.Fprintf(os.Stdout, actionArgFmt, "", "Deadline", c.Deadline)
-			printActions(c)
-
-		// Not Implemented
-		case commands.EXECUTE_SUB_PLAN,
-			commands.FLAG_ALLOW_REVERT,
-			commands.COMMAND_TYPE_MASK:
-			// TODO Not implemented
-		default:
+		fmt.Fprintf(w, "    - %s:\n", strings.ToUpper(cmd.Type().String()))
+		printFields(w, cmd.Fields(), 0, cmd)
+		printActions(w, cmd)
+	}
+}
+
+// tokenLabel returns the labelled token address, or ETH for native ETH.
+func tokenLabel(token common.Address) string {
+	if token == unidecode.ETH {
+		return "ETH"
+	}
+	return label(token)
+}
+
+// tokenAmount formats amount, adding its decimal value when the token's decimals are known.
+func tokenAmount(token common.Address, amount *big.Int) string {
+	if tokenDecimals == nil {
+		return amount.String()
+	}
+	d, ok := tokenDecimals(token)
+	if !ok || d == 0 {
+		return amount.String()
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d)), nil)
+	f := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetInt(unit))
+	return fmt.Sprintf("%s (%s)", amount, f.Text('f', -1))
+}
+
+func printEvent(w io.Writer, e events.Event) {
+	fmt.Fprintf(w, "    - %s:\n", e.Type())
+	switch e := e.(type) {
+	case events.V2Swap:
+		fmt.Fprintf(w, actionArgFmt, "", "Pair", label(e.Pair))
+		fmt.Fprintf(w, actionArgFmt, "", "Sender", label(e.Sender))
+		fmt.Fprintf(w, actionArgFmt, "", "To", label(e.To))
+		fmt.Fprintf(w, actionArgFmt, "", "AmountsIn", fmt.Sprintf("%s, %s", e.Amount0In, e.Amount1In))
+		fmt.Fprintf(w, actionArgFmt, "", "AmountsOut", fmt.Sprintf("%s, %s", e.Amount0Out, e.Amount1Out))
+	case events.V3Swap:
+		fmt.Fprintf(w, actionArgFmt, "", "Pool", label(e.Pool))
+		fmt.Fprintf(w, actionArgFmt, "", "Sender", label(e.Sender))
+		fmt.Fprintf(w, actionArgFmt, "", "Recipient", label(e.Recipient))
+		fmt.Fprintf(w, actionArgFmt, "", "Amounts", fmt.Sprintf("%s, %s", e.Amount0, e.Amount1))
+		fmt.Fprintf(w, actionArgFmt, "", "SqrtPriceX96", e.SqrtPriceX96)
+		fmt.Fprintf(w, actionArgFmt, "", "Liquidity", e.Liquidity)
+		fmt.Fprintf(w, actionArgFmt, "", "Tick", e.Tick)
+	case events.V4Swap:
+		fmt.Fprintf(w, actionArgFmt, "", "ID", e.ID)
+		fmt.Fprintf(w, actionArgFmt, "", "Sender", label(e.Sender))
+		fmt.Fprintf(w, actionArgFmt, "", "Amounts", fmt.Sprintf("%s, %s", e.Amount0, e.Amount1))
+		fmt.Fprintf(w, actionArgFmt, "", "SqrtPriceX96", e.SqrtPriceX96)
+		fmt.Fprintf(w, actionArgFmt, "", "Liquidity", e.Liquidity)
+		fmt.Fprintf(w, actionArgFmt, "", "Tick", e.Tick)
+		fmt.Fprintf(w, actionArgFmt, "", "Fee", e.Fee)
+	case events.V4ModifyLiquidity:
+		fmt.Fprintf(w, actionArgFmt, "", "ID", e.ID)
+		fmt.Fprintf(w, actionArgFmt, "", "Sender", label(e.Sender))
+		fmt.Fprintf(w, actionArgFmt, "", "Ticks", fmt.Sprintf("%d -> %d", e.TickLower, e.TickUpper))
+		fmt.Fprintf(w, actionArgFmt, "", "LiquidityDelta", e.LiquidityDelta)
+		fmt.Fprintf(w, actionArgFmt, "", "Salt", e.Salt)
+	case events.V4Initialize:
+		fmt.Fprintf(w, actionArgFmt, "", "ID", e.ID)
+		fmt.Fprintf(w, actionArgFmt, "", "Currency0", tokenLabel(e.Currency0))
+		fmt.Fprintf(w, actionArgFmt, "", "Currency1", tokenLabel(e.Currency1))
+		fmt.Fprintf(w, actionArgFmt, "", "Fee", e.Fee)
+		fmt.Fprintf(w, actionArgFmt, "", "TickSpacing", e.TickSpacing)
+		fmt.Fprintf(w, actionArgFmt, "", "Hooks", label(e.Hooks))
+		fmt.Fprintf(w, actionArgFmt, "", "SqrtPriceX96", e.SqrtPriceX96)
+		fmt.Fprintf(w, actionArgFmt, "", "Tick", e.Tick)
+	case events.Transfer:
+		fmt.Fprintf(w, actionArgFmt, "", "Token", label(e.Token))
+		fmt.Fprintf(w, actionArgFmt, "", "From", label(e.From))
+		fmt.Fprintf(w, actionArgFmt, "", "To", label(e.To))
+		fmt.Fprintf(w, actionArgFmt, "", "Value", tokenAmount(e.Token, e.Value))
+	case events.Deposit:
+		fmt.Fprintf(w, actionArgFmt, "", "Token", label(e.Token))
+		fmt.Fprintf(w, actionArgFmt, "", "Dst", label(e.Dst))
+		fmt.Fprintf(w, actionArgFmt, "", "Wad", tokenAmount(e.Token, e.Wad))
+	case events.Withdrawal:
+		fmt.Fprintf(w, actionArgFmt, "", "Token", label(e.Token))
+		fmt.Fprintf(w, actionArgFmt, "", "Src", label(e.Src))
+		fmt.Fprintf(w, actionArgFmt, "", "Wad", tokenAmount(e.Token, e.Wad))
+	}
+}
+
+func printTracedCall(w io.Writer, c unidecode.TracedCall) {
+	fmt.Fprintf(w, "TRACE [%s] depth %d: %s %s -> %s\n", joinPath(c.Path), c.Depth, c.CallType, label(c.From), label(c.To))
+	if c.Error != "" {
+		fmt.Fprintf(w, "  Error: %s\n", c.Error)
+	}
+	if c.DecodeError != "" {
+		fmt.Fprintf(w, "  %s: unable to decode; %s\n", c.Message, c.DecodeError)
+	} else {
+		printCall(w, stringer(c.Message), c.Call)
+	}
+}
+
+func printWallet(w io.Writer, t fmt.Stringer, wallet unidecode.Wallet) {
+	fmt.Fprintf(w, "%s:\n  Method: %s\n", t, wallet.Method)
+	if len(wallet.Calls) == 0 {
+		fmt.Fprintln(w, "  Calls: none supported")
+	}
+	for _, c := range wallet.Calls {
+		from := "target"
+		if c.From != nil {
+			from = label(*c.From)
+		}
+		fmt.Fprintf(w, "CALL [%s] %s: %s %s -> %s\n", joinPath(c.Path), strings.Join(c.Via, " > "), c.Operation, from, label(c.To))
+		if c.Value != nil && c.Value.Sign() > 0 {
+			fmt.Fprintf(w, "  Value: %s\n", tokenAmount(unidecode.ETH, c.Value))
+		}
+		if c.DecodeError != "" {
+			fmt.Fprintf(w, "  %s: unable to decode; %s\n", c.Message, c.DecodeError)
+		} else {
+			printCall(w, stringer(c.Message), c.Call)
+		}
+	}
+}
+
+// stringer is a plain string satisfying fmt.Stringer.
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
+
+func printOutcome(w io.Writer, account common.Address, o unidecode.Outcome) {
+	status := "SUCCESS"
+	if o.Status != types.ReceiptStatusSuccessful {
+		status = "REVERTED"
+	}
+	fmt.Fprintf(w, "RECEIPT:\n  Status: %s\n  GasUsed: %d\n  Events:\n", status, o.GasUsed)
+	for _, e := range o.Events {
+		printEvent(w, e)
+	}
+
+	if len(o.Balances) > 0 {
+		fmt.Fprintf(w, "  Balances: %s\n", label(account))
+		for _, b := range o.Balances {
+			fmt.Fprintf(w, actionArgFmt, "", tokenLabel(b.Token), tokenAmount(b.Token, b.Amount))
+		}
+	}
+
+	if len(o.Hops) > 0 {
+		fmt.Fprintf(w, "  Hops:\n")
+		for _, h := range o.Hops {
+			fmt.Fprintf(w, "    - %s:\n", h.Path)
+			fmt.Fprintf(w, actionArgFmt, "", "Pool", label(h.Pool))
+			fmt.Fprintf(w, actionArgFmt, "", "TokenIn", tokenLabel(h.TokenIn))
+			fmt.Fprintf(w, actionArgFmt, "", "AmountIn", tokenAmount(h.TokenIn, h.AmountIn))
+			fmt.Fprintf(w, actionArgFmt, "", "TokenOut", tokenLabel(h.TokenOut))
+			fmt.Fprintf(w, actionArgFmt, "", "AmountOut", tokenAmount(h.TokenOut, h.AmountOut))
 		}
 	}
+
+	s := o.Swap
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(w, "  Swap:\n")
+	fmt.Fprintf(w, actionArgFmt, "", "TokenIn", tokenLabel(s.TokenIn))
+	fmt.Fprintf(w, actionArgFmt, "", "AmountIn", tokenAmount(s.TokenIn, s.AmountIn))
+	fmt.Fprintf(w, actionArgFmt, "", "TokenOut", tokenLabel(s.TokenOut))
+	fmt.Fprintf(w, actionArgFmt, "", "AmountOut", tokenAmount(s.TokenOut, s.AmountOut))
+
+	price := s.Price.Text('g', 10)
+	if tokenDecimals != nil {
+		in, inOK := tokenDecimals(s.TokenIn)
+		out, outOK := tokenDecimals(s.TokenOut)
+		if inOK && outOK {
+			scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(in)), nil))
+			scale.Quo(scale, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(out)), nil)))
+			price = new(big.Float).Mul(s.Price, scale).Text('g', 10)
+		}
+	}
+	fmt.Fprintf(w, actionArgFmt, "", "Price", fmt.Sprintf("%s %s per %s", price, tokenLabel(s.TokenOut), tokenLabel(s.TokenIn)))
+	if s.AmountOutMin != nil {
+		fmt.Fprintf(w, actionArgFmt, "", "AmountOutMin", tokenAmount(s.TokenOut, s.AmountOutMin))
+	}
+	if s.AmountInMax != nil {
+		fmt.Fprintf(w, actionArgFmt, "", "AmountInMax", tokenAmount(s.TokenIn, s.AmountInMax))
+	}
+	if s.Slippage != nil {
+		pct := new(big.Float).Mul(s.Slippage, big.NewFloat(100))
+		fmt.Fprintf(w, actionArgFmt, "", "Slippage", fmt.Sprintf("%s%% remaining before the limit", pct.Text('f', 2)))
+	}
 }
 
-func process(isJSON, isJSONPretty bool, calldata []byte) {
+var (
+	// errUnsupportedMessage is returned when calldata isn't sent to any of the supported contracts.
+	errUnsupportedMessage = errors.New("unsupported message")
+	// errRPCFailed is returned when fetching from the RPC API fails.
+	errRPCFailed = errors.New("RPC request failed")
+	// errMalformedCallData is returned when calldata panics while being decoded, such as an offset beyond its end.
+	errMalformedCallData = errors.New("malformed calldata")
+)
+
+// decodeMessage decodes calldata sent to any of the supported contracts, returning its message type and decoded call.
+func decodeMessage(calldata []byte) (fmt.Stringer, interface{}, error) {
 	t := unidecode.MessageType(calldata)
-	if t != unidecode.ExecuteMessage {
-		checkErr("currently only supports EXECUTE messages; %s", fmt.Errorf("got %x but expected %s", calldata, unidecode.ExecuteMessage))
+	if t == unidecode.UnknownMessage {
+		return t, nil, fmt.Errorf("%w; got %x but expected one of %s, %s, %s, %s, %s, %s, %s, %s", errUnsupportedMessage, calldata,
+			unidecode.ExecuteMessage, unidecode.PositionManagerMessage, unidecode.V3PositionManagerMessage,
+			unidecode.SwapRouter02Message, unidecode.V2RouterMessage, unidecode.Permit2Message,
+			unidecode.UniswapXMessage, unidecode.WalletMessage)
 	}
-	execute, err := unidecode.DecodeExecute(calldata)
+
+	v, err := decodeCall(calldata)
+	return t, v, err
+}
+
+// decode decodes calldata sent to any of the supported contracts, exiting when it can't be decoded.
+func decode(calldata []byte) (fmt.Stringer, interface{}) {
+	t, v, err := decodeMessage(calldata)
 	checkErr("", err)
+	return t, v
+}
 
-	if isJSON || isJSONPretty {
-		var b []byte
-		if isJSONPretty {
-			b, err = json.MarshalIndent(execute, "", "  ")
-		} else {
-			b, err = json.Marshal(execute)
+// parseWhere parses the -where filter expression s, returning nil when it's empty.
+func parseWhere(s string) (*filter.Filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	return filter.Parse(s)
+}
+
+// matchWhere reports whether the decoded call v matches where, where a nil filter matches every call. Wallet calls
+// match when any Universal Router call they wrap does, and any other calls never match.
+func matchWhere(where *filter.Filter, v interface{}) bool {
+	if where == nil {
+		return true
+	}
+	switch c := v.(type) {
+	case unidecode.Execute:
+		return where.Match(c)
+	case unidecode.Wallet:
+		for _, wc := range c.Calls {
+			if e, ok := wc.Call.(unidecode.Execute); ok && where.Match(e) {
+				return true
+			}
 		}
-		checkErr("", err)
+	}
+	return false
+}
+
+func printJSON(isPretty bool, v interface{}) {
+	checkErr("", encodeJSON(os.Stdout, v, isPretty))
+}
 
-		fmt.Fprintf(os.Stdout, "%s\n", b)
+func printCall(w io.Writer, t fmt.Stringer, v interface{}) {
+	if call, ok := v.(permit2.Call); ok {
+		printPermit2(w, t, call)
+	} else if execute, ok := v.(uniswapx.Execute); ok {
+		printUniswapX(w, t, execute)
+	} else if wallet, ok := v.(unidecode.Wallet); ok {
+		printWallet(w, t, wallet)
 	} else {
-		printExecute(execute)
+		printExecute(w, t, v.(unidecode.Execute))
 	}
 }
 
-func calldataCmd(isJSON, isPretty bool, calldata []byte) {
+func process(out format, calldata []byte) {
+	t, v := decode(calldata)
+	checkErr("", out(os.Stdout, callDoc{t, v}))
+}
+
+func calldataCmd(out format, calldata []byte) {
+	if chainID == 0 {
+		useChain(registry.Mainnet)
+	} else {
+		useChain(chainID)
+	}
 	if len(calldata) == 0 {
 		checkErr("", fmt.Errorf("empty/missing CALLDATA"))
 	}
@@ -364,21 +483,199 @@ func calldataCmd(isJSON, isPretty bool, calldata []byte) {
 		calldata = calldata[2:]
 	}
 
-	b := make([]byte, len(calldata))
+	b := make([]byte, hex.DecodedLen(len(calldata)))
 	_, err := hex.Decode(b, calldata)
 	checkErr("", err)
 
-	process(isJSON, isPretty, b)
+	if annotateFlag {
+		annotateCmd(out, b)
+		return
+	}
+	process(out, b)
+}
+
+// txResult is a decoded transaction, as output by `tx -json`.
+type txResult struct {
+	Call    interface{}            `json:"call"`
+	Traced  []unidecode.TracedCall `json:"traced,omitempty"`
+	Outcome *unidecode.Outcome     `json:"outcome"`
+
+	message fmt.Stringer
+	tx      *types.Transaction
+	from    common.Address
+	// account is the account the outcome is reconciled for, which differs from the sender for wallet calls.
+	account common.Address
+	receipt *types.Receipt
 }
 
-func transactionCmd(ctx context.Context, rpcURL string, isJSON, isPretty bool, hash common.Hash) {
+// decodeTx fetches and decodes the transaction hash, along with its receipt when mined. When trace is set, internal
+// calls to known contracts are decoded using debug_traceTransaction.
+func decodeTx(ctx context.Context, client *ethclient.Client, hash common.Hash, trace bool) (txResult, error) {
+	tx, _, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return txResult{}, fmt.Errorf("%w; %w", errRPCFailed, err)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return txResult{}, err
+	}
+	r := txResult{tx: tx, from: from, account: from}
+
+	// Pending transactions have no receipt yet
+	r.receipt, err = client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		r.receipt = nil
+	} else if err != nil {
+		return r, fmt.Errorf("%w; %w", errRPCFailed, err)
+	}
+
+	if trace {
+		var root unidecode.CallFrame
+		err := client.Client().CallContext(ctx, &root, "debug_traceTransaction", hash, map[string]string{"tracer": "callTracer"})
+		if err != nil {
+			return r, fmt.Errorf("unable to trace transaction; %w; %w", errRPCFailed, err)
+		}
+
+		contracts := make(map[common.Address]string)
+		if d, ok := chains.Get(chainID); ok {
+			contracts = d.Labels()
+		}
+		r.Traced = unidecode.DecodeTrace(root, contracts)
+		for _, c := range r.Traced {
+			unidecode.SetWETH(c.Call, weth())
+		}
+
+		// Wallets and aggregators make the router call internally, so the top-level call may be unknown
+		r.message = unidecode.MessageType(tx.Data())
+		if r.message != unidecode.UnknownMessage {
+			if r.Call, err = decodeCall(tx.Data()); err != nil {
+				return r, err
+			}
+		}
+	} else if r.message, r.Call, err = decodeMessage(tx.Data()); err != nil {
+		return r, err
+	}
+
+	if r.receipt != nil {
+		call, value := r.Call, tx.Value()
+		if w, ok := r.Call.(unidecode.Wallet); ok {
+			// Reconcile the first wrapped call, made by the wallet itself unless it's a user operation's sender
+			call = nil
+			for _, c := range w.Calls {
+				if c.Call == nil {
+					continue
+				}
+				call, value = c.Call, c.Value
+				if c.From != nil {
+					r.account = *c.From
+				} else if tx.To() != nil {
+					r.account = *tx.To()
+				}
+				break
+			}
+		}
+		if call == nil && len(r.Traced) > 0 {
+			r.account, call, value = r.Traced[0].From, r.Traced[0].Call, r.Traced[0].Value
+		}
+		o, err := unidecode.Reconcile(call, r.receipt, deployment(), r.account, value)
+		if err != nil {
+			return r, fmt.Errorf("invalid receipt; %w", err)
+		}
+		r.Outcome = &o
+	}
+	return r, nil
+}
+
+func transactionCmd(ctx context.Context, rpcURL string, out format, hash common.Hash) {
 	client, err := ethclient.DialContext(ctx, rpcURL)
 	checkErr("", err)
 
-	tx, _, err := client.TransactionByHash(ctx, hash)
+	if chainID == 0 {
+		id, err := client.ChainID(ctx)
+		checkErr("", err)
+		useChain(id.Uint64())
+	} else {
+		useChain(chainID)
+	}
+
+	r, err := decodeTx(ctx, client, hash, traceFlag)
+	checkErr("", err)
+
+	txSender = &r.from
+	v3Nonce = func(npm common.Address, tokenID *big.Int) (*big.Int, error) {
+		// The nonce prior to the transaction, as the permit increments it
+		var block *big.Int
+		if r.receipt != nil {
+			block = new(big.Int).Sub(r.receipt.BlockNumber, common.Big1)
+		}
+		data := append([]byte{0x99, 0xfb, 0xab, 0x88}, common.LeftPadBytes(tokenID.Bytes(), 32)...)
+		b, err := client.CallContract(ctx, ethereum.CallMsg{To: &npm, Data: data}, block)
+		if err != nil {
+			return nil, fmt.Errorf("positions(%s) failed; %w", tokenID, err)
+		} else if len(b) < 0x20 {
+			return nil, fmt.Errorf("positions(%s) returned no data", tokenID)
+		}
+		return new(big.Int).SetBytes(b[:0x20]), nil
+	}
+
+	decimals := make(map[common.Address]int)
+	tokenDecimals = func(token common.Address) (int, bool) {
+		if token == unidecode.ETH {
+			return 18, true
+		} else if d, ok := decimals[token]; ok {
+			return d, d >= 0
+		}
+		// decimals()
+		b, err := client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: []byte{0x31, 0x3c, 0xe5, 0x67}}, nil)
+		if err != nil || len(b) < 0x20 || new(big.Int).SetBytes(b[:0x20]).Cmp(big.NewInt(77)) > 0 {
+			decimals[token] = -1
+			return 0, false
+		}
+		decimals[token] = int(b[0x1f])
+		return decimals[token], true
+	}
+
+	checkErr("", out(os.Stdout, r))
+}
+
+// revertJSON is the JSON representation of decoded revert data.
+type revertJSON struct {
+	Selector  string   `json:"selector,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Signature string   `json:"signature,omitempty"`
+	Contracts []string `json:"contracts,omitempty"`
+	Error     string   `json:"error"`
+}
+
+// decodeRevertJSON decodes the revert data b.
+func decodeRevertJSON(b []byte) revertJSON {
+	r := revertJSON{Error: unidecode.DecodeRevert(b).Error()}
+	if len(b) >= 4 {
+		r.Selector = fmt.Sprintf("0x%x", b[:4])
+	}
+	if sig, ok := unidecode.LookupError(b); ok {
+		r.Name, r.Signature, r.Contracts = sig.Name, sig.Signature, sig.Contracts
+	}
+	return r
+}
+
+func revertCmd(out format, data []byte) {
+	if bytes.HasPrefix(data, []byte("0x")) {
+		data = data[2:]
+	}
+
+	b := make([]byte, hex.DecodedLen(len(data)))
+	_, err := hex.Decode(b, data)
 	checkErr("", err)
 
-	process(isJSON, isPretty, tx.Data())
+	checkErr("", out(os.Stdout, decodeRevertJSON(b)))
+}
+
+func loadRegistry() {
+	if registryPath != "" {
+		checkErr("invalid registry file; %s", chains.LoadFile(registryPath))
+	}
 }
 
 func pipedOrArg(args []string) ([]byte, error) {
@@ -410,30 +707,135 @@ func pipedOrArg(args []string) ([]byte, error) {
 	return b, err
 }
 
+// errEmptyInput is returned when parsing an empty input.
+var errEmptyInput = errors.New("empty input")
+
+// parseHex decodes hex encoded data, which may be surrounded by whitespace or quotes, and 0x prefixed.
+func parseHex(s []byte) ([]byte, error) {
+	s = bytes.TrimSpace(s)
+	s = bytes.Trim(s, "\"")
+	s = bytes.TrimPrefix(s, []byte("0x"))
+	if len(s) == 0 {
+		return nil, errEmptyInput
+	}
+	b := make([]byte, hex.DecodedLen(len(s)))
+	if _, err := hex.Decode(b, s); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 var usageFmt = `Usage: %s [options...] command [args...]
 
 COMMANDS:
 
-  calldata [FLAGS] CALLDATA       decodes raw execute calldata
-  tx [FLAGS] HASH                 decodes an execute transactions calldata
+  calldata [FLAGS] CALLDATA       decodes raw Uniswap contract calldata
+  calldata -batch [FLAGS] [FILE]  decodes newline delimited calldata, or a CSV column, of FILE or stdin as JSON lines
+  calldata -annotate CALLDATA     prints calldata as 32 byte words, each labelled with the fields decoded from it
+  tx [FLAGS] HASH                 decodes a Uniswap contract transactions calldata and receipt
+  revert [FLAGS] DATA             decodes revert data into its custom error
+  scan [FLAGS]                    decodes the router transactions of a block range as JSON lines
+  watch [FLAGS]                   decodes pending router transactions as JSON lines, as they arrive
+  diff [FLAGS] A B                compares two decoded calls, each calldata or a transaction hash
+  serve [FLAGS]                   serves an HTTP API decoding calldata, transactions and revert data as JSON
+  -schema                         prints the versioned JSON Schema of the JSON output
+
+  Supported contracts are the Universal Router, V4 PositionManager, V3 NonfungiblePositionManager, SwapRouter02,
+  UniswapV2Router02, Permit2 and the UniswapX reactors. Calls made through a Safe (execTransaction), MultiSend,
+  ERC-4337 EntryPoint (handleOps), smart account (execute*) or Multicall3 (aggregate*) are unwrapped and decoded.
 
 FLAGS
 
   -json                           outputs compressed JSON
   -jsonpretty                     outputs pretty JSON
+  -format                         output format of calldata, tx, revert and diff; text, json, jsonpretty, yaml, csv or
+                                  table (DEFAULT: text). csv and table write a row per command, action, UniswapX order
+                                  or Permit2 call, with the token and amount it moves and its recipient, or per change
+  -chain                          chain ID used to label addresses (DEFAULT: 1, or the RPC chain for tx and diff)
+  -registry                       JSON file of deployments overriding the built-in registry
+
+  calldata
+    -batch                        decodes many inputs, writing a JSON line, with its index and either the call or the
+                                  error, per input in input order
+    -column                       with -batch, the CSV column holding calldata; a name of the header record, or a zero
+                                  based index when the input has no header
+    -concurrency                  with -batch, number of inputs decoded concurrently (DEFAULT: number of CPUs)
+    -where                        with -batch, only writes inputs whose call matches the filter expression
+    -annotate                     writes the selector and each 32 byte word following it, by offset, labelled with the
+                                  fields decoded from it, "layout" for offsets and lengths, or "!! unconsumed" for
+                                  words the decoders didn't read. Words of calls nested within the calldata, such as
+                                  those of a multicall, may straddle fields
 
   tx
     -rpc                          URL of an RPC API endpoint (DEFAULT: http://localhost:8545)
+    -trace                        decodes internal calls to known contracts, using debug_traceTransaction
+
+  scan
+    -rpc                          URL of an RPC API endpoint (DEFAULT: http://localhost:8545)
+    -from                         first block to scan, unless resuming from a checkpoint
+    -to                           last block to scan (DEFAULT: latest)
+    -router                       comma separated router addresses (DEFAULT: the chain's Universal Router)
+    -concurrency                  number of blocks fetched concurrently (DEFAULT: 4)
+    -checkpoint                   file recording the last scanned block; an existing checkpoint resumes after it
+    -receipts                     fetches and reconciles the receipt of each transaction
+    -where                        only writes transactions whose call matches the filter expression
+
+  watch
+    -rpc                          websocket URL of an RPC API endpoint supporting full pending transaction
+                                  subscriptions (DEFAULT: ws://localhost:8546)
+    -router                       comma separated router addresses (DEFAULT: the chain's Universal Router)
+    -command                      comma separated command types, such as V4_SWAP,V3_SWAP_EXACT_IN
+    -token                        comma separated token addresses referenced by the commands, where ETH is 0x0
+    -pool                         comma separated V4 pool IDs swapped through, or initialized
+    -min-value                    minimum ETH sent with the transaction, such as 0.5
+    -where                        only writes transactions whose call matches the filter expression
+
+  Filter expressions compare the fields of each command, and each of its actions, by their JSON names, such as
+  command == V4_SWAP && action.currencyIn == 0x0 && amountIn > 1e18. command and action are the types, while a bare
+  field is of the action, or else the command. Comparisons are combined with &&, || and !, and grouped with parentheses.
+  Only Universal Router calls, including those wrapped by a wallet call, match.
+
+  diff
+    -rpc                          URL of an RPC API endpoint, used for transaction hashes (DEFAULT: http://localhost:8545)
+
+  diff writes a line per field added (+), removed (-) or changed (~) from A to B, such as
+  ~ Commands[1].Actions[0].AmountIn: 1000000 -> 1200000. Commands, actions and orders are matched by type, so those
+  inserted or removed are reported as such. Transactions are also compared by recipient and value when both are
+  hashes, and calldata can't be 32 bytes, which is taken as a hash.
+
+  serve
+    -addr                         address to listen on (DEFAULT: localhost:8080)
+    -rpc                          URL of an RPC API endpoint used by /tx (DEFAULT: http://localhost:8545)
+    -max-body                     maximum request body size, in bytes (DEFAULT: 1048576)
+    -timeout                      maximum duration of a request (DEFAULT: 30s)
+
+    POST /decode                  hex calldata body, responding with the decoded call, as calldata -json
+    POST /summarize               hex calldata body, responding with the message, commands, tokens and pools
+    POST /revert                  hex revert data body, responding as revert -json
+    GET  /tx/{hash}               the decoded transaction, as tx -json; ?trace=true decodes internal calls
+    GET  /schema                  the JSON Schema of responses, as -schema
+    GET  /healthz                 responds with {"status":"ok"} while serving
+
+    Errors respond with {"error":{"code":"...","message":"..."}}, and ?pretty=true indents responses.
 
 EXAMPLES
 
   unidecode calldata "3593564c000000000000000000000..."
   unidecode calldata -json "3593564c000000000000000000000..."
   unidecode calldata -jsonpretty "3593564c000000000000000000000..."
+  unidecode calldata -format table "3593564c000000000000000000000..."
+  unidecode calldata -annotate "3593564c000000000000000000000..."
+
+  unidecode calldata -batch calldata.txt > decoded.jsonl
+  unidecode calldata -batch -column input -concurrency 16 < transactions.csv > decoded.jsonl
+  unidecode calldata -batch -where 'command == V3_SWAP_EXACT_IN && path[0].fee == 500' calldata.txt > swaps.jsonl
+
+  unidecode revert 0x8b063d73000000000000000000000...
 
   unidecode tx 0x061c3b1c472fb8727648e106e002b620b413e46d2b3e50d70dd7cefac4e110a3
   unidecode tx -json 0x061c3b1c472fb8727648e106e002b620b413e46d2b3e50d70dd7cefac4e110a3
   unidecode tx -jsonpretty 0x061c3b1c472fb8727648e106e002b620b413e46d2b3e50d70dd7cefac4e110a3
+  unidecode tx -format csv 0x061c3b1c472fb8727648e106e002b620b413e46d2b3e50d70dd7cefac4e110a3 > swap.csv
 
   unidecode tx \
     -rpc "https://mainnet.infura.io/v3/00000000000000000000000000000000" \
@@ -443,6 +845,17 @@ EXAMPLES
     -jsonpretty \
     -rpc "https://mainnet.infura.io/v3/00000000000000000000000000000000" \
     0x061c3b1c472fb8727648e106e002b620b413e46d2b3e50d70dd7cefac4e110a3
+
+  unidecode scan -from 20000000 -to 20000100 -concurrency 8 -checkpoint scan.checkpoint > swaps.jsonl
+
+  unidecode watch -rpc ws://localhost:8546 -command V4_SWAP -min-value 1
+  unidecode watch -where 'command == V4_SWAP && action == SWAP_EXACT_IN_SINGLE && amountIn >= 10e18'
+
+  unidecode diff 3593564c000000000000000000000... 3593564c000000000000000000000...
+  unidecode diff -json 0x061c3b1c472fb8727648e106e002b620b413e46d2b3e50d70dd7cefac4e110a3 3593564c000000000000000000000...
+
+  unidecode serve -addr :8080 -rpc http://localhost:8545
+  curl -d 3593564c000000000000000000000... localhost:8080/decode
 `
 
 func usage() {
@@ -453,20 +866,80 @@ func usage() {
 var (
 	jsonFlag       bool
 	jsonPrettyFlag bool
+	formatFlag     string
+	whereFlag      string
 	rpcURL         string
+	traceFlag      bool
+	chainID        uint64
+	registryPath   string
+	chains         = registry.Default()
+
+	// Only known when decoding a transaction
+	txSender      *common.Address
+	v3Nonce       func(npm common.Address, tokenID *big.Int) (*big.Int, error)
+	tokenDecimals func(token common.Address) (int, bool)
 )
 
 func main() {
 	mainFlags := flag.NewFlagSet("main", flag.ExitOnError)
 	calldataFlags := flag.NewFlagSet("calldata", flag.ExitOnError)
 	txFlags := flag.NewFlagSet("tx", flag.ExitOnError)
+	revertFlags := flag.NewFlagSet("revert", flag.ExitOnError)
+	scanFlags := flag.NewFlagSet("scan", flag.ExitOnError)
+	watchFlags := flag.NewFlagSet("watch", flag.ExitOnError)
+	serveFlags := flag.NewFlagSet("serve", flag.ExitOnError)
+	diffFlags := flag.NewFlagSet("diff", flag.ExitOnError)
 
 	calldataFlags.BoolVar(&jsonFlag, "json", false, "outputs results in JSON")
 	calldataFlags.BoolVar(&jsonPrettyFlag, "jsonpretty", false, "outputs results in pretty JSON")
+	calldataFlags.StringVar(&formatFlag, "format", "", "output format; text, json, jsonpretty, yaml, csv or table")
+	calldataFlags.BoolVar(&batchFlag, "batch", false, "decodes newline delimited calldata of FILE, or stdin, as JSON lines")
+	calldataFlags.StringVar(&batchColumn, "column", "", "with -batch, the CSV column holding calldata, by name or index")
+	calldataFlags.IntVar(&batchConcurrency, "concurrency", runtime.NumCPU(), "with -batch, number of inputs decoded concurrently")
+	calldataFlags.StringVar(&whereFlag, "where", "", "with -batch, filter expression the decoded calls written must match")
+	calldataFlags.BoolVar(&annotateFlag, "annotate", false, "outputs the calldata as words, each labelled with its fields")
 
 	txFlags.BoolVar(&jsonFlag, "json", false, "outputs results in JSON")
 	txFlags.BoolVar(&jsonPrettyFlag, "jsonpretty", false, "outputs results in pretty JSON")
+	txFlags.StringVar(&formatFlag, "format", "", "output format; text, json, jsonpretty, yaml, csv or table")
 	txFlags.StringVar(&rpcURL, "rpc", "http://localhost:8545", "JSON RPC URL")
+	txFlags.BoolVar(&traceFlag, "trace", false, "decodes internal calls using debug_traceTransaction")
+
+	revertFlags.BoolVar(&jsonFlag, "json", false, "outputs results in JSON")
+	revertFlags.BoolVar(&jsonPrettyFlag, "jsonpretty", false, "outputs results in pretty JSON")
+	revertFlags.StringVar(&formatFlag, "format", "", "output format; text, json, jsonpretty, yaml, csv or table")
+
+	scanFlags.StringVar(&rpcURL, "rpc", "http://localhost:8545", "JSON RPC URL")
+	scanFlags.Uint64Var(&scanFrom, "from", 0, "first block to scan")
+	scanFlags.Uint64Var(&scanTo, "to", 0, "last block to scan (DEFAULT: latest)")
+	scanFlags.StringVar(&scanRouters, "router", "", "comma separated router addresses (DEFAULT: the chain's Universal Router)")
+	scanFlags.IntVar(&scanConcurrency, "concurrency", 4, "number of blocks fetched concurrently")
+	scanFlags.StringVar(&scanCheckpoint, "checkpoint", "", "file recording the last scanned block, resuming after it")
+	scanFlags.BoolVar(&scanReceipts, "receipts", false, "fetches and reconciles the receipt of each transaction")
+	scanFlags.StringVar(&whereFlag, "where", "", "filter expression the decoded calls written must match")
+
+	watchFlags.StringVar(&rpcURL, "rpc", "ws://localhost:8546", "websocket JSON RPC URL")
+	watchFlags.StringVar(&watchRouters, "router", "", "comma separated router addresses (DEFAULT: the chain's Universal Router)")
+	watchFlags.StringVar(&watchCommands, "command", "", "comma separated command types, such as V4_SWAP")
+	watchFlags.StringVar(&watchTokens, "token", "", "comma separated token addresses, where ETH is the zero address")
+	watchFlags.StringVar(&watchPools, "pool", "", "comma separated V4 pool IDs")
+	watchFlags.StringVar(&watchMinValue, "min-value", "", "minimum ETH sent, such as 0.5")
+	watchFlags.StringVar(&whereFlag, "where", "", "filter expression the decoded calls written must match")
+
+	serveFlags.StringVar(&serveAddr, "addr", "localhost:8080", "address to listen on")
+	serveFlags.StringVar(&rpcURL, "rpc", "http://localhost:8545", "JSON RPC URL")
+	serveFlags.Int64Var(&serveMaxBody, "max-body", 1<<20, "maximum request body size, in bytes")
+	serveFlags.DurationVar(&serveTimeout, "timeout", 30*time.Second, "maximum duration of a request")
+
+	diffFlags.BoolVar(&jsonFlag, "json", false, "outputs results in JSON")
+	diffFlags.BoolVar(&jsonPrettyFlag, "jsonpretty", false, "outputs results in pretty JSON")
+	diffFlags.StringVar(&formatFlag, "format", "", "output format; text, json, jsonpretty, yaml, csv or table")
+	diffFlags.StringVar(&rpcURL, "rpc", "http://localhost:8545", "JSON RPC URL")
+
+	for _, fs := range []*flag.FlagSet{calldataFlags, txFlags, scanFlags, watchFlags, serveFlags, diffFlags} {
+		fs.Uint64Var(&chainID, "chain", 0, "chain ID used to label addresses")
+		fs.StringVar(&registryPath, "registry", "", "JSON file of deployments overriding the built-in registry")
+	}
 
 	if len(os.Args) < 2 {
 		usage()
@@ -486,28 +959,108 @@ func main() {
 		checkErr("", err)
 
 		args = calldataFlags.Args()
+		loadRegistry()
+
+		if batchFlag && annotateFlag {
+			checkErr("", errors.New("-annotate can't be used along with -batch"))
+		} else if batchFlag {
+			var path string
+			if len(args) > 0 {
+				path = args[0]
+			}
+			where, err := parseWhere(whereFlag)
+			checkErr("", err)
+			batchCmd(path, batchColumn, batchConcurrency, where)
+			return
+		} else if whereFlag != "" {
+			checkErr("", errors.New("-where can only be used along with -batch"))
+		}
 
+		out, err := outputFormat(formatFlag, jsonFlag, jsonPrettyFlag)
+		checkErr("", err)
 		b, err := pipedOrArg(args)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s\n", err)
 			usage()
 			os.Exit(1)
 		}
-		calldataCmd(jsonFlag, jsonPrettyFlag, b)
+		calldataCmd(out, b)
 	case "tx":
 		err := txFlags.Parse(args)
 		checkErr("", err)
 
 		args = txFlags.Args()
+		loadRegistry()
+		out, err := outputFormat(formatFlag, jsonFlag, jsonPrettyFlag)
+		checkErr("", err)
 		b, err := pipedOrArg(args)
 		if err != nil {
-			fmt.Fprint(os.Stderr, "%s\n", err)
+			fmt.Fprintf(os.Stderr, "%s\n", err)
 			usage()
 			os.Exit(1)
 		}
-		hash := common.BytesToHash(b)
+		hash := common.HexToHash(string(b))
 		ctx := context.Background()
-		transactionCmd(ctx, rpcURL, jsonFlag, jsonPrettyFlag, hash)
+		transactionCmd(ctx, rpcURL, out, hash)
+	case "scan":
+		err := scanFlags.Parse(args)
+		checkErr("", err)
+
+		loadRegistry()
+		where, err := parseWhere(whereFlag)
+		checkErr("", err)
+
+		ctx := context.Background()
+		scanCmd(ctx, rpcURL, scanFrom, scanTo, scanRouters, scanConcurrency, scanCheckpoint, scanReceipts, where)
+	case "watch":
+		err := watchFlags.Parse(args)
+		checkErr("", err)
+
+		loadRegistry()
+		filter, err := parseWatchFilter(watchCommands, watchTokens, watchPools, watchMinValue)
+		checkErr("", err)
+		filter.where, err = parseWhere(whereFlag)
+		checkErr("", err)
+
+		ctx := context.Background()
+		watchCmd(ctx, rpcURL, watchRouters, filter)
+	case "serve":
+		err := serveFlags.Parse(args)
+		checkErr("", err)
+
+		loadRegistry()
+		ctx := context.Background()
+		serveCmd(ctx, serveAddr, rpcURL)
+	case "diff":
+		err := diffFlags.Parse(args)
+		checkErr("", err)
+
+		args = diffFlags.Args()
+		loadRegistry()
+		out, err := outputFormat(formatFlag, jsonFlag, jsonPrettyFlag)
+		checkErr("", err)
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "expected two calldata or transaction hash arguments\n")
+			usage()
+			os.Exit(1)
+		}
+		ctx := context.Background()
+		diffCmd(ctx, rpcURL, out, args[0], args[1])
+	case "-schema":
+		schemaCmd()
+	case "revert":
+		err := revertFlags.Parse(args)
+		checkErr("", err)
+
+		out, err := outputFormat(formatFlag, jsonFlag, jsonPrettyFlag)
+		checkErr("", err)
+		b, err := pipedOrArg(revertFlags.Args())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			usage()
+			os.Exit(1)
+		}
+		revertCmd(out, b)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
 		usage()
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/internal/yamlx"
+	"github.com/juztin/unidecode/permit2"
+	"github.com/juztin/unidecode/uniswapx"
+)
+
+// document is decoded output, written in any of the output formats. JSON and YAML encode the document itself.
+type document interface {
+	// text writes the document as the indented text tree.
+	text(w io.Writer)
+	// records returns the document as rows of columns, starting with the column names, for CSV and table output.
+	records() [][]string
+}
+
+// format writes a document to w.
+type format func(w io.Writer, d document) error
+
+// formats are the output formats, by name.
+var formats = map[string]format{
+	"text":       writeText,
+	"json":       func(w io.Writer, d document) error { return encodeJSON(w, d, false) },
+	"jsonpretty": func(w io.Writer, d document) error { return encodeJSON(w, d, true) },
+	"yaml":       writeYAML,
+	"csv":        writeCSV,
+	"table":      writeTable,
+}
+
+// outputFormat returns the format named by -format, otherwise the JSON format selected by -json or -jsonpretty, or
+// text.
+func outputFormat(name string, isJSON, isPretty bool) (format, error) {
+	if name == "" {
+		switch {
+		case isPretty:
+			name = "jsonpretty"
+		case isJSON:
+			name = "json"
+		default:
+			name = "text"
+		}
+	} else if isJSON || isPretty {
+		return nil, fmt.Errorf("-format can't be used along with -json or -jsonpretty")
+	}
+
+	f, ok := formats[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(formats))
+		for n := range formats {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown format %q; expected one of %s", name, strings.Join(names, ", "))
+	}
+	return f, nil
+}
+
+func writeText(w io.Writer, d document) error {
+	d.text(w)
+	return nil
+}
+
+// encodeJSON writes v as a line of JSON, or as indented JSON when isPretty is set.
+func encodeJSON(w io.Writer, v interface{}, isPretty bool) error {
+	var (
+		b   []byte
+		err error
+	)
+	if isPretty {
+		b, err = jsonx.MarshalIndent(v, "", "  ")
+	} else {
+		b, err = jsonx.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+func writeYAML(w io.Writer, d document) error {
+	b, err := jsonx.Marshal(d)
+	if err != nil {
+		return err
+	}
+	b, err = yamlx.FromJSON(b)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func writeCSV(w io.Writer, d document) error {
+	c := csv.NewWriter(w)
+	c.WriteAll(d.records())
+	return c.Error()
+}
+
+// writeTable writes the records of d as aligned columns, with upper case column names and empty cells as "-".
+func writeTable(w io.Writer, d document) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, record := range d.records() {
+		cells := make([]string, len(record))
+		for j, cell := range record {
+			if i == 0 {
+				cell = strings.ToUpper(cell)
+			} else if cell == "" {
+				cell = "-"
+			}
+			cells[j] = cell
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// callColumns are the names of the columns of a row.
+var callColumns = []string{"call", "message", "index", "command", "action", "token", "amount", "recipient"}
+
+// row is a command, action, order or Permit2 call flattened into the token and amount it moves, and its recipient.
+//
+// The token is that of the amount, such as the input token of an exact input swap or the output token of an exact
+// output swap, where native ETH is the zero address.
+type row struct {
+	// call is the wallet or traced call the row is found within, as in the text output, or empty for the top-level
+	// call.
+	call      string
+	message   string
+	index     string
+	command   string
+	action    string
+	token     *common.Address
+	amount    *big.Int
+	recipient *common.Address
+}
+
+func (r row) record() []string {
+	var token, amount, recipient string
+	if r.token != nil {
+		token = r.token.Hex()
+	}
+	if r.amount != nil {
+		amount = r.amount.String()
+	}
+	if r.recipient != nil {
+		recipient = r.recipient.Hex()
+	}
+	return []string{r.call, r.message, r.index, r.command, r.action, token, amount, recipient}
+}
+
+// callRecords returns rows as records, starting with the column names.
+func callRecords(rows []row) [][]string {
+	records := [][]string{callColumns}
+	for _, r := range rows {
+		records = append(records, r.record())
+	}
+	return records
+}
+
+// addr returns a pointer to a copy of a.
+func addr(a common.Address) *common.Address {
+	return &a
+}
+
+// joinPath formats the path of a wallet or traced call as in the text output.
+func joinPath(p []int) string {
+	path := make([]string, len(p))
+	for i, n := range p {
+		path[i] = strconv.Itoa(n)
+	}
+	return strings.Join(path, ",")
+}
+
+// callRows flattens the decoded call v, of message type t, found within the call named call.
+func callRows(call string, t fmt.Stringer, v interface{}) []row {
+	var rows []row
+	switch c := v.(type) {
+	case unidecode.Execute:
+		for i, cmd := range c.Commands {
+			r := commandRow(cmd)
+			r.call, r.message, r.index, r.command = call, t.String(), strconv.Itoa(i), cmd.Type().String()
+			rows = append(rows, r)
+			for j, a := range cmd.Actions() {
+				r := actionRow(a)
+				r.call, r.message, r.index, r.command = call, t.String(), fmt.Sprintf("%d.%d", i, j), cmd.Type().String()
+				r.action = a.Type().String()
+				rows = append(rows, r)
+			}
+		}
+	case unidecode.Wallet:
+		for _, wc := range c.Calls {
+			if wc.Call != nil {
+				rows = append(rows, callRows("CALL ["+joinPath(wc.Path)+"]", stringer(wc.Message), wc.Call)...)
+			}
+		}
+	case uniswapx.Execute:
+		for i, signed := range c.Orders {
+			r := orderRow(signed.Order)
+			r.call, r.message, r.index, r.command = call, t.String(), strconv.Itoa(i), c.Method.String()
+			r.action = signed.Order.Type().String()
+			rows = append(rows, r)
+		}
+	case permit2.Call:
+		r := permit2Row(c)
+		r.call, r.message, r.index, r.command = call, t.String(), "0", c.Type().String()
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+func commandRow(cmd commands.Command) row {
+	switch c := cmd.(type) {
+	case commands.V3SwapExactIn:
+		r := row{amount: c.AmountIn, recipient: addr(c.Recipient)}
+		if len(c.Path) > 0 {
+			r.token = addr(c.Path[0].TokenIn)
+		}
+		return r
+	case commands.V3SwapExactOut:
+		// The path of an exact output swap is reversed
+		r := row{amount: c.AmountOut, recipient: addr(c.Recipient)}
+		if len(c.Path) > 0 {
+			r.token = addr(c.Path[0].TokenIn)
+		}
+		return r
+	case commands.V2SwapExactIn:
+		r := row{amount: c.AmountIn, recipient: addr(c.Recipient)}
+		if len(c.Path) > 0 {
+			r.token = addr(c.Path[0])
+		}
+		return r
+	case commands.V2SwapExactOut:
+		r := row{amount: c.AmountOut, recipient: addr(c.Recipient)}
+		if len(c.Path) > 0 {
+			r.token = addr(c.Path[len(c.Path)-1])
+		}
+		return r
+	case commands.Permit2TransferFrom:
+		return row{token: addr(c.Token), amount: c.Amount, recipient: addr(c.Recipient)}
+	case commands.Permit2Permit:
+		return row{token: addr(c.Details.Token), amount: c.Details.Amount}
+	case commands.Sweep:
+		return row{token: addr(c.Token), amount: c.AmountMin, recipient: addr(c.Recipient)}
+	case commands.Transfer:
+		return row{token: addr(c.Token), amount: c.Value, recipient: addr(c.Recipient)}
+	case commands.PayPortion:
+		return row{token: addr(c.Token), recipient: addr(c.Recipient)}
+	case commands.WrapWETH:
+		return row{token: addr(unidecode.ETH), amount: c.AmountMin, recipient: addr(c.Recipient)}
+	case commands.UnwrapWETH:
+		return row{token: addr(weth()), amount: c.AmountMin, recipient: addr(c.Recipient)}
+	case commands.BalanceCheckERC20:
+		return row{token: addr(c.Token), amount: c.MinBalance}
+	}
+	return row{}
+}
+
+func actionRow(action actions.Action) row {
+	switch a := action.(type) {
+	case actions.SwapExactInSingle:
+		token := a.PoolKey.Currency1
+		if a.ZeroForOne {
+			token = a.PoolKey.Currency0
+		}
+		return row{token: addr(token), amount: a.AmountIn}
+	case actions.SwapExactOutSingle:
+		token := a.PoolKey.Currency0
+		if a.ZeroForOne {
+			token = a.PoolKey.Currency1
+		}
+		return row{token: addr(token), amount: a.AmountOut}
+	case actions.SwapExactIn:
+		return row{token: addr(a.CurrencyIn), amount: a.AmountIn}
+	case actions.SwapExactOut:
+		return row{token: addr(a.CurrencyOut), amount: a.AmountOut}
+	case actions.Settle:
+		return row{token: addr(a.Currency), amount: a.Amount}
+	case actions.SettleAll:
+		return row{token: addr(a.Currency), amount: a.MaxAmount}
+	case actions.Take:
+		return row{token: addr(a.Currency), amount: a.Amount, recipient: addr(a.Recipient)}
+	case actions.TakeAll:
+		return row{token: addr(a.Currency), amount: a.MinAmount}
+	case actions.TakePortion:
+		return row{token: addr(a.Currency), recipient: addr(a.Recipient)}
+	case actions.TakePair:
+		return row{recipient: addr(a.Recipient)}
+	case actions.Sweep:
+		return row{token: addr(a.Currency), recipient: addr(a.Recipient)}
+	case actions.Wrap:
+		return row{token: addr(a.Currency), amount: a.Amount}
+	case actions.Unwrap:
+		return row{token: addr(a.Wrapped), amount: a.Amount}
+	case actions.CloseCurrency:
+		return row{token: addr(a.Currency)}
+	case actions.ClearOrTake:
+		return row{token: addr(a.Currency), amount: a.AmountMax}
+	case actions.MintPosition:
+		return row{recipient: addr(a.Owner)}
+	case actions.MintPositionFromDeltas:
+		return row{recipient: addr(a.Owner)}
+	case actions.V3Mint:
+		return row{recipient: addr(a.Recipient)}
+	case actions.V3Collect:
+		return row{recipient: addr(a.Recipient)}
+	case actions.V3SelfPermit:
+		return row{token: addr(a.Token), amount: a.Value}
+	case actions.V3SelfPermitAllowed:
+		return row{token: addr(a.Token)}
+	}
+	return row{}
+}
+
+// orderRow flattens an order into its input.
+func orderRow(order uniswapx.Order) row {
+	switch o := order.(type) {
+	case uniswapx.DutchOrder:
+		return row{token: addr(o.Input.Token), amount: o.Input.StartAmount}
+	case uniswapx.ExclusiveDutchOrder:
+		return row{token: addr(o.Input.Token), amount: o.Input.StartAmount}
+	case uniswapx.V2DutchOrder:
+		return row{token: addr(o.BaseInput.Token), amount: o.BaseInput.StartAmount}
+	case uniswapx.PriorityOrder:
+		return row{token: addr(o.Input.Token), amount: o.Input.Amount}
+	}
+	return row{}
+}
+
+func permit2Row(call permit2.Call) row {
+	switch c := call.(type) {
+	case permit2.Permit:
+		return row{token: addr(c.Details.Token), amount: c.Details.Amount}
+	case permit2.TransferFrom:
+		return row{token: addr(c.Token), amount: c.Amount, recipient: addr(c.To)}
+	case permit2.Approve:
+		return row{token: addr(c.Token), amount: c.Amount}
+	case permit2.InvalidateNonces:
+		return row{token: addr(c.Token)}
+	case permit2.PermitTransferFrom:
+		d := c.TransferDetails
+		return row{token: addr(c.Permitted.Token), amount: d.RequestedAmount, recipient: addr(d.To)}
+	case permit2.PermitWitnessTransferFrom:
+		d := c.TransferDetails
+		return row{token: addr(c.Permitted.Token), amount: d.RequestedAmount, recipient: addr(d.To)}
+	}
+	return row{}
+}
+
+// callDoc is a decoded call of message type t, as output by `calldata`.
+type callDoc struct {
+	t fmt.Stringer
+	v interface{}
+}
+
+func (d callDoc) MarshalJSON() ([]byte, error) {
+	return jsonx.Marshal(d.v)
+}
+
+func (d callDoc) text(w io.Writer) {
+	printCall(w, d.t, d.v)
+}
+
+func (d callDoc) records() [][]string {
+	return callRecords(callRows("", d.t, d.v))
+}
+
+func (r txResult) text(w io.Writer) {
+	if r.Call != nil {
+		printCall(w, r.message, r.Call)
+	} else {
+		to := "contract creation"
+		if r.tx.To() != nil {
+			to = label(*r.tx.To())
+		}
+		fmt.Fprintf(w, "%s: %s\n", r.message, to)
+	}
+	for _, c := range r.Traced {
+		printTracedCall(w, c)
+	}
+	if r.Outcome == nil {
+		fmt.Fprintln(w, "RECEIPT: pending")
+	} else {
+		printOutcome(w, r.account, *r.Outcome)
+	}
+}
+
+func (r txResult) records() [][]string {
+	var rows []row
+	if r.Call != nil {
+		rows = callRows("", r.message, r.Call)
+	}
+	for _, c := range r.Traced {
+		if c.Call != nil {
+			rows = append(rows, callRows("TRACE ["+joinPath(c.Path)+"]", stringer(c.Message), c.Call)...)
+		}
+	}
+	return callRecords(rows)
+}
+
+func (r revertJSON) text(w io.Writer) {
+	fmt.Fprintf(w, "REVERT: %s\n", r.Error)
+	if r.Signature != "" {
+		fmt.Fprintf(w, "  Selector: %s\n", r.Selector)
+		fmt.Fprintf(w, "  Signature: %s\n", r.Signature)
+		fmt.Fprintf(w, "  Contracts: %s\n", strings.Join(r.Contracts, ", "))
+	}
+}
+
+func (r revertJSON) records() [][]string {
+	return [][]string{
+		{"selector", "name", "signature", "contracts", "error"},
+		{r.Selector, r.Name, r.Signature, strings.Join(r.Contracts, " "), r.Error},
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/filter"
+	"github.com/juztin/unidecode/hex"
+)
+
+var (
+	scanFrom        uint64
+	scanTo          uint64
+	scanRouters     string
+	scanConcurrency int
+	scanCheckpoint  string
+	scanReceipts    bool
+)
+
+// scanRetries is how many times a block is fetched before the scan fails.
+const scanRetries = 3
+
+// rpcTransaction is the subset of an RPC transaction needed to decode router transactions.
+//
+// Transactions are decoded loosely, rather than as `types.Transaction`, so transaction types unknown to go-ethereum,
+// such as L2 deposits, don't fail the blocks or subscriptions holding them.
+type rpcTransaction struct {
+	Hash             common.Hash     `json:"hash"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"` // nil while pending
+	From             common.Address  `json:"from"`
+	To               *common.Address `json:"to"`
+	Value            *hexutil.Big    `json:"value"`
+	Input            hexutil.Bytes   `json:"input"`
+}
+
+// scanBlock is the subset of an `eth_getBlockByNumber` block needed to find router transactions.
+type scanBlock struct {
+	Number       hexutil.Uint64   `json:"number"`
+	Hash         common.Hash      `json:"hash"`
+	Timestamp    hexutil.Uint64   `json:"timestamp"`
+	Transactions []rpcTransaction `json:"transactions"`
+}
+
+// routerTx is a decoded transaction sent to a router.
+type routerTx struct {
+	Hash    common.Hash    `json:"hash"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Value   *big.Int       `json:"value"`
+	Message string         `json:"message"`
+	Call    interface{}    `json:"call,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// decodeRouterTx decodes tx, setting Error when its calldata isn't a supported message.
+func decodeRouterTx(tx rpcTransaction) routerTx {
+	r := routerTx{Hash: tx.Hash, From: tx.From, Value: new(big.Int)}
+	if tx.To != nil {
+		r.To = *tx.To
+	}
+	if tx.Value != nil {
+		r.Value = tx.Value.ToInt()
+	}
+
+	t := unidecode.MessageType(tx.Input)
+	r.Message = t.String()
+	if t == unidecode.UnknownMessage {
+		r.Error = fmt.Errorf("%w; unsupported method %x", unidecode.ErrIncorrectMethodSig, hex.MethodSig(tx.Input)).Error()
+	} else if v, err := decodeCall(tx.Input); err != nil {
+		r.Error = err.Error()
+	} else {
+		r.Call = v
+	}
+	return r
+}
+
+// scanResult is a decoded router transaction of a block, written as a JSON line.
+type scanResult struct {
+	Block     uint64 `json:"block"`
+	Timestamp uint64 `json:"timestamp"`
+	Index     uint64 `json:"index"`
+	routerTx
+	Outcome *unidecode.Outcome `json:"outcome,omitempty"`
+}
+
+// scannedBlock holds the results of a block, or the error which stopped it from being scanned.
+type scannedBlock struct {
+	number  uint64
+	results []scanResult
+	err     error
+}
+
+// readCheckpoint returns the last block recorded in path, and false when there's no checkpoint yet.
+func readCheckpoint(path string) (uint64, bool) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, false
+	}
+	checkErr("invalid checkpoint; %s", err)
+
+	n, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	checkErr("invalid checkpoint; %s", err)
+	return n, true
+}
+
+// writeCheckpoint records block as the last block fully written, replacing path so it's never left partially written.
+func writeCheckpoint(path string, block uint64) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(fmt.Sprintf("%d\n", block)), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// parseRouters parses a comma separated list of addresses, defaulting to the Universal Router of the selected chain.
+func parseRouters(s string) (map[common.Address]bool, error) {
+	if s == "" {
+		d, ok := chains.Get(chainID)
+		if !ok || d.UniversalRouter == (common.Address{}) {
+			return nil, fmt.Errorf("missing -router; no Universal Router is known for chain %d", chainID)
+		}
+		s = d.UniversalRouter.Hex()
+	}
+	routers := make(map[common.Address]bool)
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		} else if !common.IsHexAddress(r) {
+			return nil, fmt.Errorf("invalid router address: %s", r)
+		}
+		routers[common.HexToAddress(r)] = true
+	}
+	return routers, nil
+}
+
+// reconcileScanned fetches the receipt of r, reconciling it against its decoded call.
+func reconcileScanned(ctx context.Context, client *ethclient.Client, r *scanResult) error {
+	receipt, err := client.TransactionReceipt(ctx, r.Hash)
+	if err != nil {
+		return fmt.Errorf("unable to fetch receipt of %s; %w", r.Hash, err)
+	}
+	o, err := unidecode.Reconcile(r.Call, receipt, deployment(), r.From, r.Value)
+	if err != nil {
+		return fmt.Errorf("invalid receipt of %s; %w", r.Hash, err)
+	}
+	r.Outcome = &o
+	return nil
+}
+
+// fetchScanBlock returns the decoded router transactions of block n, matching where when it's set.
+func fetchScanBlock(ctx context.Context, client *ethclient.Client, n uint64, routers map[common.Address]bool, withReceipts bool, where *filter.Filter) ([]scanResult, error) {
+	var b *scanBlock
+	err := client.Client().CallContext(ctx, &b, "eth_getBlockByNumber", hexutil.EncodeUint64(n), true)
+	if err != nil {
+		return nil, err
+	} else if b == nil {
+		return nil, fmt.Errorf("block %d not found", n)
+	}
+
+	var results []scanResult
+	for i, tx := range b.Transactions {
+		if tx.To == nil || !routers[*tx.To] {
+			continue
+		}
+		r := scanResult{
+			Block:     uint64(b.Number),
+			Timestamp: uint64(b.Timestamp),
+			Index:     uint64(i),
+			routerTx:  decodeRouterTx(tx),
+		}
+		if !matchWhere(where, r.Call) {
+			continue
+		}
+		if withReceipts {
+			if err := reconcileScanned(ctx, client, &r); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// scanCmd decodes every transaction sent to one of routers within blocks from through to, writing each as a JSON line
+// in block order.
+//
+// Blocks are fetched by up to concurrency workers. When checkpoint is set, the scan resumes after the block recorded
+// within it, and records each block once its transactions have been written. When where is set, only transactions
+// whose call matches it are written.
+func scanCmd(ctx context.Context, rpcURL string, from, to uint64, routerList string, concurrency int, checkpoint string, withReceipts bool, where *filter.Filter) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	checkErr("", err)
+
+	if chainID == 0 {
+		id, err := client.ChainID(ctx)
+		checkErr("", err)
+		useChain(id.Uint64())
+	} else {
+		useChain(chainID)
+	}
+
+	routers, err := parseRouters(routerList)
+	checkErr("", err)
+
+	if checkpoint != "" {
+		if last, ok := readCheckpoint(checkpoint); ok && last >= from {
+			from = last + 1
+		}
+	}
+	if from == 0 {
+		checkErr("", fmt.Errorf("missing -from block"))
+	}
+	if to == 0 {
+		to, err = client.BlockNumber(ctx)
+		checkErr("unable to fetch the latest block; %s", err)
+	}
+	if from > to {
+		return
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// window bounds how far workers may run ahead of the next block to be written
+	window := make(chan struct{}, concurrency*2)
+	blocks := make(chan uint64)
+	scanned := make(chan scannedBlock)
+	go func() {
+		defer close(blocks)
+		for n := from; n <= to; n++ {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case blocks <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			for n := range blocks {
+				var results []scanResult
+				var err error
+				for attempt := 0; attempt < scanRetries; attempt++ {
+					if results, err = fetchScanBlock(ctx, client, n, routers, withReceipts, where); err == nil || ctx.Err() != nil {
+						break
+					}
+					time.Sleep(time.Duration(attempt+1) * 500 * time.Millisecond)
+				}
+				select {
+				case scanned <- scannedBlock{n, results, err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	pending := make(map[uint64]scannedBlock)
+	for next := from; next <= to; {
+		s := <-scanned
+		pending[s.number] = s
+		for s, ok := pending[next]; ok; s, ok = pending[next] {
+			if s.err != nil {
+				checkErr("", fmt.Errorf("unable to scan block %d; %w", s.number, s.err))
+			}
+			for _, r := range s.results {
+				printJSON(false, r)
+			}
+			if checkpoint != "" {
+				checkErr("unable to write checkpoint; %s", writeCheckpoint(checkpoint, next))
+			}
+			delete(pending, next)
+			<-window
+			next++
+		}
+	}
+}
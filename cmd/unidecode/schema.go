@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/events"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/path"
+	"github.com/juztin/unidecode/permit2"
+	"github.com/juztin/unidecode/uniswapx"
+	"github.com/juztin/unidecode/wallet"
+)
+
+// schemaID identifies the published schema.
+const schemaID = "https://raw.githubusercontent.com/juztin/unidecode/main/schema.json"
+
+// typed registers vs as the implementations of the interface V, each with its type as the "type" discriminator.
+func typed[T fmt.Stringer, V interface{ Type() T }](g *jsonx.Generator, vs ...V) {
+	for _, v := range vs {
+		g.Define(v, jsonx.Def{Types: []string{v.Type().String()}})
+		g.Union((*V)(nil), v)
+	}
+}
+
+// stringsOf returns the names of ts.
+func stringsOf[T fmt.Stringer](ts ...T) []string {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.String()
+	}
+	return names
+}
+
+// schema returns the JSON Schema of the JSON output of each command.
+func schema() ([]byte, error) {
+	g := jsonx.NewGenerator()
+
+	typed[commands.Type, commands.Command](g,
+		commands.V3SwapExactIn{}, commands.V3SwapExactOut{}, commands.Permit2TransferFrom{},
+		commands.Permit2PermitBatch{}, commands.Sweep{}, commands.Transfer{}, commands.PayPortion{},
+		commands.V2SwapExactIn{}, commands.V2SwapExactOut{}, commands.Permit2Permit{}, commands.WrapWETH{},
+		commands.UnwrapWETH{}, commands.Permit2TransferFromBatch{}, commands.BalanceCheckERC20{},
+		commands.V4Swap{}, commands.V3PositionManagerPermit{}, commands.V3PositionManagerCall{},
+		commands.V4InitializePool{}, commands.V4PositionManagerCall{}, commands.ExecuteSubPlan{})
+	typed[actions.Type, actions.Action](g,
+		actions.IncreaseLiquidity{}, actions.DecreaseLiquidity{}, actions.MintPosition{}, actions.BurnPosition{},
+		actions.IncreaseLiquidityFromDeltas{}, actions.MintPositionFromDeltas{}, actions.SwapExactInSingle{},
+		actions.SwapExactIn{}, actions.SwapExactOutSingle{}, actions.SwapExactOut{}, actions.Settle{},
+		actions.SettleAll{}, actions.SettlePair{}, actions.Take{}, actions.TakeAll{}, actions.TakePortion{},
+		actions.TakePair{}, actions.CloseCurrency{}, actions.ClearOrTake{}, actions.Sweep{}, actions.Wrap{},
+		actions.Unwrap{}, actions.V3Mint{}, actions.V3IncreaseLiquidity{}, actions.V3DecreaseLiquidity{},
+		actions.V3Collect{}, actions.V3Burn{}, actions.V3CreateAndInitializePool{}, actions.V3SelfPermit{},
+		actions.V3SelfPermitAllowed{})
+	typed[permit2.Type, permit2.Call](g,
+		permit2.Permit{}, permit2.PermitBatch{}, permit2.TransferFrom{}, permit2.TransferFromBatch{},
+		permit2.Approve{}, permit2.Lockdown{}, permit2.InvalidateNonces{}, permit2.InvalidateUnorderedNonces{},
+		permit2.PermitTransferFrom{}, permit2.PermitBatchTransferFrom{}, permit2.PermitWitnessTransferFrom{},
+		permit2.PermitBatchWitnessTransferFrom{})
+	typed[uniswapx.OrderType, uniswapx.Order](g,
+		uniswapx.DutchOrder{}, uniswapx.ExclusiveDutchOrder{}, uniswapx.V2DutchOrder{}, uniswapx.PriorityOrder{})
+	typed[events.Type, events.Event](g,
+		events.V2Swap{}, events.V3Swap{}, events.V4Swap{}, events.V4ModifyLiquidity{}, events.V4Initialize{},
+		events.Transfer{}, events.Deposit{}, events.Withdrawal{})
+
+	// V4 commands encode the actions they hold
+	g.Define(commands.V4Swap{}, jsonx.Def{
+		Types: []string{commands.V4_SWAP.String()},
+		Extra: map[string]interface{}{"actions": []actions.Action(nil)},
+	})
+	g.Define(commands.V4PositionManagerCall{}, jsonx.Def{
+		Types: []string{commands.V4_POSITION_MANAGER_CALL.String()},
+		Extra: map[string]interface{}{"actions": []actions.Action(nil)},
+	})
+
+	// Self permits encode whether they're only made when necessary by their type
+	g.Define(actions.V3SelfPermit{}, jsonx.Def{
+		Types: stringsOf(actions.V3_SELF_PERMIT, actions.V3_SELF_PERMIT_IF_NECESSARY),
+	})
+	g.Define(actions.V3SelfPermitAllowed{}, jsonx.Def{
+		Types: stringsOf(actions.V3_SELF_PERMIT_ALLOWED, actions.V3_SELF_PERMIT_ALLOWED_IF_NECESSARY),
+	})
+
+	g.Define(path.Key{}, jsonx.Def{})
+	g.Define(uniswapx.OrderInfo{}, jsonx.Def{})
+	g.Define(uniswapx.SignedOrder{}, jsonx.Def{})
+	g.Define(uniswapx.Execute{}, jsonx.Def{
+		Types: stringsOf(uniswapx.EXECUTE, uniswapx.EXECUTE_WITH_CALLBACK, uniswapx.EXECUTE_BATCH,
+			uniswapx.EXECUTE_BATCH_WITH_CALLBACK),
+		Description: "A UniswapX reactor call.",
+	})
+	g.Define(unidecode.Execute{}, jsonx.Def{
+		Description: "A Universal Router, PositionManager, NonfungiblePositionManager, SwapRouter02 or " +
+			"UniswapV2Router02 call, as Universal Router commands.",
+	})
+	g.Define(unidecode.Wallet{}, jsonx.Def{
+		Types: stringsOf(wallet.EXEC_TRANSACTION, wallet.MULTI_SEND, wallet.HANDLE_OPS, wallet.HANDLE_PACKED_OPS,
+			wallet.EXECUTE, wallet.EXECUTE_BATCH, wallet.EXECUTE_BATCH_NO_VALUE, wallet.EXECUTE_BATCH_CALLS,
+			wallet.EXECUTE_USER_OP, wallet.EXECUTE_USER_OP_WITH_ERROR_STRING, wallet.AGGREGATE,
+			wallet.TRY_AGGREGATE, wallet.AGGREGATE3, wallet.AGGREGATE3_VALUE),
+		Description: "A wallet call, with the supported calls found within its layers.",
+	})
+	g.Define(unidecode.WalletCall{}, jsonx.Def{})
+	g.Define(unidecode.TracedCall{}, jsonx.Def{})
+	g.Define(unidecode.Outcome{}, jsonx.Def{})
+
+	// Decoded calls are held as interface{}
+	var call interface{}
+	g.Union(&call, unidecode.Execute{}, uniswapx.Execute{}, unidecode.Wallet{}, permit2.Permit{},
+		permit2.PermitBatch{}, permit2.TransferFrom{}, permit2.TransferFromBatch{}, permit2.Approve{},
+		permit2.Lockdown{}, permit2.InvalidateNonces{}, permit2.InvalidateUnorderedNonces{},
+		permit2.PermitTransferFrom{}, permit2.PermitBatchTransferFrom{}, permit2.PermitWitnessTransferFrom{},
+		permit2.PermitBatchWitnessTransferFrom{})
+
+	g.Name(&call, "Call")
+	g.Name(txResult{}, "Transaction")
+	g.Name(revertJSON{}, "Revert")
+	g.Name(scanResult{}, "ScanResult")
+	g.Name(watchResult{}, "WatchResult")
+	g.Name(batchResult{}, "BatchResult")
+	g.Name(diffResult{}, "Diff")
+	g.Name(diffChange{}, "DiffChange")
+	g.Name(annotation{}, "Annotation")
+	g.Name(annotatedWord{}, "AnnotatedWord")
+	g.Name(callSummary{}, "Summary")
+	g.Name(commandSummary{}, "CommandSummary")
+	g.Name(errorBody{}, "ErrorResponse")
+	g.Name(apiError{}, "Error")
+
+	return g.Schema(schemaID, "unidecode",
+		"JSON output of unidecode; a Call (calldata -json, POST /decode), Transaction (tx -json, "+
+			"GET /tx), Revert (revert -json, POST /revert), ScanResult (scan), WatchResult (watch), "+
+			"BatchResult (calldata -batch), Annotation (calldata -annotate), Diff (diff -json), Summary (POST /summarize) or ErrorResponse (serve errors).",
+		unidecode.SchemaVersion,
+		&call, txResult{}, revertJSON{}, scanResult{}, watchResult{}, batchResult{}, annotation{}, diffResult{},
+		callSummary{}, errorBody{})
+}
+
+// schemaCmd prints the JSON Schema of the JSON output.
+func schemaCmd() {
+	b, err := schema()
+	checkErr("", err)
+	fmt.Fprintf(os.Stdout, "%s\n", b)
+}
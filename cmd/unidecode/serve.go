@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/registry"
+)
+
+var (
+	serveAddr    string
+	serveMaxBody int64
+	serveTimeout time.Duration
+)
+
+// apiError is the error of an unsuccessful response.
+type apiError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+// errorBody is the body of an unsuccessful response.
+type errorBody struct {
+	Error *apiError `json:"error"`
+}
+
+func badRequest(code string, err error) *apiError {
+	return &apiError{http.StatusBadRequest, code, err.Error()}
+}
+
+// callSummary is a compact description of a decoded call, naming what it does rather than each of its arguments.
+type callSummary struct {
+	Message  string           `json:"message"`
+	Commands []commandSummary `json:"commands,omitempty"`
+	Tokens   []common.Address `json:"tokens,omitempty"`
+	Pools    []common.Hash    `json:"pools,omitempty"`
+	Deadline *time.Time       `json:"deadline,omitempty"`
+	// Calls are the summaries of the calls wrapped by a wallet call.
+	Calls []callSummary `json:"calls,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// commandSummary is a command type along with the types of its actions.
+type commandSummary struct {
+	Type    string   `json:"type"`
+	Actions []string `json:"actions,omitempty"`
+}
+
+// summarize summarizes the call v, of message type t.
+func summarize(t string, v interface{}) callSummary {
+	s := callSummary{Message: t}
+	switch c := v.(type) {
+	case unidecode.Execute:
+		for _, cmd := range c.Commands {
+			cs := commandSummary{Type: cmd.Type().String()}
+			for _, a := range cmd.Actions() {
+				cs.Actions = append(cs.Actions, a.Type().String())
+			}
+			s.Commands = append(s.Commands, cs)
+		}
+		s.Tokens, s.Pools, s.Deadline = c.Tokens(), c.PoolIDs(), c.Deadline
+	case unidecode.Wallet:
+		for _, wc := range c.Calls {
+			inner := summarize(wc.Message, wc.Call)
+			inner.Error = wc.DecodeError
+			s.Calls = append(s.Calls, inner)
+		}
+	}
+	return s
+}
+
+// parseHexBody reads a hex encoded request body, optionally quoted and 0x prefixed, of at most serveMaxBody bytes.
+func parseHexBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, serveMaxBody))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, &apiError{http.StatusRequestEntityTooLarge, "request_too_large",
+			fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)}
+	} else if err != nil {
+		return nil, badRequest("invalid_body", err)
+	}
+
+	b, err := parseHex(body)
+	if errors.Is(err, errEmptyInput) {
+		return nil, badRequest("invalid_body", errors.New("empty request body, expected hex encoded data"))
+	} else if err != nil {
+		return nil, badRequest("invalid_hex", err)
+	}
+	return b, nil
+}
+
+// writeJSON writes v as the response body, indented when the `pretty` query parameter is set.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	var (
+		b   []byte
+		err error
+	)
+	if pretty, _ := strconv.ParseBool(r.URL.Query().Get("pretty")); pretty {
+		b, err = jsonx.MarshalIndent(v, "", "  ")
+	} else {
+		b, err = jsonx.Marshal(v)
+	}
+	if err != nil {
+		status = http.StatusInternalServerError
+		b, _ = json.Marshal(errorBody{&apiError{Code: "internal", Message: err.Error()}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(b, '\n'))
+}
+
+// writeError writes err as a structured error body, with a status derived from its type.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var e *apiError
+	switch {
+	case errors.As(err, &e):
+	case errors.Is(err, errMalformedCallData):
+		e = badRequest("invalid_calldata", err)
+	case errors.Is(err, errUnsupportedMessage):
+		e = &apiError{http.StatusUnprocessableEntity, "unsupported_message", err.Error()}
+	case errors.Is(err, ethereum.NotFound):
+		e = &apiError{http.StatusNotFound, "not_found", err.Error()}
+	case errors.Is(err, context.DeadlineExceeded):
+		e = &apiError{http.StatusGatewayTimeout, "timeout", err.Error()}
+	case errors.Is(err, errRPCFailed):
+		e = &apiError{http.StatusBadGateway, "rpc_failed", err.Error()}
+	default:
+		e = &apiError{http.StatusUnprocessableEntity, "decode_failed", err.Error()}
+	}
+	writeJSON(w, r, e.Status, errorBody{e})
+}
+
+// apiHandler adapts a handler returning its response body, or an error, to an http.HandlerFunc, bounding each request
+// by serveTimeout. Calldata which panics while being decoded is written as an invalid_calldata error.
+func apiHandler(fn func(w http.ResponseWriter, r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), serveTimeout)
+		defer cancel()
+		defer func() {
+			if p := recover(); p != nil {
+				writeError(w, r, fmt.Errorf("%w; %v", errMalformedCallData, p))
+			}
+		}()
+
+		v, err := fn(w, r.WithContext(ctx))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, v)
+	}
+}
+
+// newServeMux returns the routes of the decoding service, where client is used to fetch transactions.
+func newServeMux(client *ethclient.Client) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, &apiError{http.StatusNotFound, "not_found", fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path)})
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("GET /schema", func(w http.ResponseWriter, r *http.Request) {
+		b, err := schema()
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/schema+json")
+		w.Write(append(b, '\n'))
+	})
+	mux.HandleFunc("POST /decode", apiHandler(func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		b, err := parseHexBody(w, r)
+		if err != nil {
+			return nil, err
+		}
+		_, v, err := decodeMessage(b)
+		return v, err
+	}))
+	mux.HandleFunc("POST /summarize", apiHandler(func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		b, err := parseHexBody(w, r)
+		if err != nil {
+			return nil, err
+		}
+		t, v, err := decodeMessage(b)
+		if err != nil {
+			return nil, err
+		}
+		return summarize(t.String(), v), nil
+	}))
+	mux.HandleFunc("POST /revert", apiHandler(func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		b, err := parseHexBody(w, r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeRevertJSON(b), nil
+	}))
+	mux.HandleFunc("GET /tx/{hash}", apiHandler(func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+		hash := r.PathValue("hash")
+		if b, err := hex.DecodeString(strings.TrimPrefix(hash, "0x")); err != nil || len(b) != common.HashLength {
+			return nil, badRequest("invalid_hash", fmt.Errorf("invalid transaction hash: %s", hash))
+		}
+		trace, _ := strconv.ParseBool(r.URL.Query().Get("trace"))
+		return decodeTx(r.Context(), client, common.HexToHash(hash), trace)
+	}))
+	return mux
+}
+
+// serveCmd serves the decoding HTTP API on addr until interrupted, fetching transactions from rpcURL.
+func serveCmd(ctx context.Context, addr, rpcURL string) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	checkErr("", err)
+
+	if chainID == 0 {
+		// The node may be down while starting, in which case only the calldata endpoints are usable
+		idCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		id, err := client.ChainID(idCtx)
+		cancel()
+		if err != nil {
+			log.Printf("unable to fetch the chain ID, using mainnet; %s", err)
+			useChain(registry.Mainnet)
+		} else {
+			useChain(id.Uint64())
+		}
+	} else {
+		useChain(chainID)
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           newServeMux(client),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       serveTimeout,
+		// Leave time to write the error of a request which timed out
+		WriteTimeout: serveTimeout + 5*time.Second,
+		IdleTimeout:  time.Minute,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveTimeout)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("serving chain %d on http://%s", chainID, addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		checkErr("", err)
+	}
+}
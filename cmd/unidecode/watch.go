@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/filter"
+)
+
+var (
+	watchRouters  string
+	watchCommands string
+	watchTokens   string
+	watchPools    string
+	watchMinValue string
+)
+
+// watchFilter selects which pending router transactions are written; each set criteria must match, where a list
+// matches when any of its values do.
+type watchFilter struct {
+	commands map[string]bool
+	tokens   map[common.Address]bool
+	pools    map[common.Hash]bool
+	minValue *big.Int
+	where    *filter.Filter
+}
+
+// watchResult is a decoded pending router transaction, written as a JSON line.
+type watchResult struct {
+	Seen time.Time `json:"seen"`
+	routerTx
+}
+
+// splitList splits a comma separated list, dropping empty values.
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseEther parses a decimal amount of ETH as wei.
+func parseEther(s string) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("invalid ETH value: %s", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(big.NewInt(1e18)))
+	return new(big.Int).Quo(r.Num(), r.Denom()), nil
+}
+
+func parseWatchFilter(cmds, tokens, pools, minValue string) (watchFilter, error) {
+	var f watchFilter
+	if values := splitList(cmds); len(values) > 0 {
+		f.commands = make(map[string]bool)
+		for _, v := range values {
+			f.commands[strings.ToUpper(v)] = true
+		}
+	}
+	if values := splitList(tokens); len(values) > 0 {
+		f.tokens = make(map[common.Address]bool)
+		for _, v := range values {
+			if !common.IsHexAddress(v) {
+				return f, fmt.Errorf("invalid token address: %s", v)
+			}
+			f.tokens[common.HexToAddress(v)] = true
+		}
+	}
+	if values := splitList(pools); len(values) > 0 {
+		f.pools = make(map[common.Hash]bool)
+		for _, v := range values {
+			if len(strings.TrimPrefix(v, "0x")) != 2*common.HashLength {
+				return f, fmt.Errorf("invalid pool ID: %s", v)
+			}
+			f.pools[common.HexToHash(v)] = true
+		}
+	}
+	if minValue != "" {
+		var err error
+		if f.minValue, err = parseEther(minValue); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// match reports whether r satisfies the filter. Command, token and pool criteria only match Universal Router calls.
+func (f watchFilter) match(r routerTx) bool {
+	if f.minValue != nil && r.Value.Cmp(f.minValue) < 0 {
+		return false
+	}
+	if !matchWhere(f.where, r.Call) {
+		return false
+	}
+	if f.commands == nil && f.tokens == nil && f.pools == nil {
+		return true
+	}
+	execute, ok := r.Call.(unidecode.Execute)
+	if !ok {
+		return false
+	}
+
+	if f.commands != nil {
+		found := false
+		for _, cmd := range commands.Flatten(execute.Commands) {
+			if f.commands[cmd.Type().String()] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.tokens != nil {
+		found := false
+		for _, token := range execute.Tokens() {
+			if f.tokens[token] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.pools != nil {
+		found := false
+		for _, id := range execute.PoolIDs() {
+			if f.pools[id] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTx decodes tx, reporting whether it matches f. A transaction which panics while being decoded or matched is
+// reported with Error set, rather than ending the subscription.
+func (f watchFilter) matchTx(tx rpcTransaction) (r routerTx, ok bool) {
+	defer func() {
+		if p := recover(); p != nil {
+			r.Call, r.Error, ok = nil, fmt.Errorf("%w; %v", errMalformedCallData, p).Error(), true
+		}
+	}()
+	r = decodeRouterTx(tx)
+	return r, f.match(r)
+}
+
+// watchCmd subscribes to full pending transactions over the websocket rpcURL, writing each sent to one of routers,
+// and matching filter, as a JSON line.
+func watchCmd(ctx context.Context, rpcURL string, routerList string, filter watchFilter) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	checkErr("", err)
+
+	if chainID == 0 {
+		id, err := client.ChainID(ctx)
+		checkErr("", err)
+		useChain(id.Uint64())
+	} else {
+		useChain(chainID)
+	}
+
+	routers, err := parseRouters(routerList)
+	checkErr("", err)
+
+	pending := make(chan rpcTransaction, 256)
+	sub, err := client.Client().EthSubscribe(ctx, pending, "newPendingTransactions", true)
+	checkErr("unable to subscribe to pending transactions; %s", err)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			// Nodes without full transaction subscriptions send hashes, which fail to decode
+			checkErr("pending transaction subscription failed; %s", err)
+			return
+		case tx := <-pending:
+			if tx.To == nil || !routers[*tx.To] {
+				continue
+			}
+			if r, ok := filter.matchTx(tx); ok {
+				printJSON(false, watchResult{time.Now().UTC(), r})
+			}
+		}
+	}
+}
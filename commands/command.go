@@ -1,14 +1,28 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
+)
+
+// Recipient constants used by the router in place of an address.
+//
+// see: v4-periphery/src/libraries/ActionConstants.sol
+var (
+	MSG_SENDER   = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	ADDRESS_THIS = common.HexToAddress("0x0000000000000000000000000000000000000002")
 )
 
 type Command interface {
 	Type() Type
 	Actions() []actions.Action
+	// Fields returns the arguments of the command, other than its actions.
+	Fields() []field.Field
 }
 
 var errNotImplemented = errors.New("Not implemented")
@@ -53,11 +67,80 @@ func Decode(t Type, calldata []byte, offset int) (Command, error) {
 		return DecodeV4InitializePool(calldata, offset)
 	case V4_POSITION_MANAGER_CALL:
 		return DecodeV4PositionManagerCall(calldata, offset)
+	case EXECUTE_SUB_PLAN:
+		return DecodeExecuteSubPlan(calldata, offset)
+	// NOT IMPLEMENTED
+	case FLAG_ALLOW_REVERT,
+		COMMAND_TYPE_MASK:
+		return nil, errNotImplemented
+	}
+	return nil, errInvalidType
+}
+
+// Unmarshal unmarshals a command encoded as JSON by its MarshalJSON, of the type named by its "type".
+func Unmarshal(data []byte) (Command, error) {
+	var v struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	t, err := ParseName(v.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case V3_SWAP_EXACT_IN:
+		return unmarshal[V3SwapExactIn](data)
+	case V3_SWAP_EXACT_OUT:
+		return unmarshal[V3SwapExactOut](data)
+	case PERMIT2_TRANSFER_FROM:
+		return unmarshal[Permit2TransferFrom](data)
+	case PERMIT2_PERMIT_BATCH:
+		return unmarshal[Permit2PermitBatch](data)
+	case SWEEP:
+		return unmarshal[Sweep](data)
+	case TRANSFER:
+		return unmarshal[Transfer](data)
+	case PAY_PORTION:
+		return unmarshal[PayPortion](data)
+	case V2_SWAP_EXACT_IN:
+		return unmarshal[V2SwapExactIn](data)
+	case V2_SWAP_EXACT_OUT:
+		return unmarshal[V2SwapExactOut](data)
+	case PERMIT2_PERMIT:
+		return unmarshal[Permit2Permit](data)
+	case WRAP_ETH:
+		return unmarshal[WrapWETH](data)
+	case UNWRAP_WETH:
+		return unmarshal[UnwrapWETH](data)
+	case PERMIT2_TRANSFER_FROM_BATCH:
+		return unmarshal[Permit2TransferFromBatch](data)
+	case BALANCE_CHECK_ERC20:
+		return unmarshal[BalanceCheckERC20](data)
+	case V3_POSITION_MANAGER_PERMIT:
+		return unmarshal[V3PositionManagerPermit](data)
+	case V3_POSITION_MANAGER_CALL:
+		return unmarshal[V3PositionManagerCall](data)
+	case V4_SWAP:
+		return unmarshal[V4Swap](data)
+	case V4_INITIALIZE_POOL:
+		return unmarshal[V4InitializePool](data)
+	case V4_POSITION_MANAGER_CALL:
+		return unmarshal[V4PositionManagerCall](data)
+	case EXECUTE_SUB_PLAN:
+		return unmarshal[ExecuteSubPlan](data)
 	// NOT IMPLEMENTED
-	case EXECUTE_SUB_PLAN,
-		FLAG_ALLOW_REVERT,
+	case FLAG_ALLOW_REVERT,
 		COMMAND_TYPE_MASK:
 		return nil, errNotImplemented
 	}
 	return nil, errInvalidType
 }
+
+func unmarshal[T Command](data []byte) (Command, error) {
+	var c T
+	err := json.Unmarshal(data, &c)
+	return c, err
+}
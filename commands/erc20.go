@@ -1,12 +1,13 @@
 package commands
 
 import (
-	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type BalanceCheckERC20 struct {
@@ -17,12 +18,25 @@ type BalanceCheckERC20 struct {
 
 func (b BalanceCheckERC20) MarshalJSON() ([]byte, error) {
 	type Alias BalanceCheckERC20
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(b), BALANCE_CHECK_ERC20.String()})
 }
 
+func (b *BalanceCheckERC20) UnmarshalJSON(data []byte) error {
+	type Alias BalanceCheckERC20
+	return jsonx.Unmarshal(data, (*Alias)(b))
+}
+
+func (b BalanceCheckERC20) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Owner", Value: b.Owner},
+		{Name: "Token", Value: b.Token},
+		{Name: "MinBalance", Value: b.MinBalance},
+	}
+}
+
 func (BalanceCheckERC20) Type() Type {
 	return BALANCE_CHECK_ERC20
 }
@@ -1,14 +1,15 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type PayPortion struct {
@@ -19,12 +20,25 @@ type PayPortion struct {
 
 func (p PayPortion) MarshalJSON() ([]byte, error) {
 	type Alias PayPortion
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), PAY_PORTION.String()})
 }
 
+func (p *PayPortion) UnmarshalJSON(data []byte) error {
+	type Alias PayPortion
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p PayPortion) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Recipient", Value: p.Recipient},
+		{Name: "BIPs", Value: p.BIPs},
+	}
+}
+
 func (PayPortion) Type() Type {
 	return PAY_PORTION
 }
@@ -37,7 +51,7 @@ func DecodePayPortion(calldata []byte, offset int) (PayPortion, error) {
 	var p PayPortion
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return p, fmt.Errorf("invalid %s input count; %w", err)
+		return p, fmt.Errorf("invalid %s input count; %w", PAY_PORTION, err)
 	} else if count != 0x60 {
 		return p, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", SWEEP, count)
 	}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"time"
@@ -9,7 +8,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type PermitDetails struct {
@@ -19,6 +20,71 @@ type PermitDetails struct {
 	Nonce      uint64         `json:"nonce"`
 }
 
+func (p PermitDetails) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Amount", Value: p.Amount},
+		{Name: "Expiration", Value: p.Expiration},
+		{Name: "Nonce", Value: p.Nonce},
+	}
+}
+
+// DecodePermitDetails decodes the static PermitDetails tuple at offset.
+func DecodePermitDetails(calldata []byte, offset int) (PermitDetails, error) {
+	var d PermitDetails
+	if offset < 0 || offset > len(calldata)-0x80 {
+		return d, fmt.Errorf("PermitDetails exceeds calldata bounds")
+	}
+
+	d = PermitDetails{
+		Token:  common.BytesToAddress(calldata[offset : offset+0x20]),
+		Amount: new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+	}
+
+	epoch, err := hex.Int64(calldata[offset+0x40 : offset+0x60])
+	if err != nil {
+		return d, fmt.Errorf("invalid PermitDetails expiration; %w", err)
+	}
+	d.Expiration = time.Unix(epoch, 0)
+
+	nonce, err := hex.Int64(calldata[offset+0x60 : offset+0x80])
+	if err != nil {
+		return d, fmt.Errorf("invalid PermitDetails nonce; %w", err)
+	}
+	d.Nonce = uint64(nonce)
+	return d, nil
+}
+
+// DecodePermitBatch decodes the `PermitBatch` tuple located at start.
+//
+// see: permit2/src/interfaces/IAllowanceTransfer.sol
+func DecodePermitBatch(calldata []byte, start int) ([]PermitDetails, common.Address, *big.Int, error) {
+	//    struct PermitBatch {
+	//        PermitDetails[] details;
+	//        address spender;
+	//        uint256 sigDeadline;
+	//    }
+	if start < 0 || start > len(calldata)-0x60 {
+		return nil, common.Address{}, nil, fmt.Errorf("PermitBatch exceeds calldata bounds")
+	}
+
+	loc, count, err := hex.Array(calldata, start, 0x00, 0x80)
+	if err != nil {
+		return nil, common.Address{}, nil, fmt.Errorf("invalid PermitBatch details; %w", err)
+	}
+	var details []PermitDetails
+	for i := 0; i < count; i++ {
+		d, err := DecodePermitDetails(calldata, loc+i*0x80)
+		if err != nil {
+			return nil, common.Address{}, nil, fmt.Errorf("invalid PermitBatch details at index %d; %w", i, err)
+		}
+		details = append(details, d)
+	}
+	spender := common.BytesToAddress(calldata[start+0x20 : start+0x40])
+	sigDeadline := new(big.Int).SetBytes(calldata[start+0x40 : start+0x60])
+	return details, spender, sigDeadline, nil
+}
+
 type Permit2Permit struct {
 	Details     PermitDetails  `json:"details"`
 	Spender     common.Address `json:"spender"`
@@ -28,12 +94,26 @@ type Permit2Permit struct {
 
 func (p Permit2Permit) MarshalJSON() ([]byte, error) {
 	type Alias Permit2Permit
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), PERMIT2_PERMIT.String()})
 }
 
+func (p *Permit2Permit) UnmarshalJSON(data []byte) error {
+	type Alias Permit2Permit
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p Permit2Permit) Fields() []field.Field {
+	return []field.Field{
+		field.Struct("Details", p.Details),
+		{Name: "Spender", Value: p.Spender},
+		{Name: "SigDeadline", Value: p.SigDeadline},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
 func (Permit2Permit) Type() Type {
 	return PERMIT2_PERMIT
 }
@@ -67,38 +147,23 @@ func DecodePermit2Permit(calldata []byte, offset int) (Permit2Permit, error) {
 
 	dataLen, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return p, fmt.Errorf("invalid %s input count; %w", err)
-	} else if dataLen > len(calldata)-offset {
+		return p, fmt.Errorf("invalid %s input count; %w", PERMIT2_PERMIT, err)
+	} else if dataLen > len(calldata)-offset || dataLen < 0xe0 {
 		return p, fmt.Errorf("invalid %s data length", PERMIT2_PERMIT)
 	}
 	offset += 0x20
 
-	p.Details = PermitDetails{
-		Token:  common.BytesToAddress(calldata[offset : offset+0x20]),
-		Amount: new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
-	}
-
-	epoch, err := hex.Int64(calldata[offset+0x40 : offset+0x60])
+	p.Details, err = DecodePermitDetails(calldata, offset)
 	if err != nil {
-		return p, fmt.Errorf("invalid PermitDetails expiration; %w", err)
+		return p, err
 	}
-	p.Details.Expiration = time.Unix(epoch, 0)
-
-	nonce, err := hex.Int64(calldata[offset+0x60 : offset+0x80])
-	if err != nil {
-		return p, fmt.Errorf("invalid PermitDetails nonce; %w", err)
-	}
-	p.Details.Nonce = uint64(nonce)
-
 	p.Spender = common.BytesToAddress(calldata[offset+0x80 : offset+0xa0])
 	p.SigDeadline = new(big.Int).SetBytes(calldata[offset+0xa0 : offset+0xc0])
 
-	sigLen, err := hex.Int(calldata[offset+0xc0 : offset+0xe0])
+	p.Sig, err = hex.Bytes(calldata, offset, 0xc0)
 	if err != nil {
-		return p, fmt.Errorf("invalid Permit2 signature length; %w", err)
+		return p, fmt.Errorf("invalid Permit2 signature; %w", err)
 	}
-	p.Sig = calldata[offset+0x100 : offset+0x100+sigLen]
-
 	return p, nil
 }
 
@@ -111,12 +176,26 @@ type Permit2PermitBatch struct {
 
 func (p Permit2PermitBatch) MarshalJSON() ([]byte, error) {
 	type Alias Permit2PermitBatch
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), PERMIT2_PERMIT_BATCH.String()})
 }
 
+func (p *Permit2PermitBatch) UnmarshalJSON(data []byte) error {
+	type Alias Permit2PermitBatch
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p Permit2PermitBatch) Fields() []field.Field {
+	return []field.Field{
+		field.List("Details", p.Details),
+		{Name: "Spender", Value: p.Spender},
+		{Name: "SigDeadline", Value: p.SigDeadline},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
 func (Permit2PermitBatch) Type() Type {
 	return PERMIT2_PERMIT_BATCH
 }
@@ -132,8 +211,29 @@ func DecodePermit2PermitBatch(calldata []byte, offset int) (Permit2PermitBatch,
 	//        owner   │ token   amount  expire nonce     spender deadline sig
 	// permit(address,((address,uint160,uint48,uint48)[],address,uint256),bytes)
 	var p Permit2PermitBatch
-	// TODO Implement; should be the same as permit2, with an array
-	return p, errNotImplemented
+
+	dataLen, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid %s input count; %w", PERMIT2_PERMIT_BATCH, err)
+	} else if dataLen > len(calldata)-offset || dataLen < 0x40 {
+		return p, fmt.Errorf("invalid %s data length", PERMIT2_PERMIT_BATCH)
+	}
+	offset += 0x20
+
+	batchLoc, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid PermitBatch location; %w", err)
+	}
+	p.Details, p.Spender, p.SigDeadline, err = DecodePermitBatch(calldata, offset+batchLoc)
+	if err != nil {
+		return p, err
+	}
+
+	p.Sig, err = hex.Bytes(calldata, offset, 0x20)
+	if err != nil {
+		return p, fmt.Errorf("invalid Permit2 signature; %w", err)
+	}
+	return p, nil
 }
 
 type Permit2TransferFrom struct {
@@ -144,12 +244,25 @@ type Permit2TransferFrom struct {
 
 func (p Permit2TransferFrom) MarshalJSON() ([]byte, error) {
 	type Alias Permit2TransferFrom
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), PERMIT2_TRANSFER_FROM.String()})
 }
 
+func (p *Permit2TransferFrom) UnmarshalJSON(data []byte) error {
+	type Alias Permit2TransferFrom
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p Permit2TransferFrom) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Recipient", Value: p.Recipient},
+		{Name: "Amount", Value: p.Amount},
+	}
+}
+
 func (Permit2TransferFrom) Type() Type {
 	return PERMIT2_TRANSFER_FROM
 }
@@ -159,6 +272,15 @@ func (Permit2TransferFrom) Actions() []actions.Action {
 }
 
 func DecodePermit2TransferFrom(calldata []byte, offset int) (Permit2TransferFrom, error) {
+	var p Permit2TransferFrom
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid %s input count; %w", PERMIT2_TRANSFER_FROM, err)
+	} else if count != 0x60 || offset+0x80 > len(calldata) {
+		return p, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", PERMIT2_TRANSFER_FROM, count)
+	}
+	offset += 0x20
+
 	return Permit2TransferFrom{
 		Token:     common.BytesToAddress(calldata[offset : offset+0x20]),
 		Recipient: common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
@@ -166,17 +288,69 @@ func DecodePermit2TransferFrom(calldata []byte, offset int) (Permit2TransferFrom
 	}, nil
 }
 
-// TODO Implement
-type Permit2TransferFromBatch struct{}
+// AllowanceTransferDetails Solidity representation
+//
+// see: permit2/src/interfaces/IAllowanceTransfer.sol
+type AllowanceTransferDetails struct {
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Amount *big.Int       `json:"amount"`
+	Token  common.Address `json:"token"`
+}
+
+func (a AllowanceTransferDetails) Fields() []field.Field {
+	return []field.Field{
+		{Name: "From", Value: a.From},
+		{Name: "To", Value: a.To},
+		{Name: "Amount", Value: a.Amount},
+		{Name: "Token", Value: a.Token},
+	}
+}
+
+// DecodeAllowanceTransferDetails decodes the `AllowanceTransferDetails[]` whose location, relative to start, is
+// stored at start+head.
+func DecodeAllowanceTransferDetails(calldata []byte, start, head int) ([]AllowanceTransferDetails, error) {
+	loc, count, err := hex.Array(calldata, start, head, 0x80)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AllowanceTransferDetails; %w", err)
+	}
+
+	var details []AllowanceTransferDetails
+	for i := 0; i < count; i++ {
+		o := loc + i*0x80
+		details = append(details, AllowanceTransferDetails{
+			From:   common.BytesToAddress(calldata[o : o+0x20]),
+			To:     common.BytesToAddress(calldata[o+0x20 : o+0x40]),
+			Amount: new(big.Int).SetBytes(calldata[o+0x40 : o+0x60]),
+			Token:  common.BytesToAddress(calldata[o+0x60 : o+0x80]),
+		})
+	}
+	return details, nil
+}
+
+type Permit2TransferFromBatch struct {
+	Details []AllowanceTransferDetails `json:"details"`
+}
 
 func (p Permit2TransferFromBatch) MarshalJSON() ([]byte, error) {
 	type Alias Permit2TransferFromBatch
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), PERMIT2_TRANSFER_FROM_BATCH.String()})
 }
 
+func (p *Permit2TransferFromBatch) UnmarshalJSON(data []byte) error {
+	type Alias Permit2TransferFromBatch
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p Permit2TransferFromBatch) Fields() []field.Field {
+	return []field.Field{
+		field.List("Details", p.Details),
+	}
+}
+
 func (Permit2TransferFromBatch) Type() Type {
 	return PERMIT2_TRANSFER_FROM_BATCH
 }
@@ -186,6 +360,21 @@ func (Permit2TransferFromBatch) Actions() []actions.Action {
 }
 
 func DecodePermit2TransferFromBatch(calldata []byte, offset int) (Permit2TransferFromBatch, error) {
-	// permit(address,((address,uint160,uint48,uint48)[],address,uint256),bytes)
-	return Permit2TransferFromBatch{}, errNotImplemented
+	// Dispatcher.sol:112
+	//
+	// (IAllowanceTransfer.AllowanceTransferDetails[] memory batchDetails) = abi.decode(inputs, (...))
+	//
+	//              from    to      amount  token
+	// transferFrom((address,address,uint160,address)[])
+	var p Permit2TransferFromBatch
+
+	dataLen, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid %s input count; %w", PERMIT2_TRANSFER_FROM_BATCH, err)
+	} else if dataLen > len(calldata)-offset {
+		return p, fmt.Errorf("invalid %s data length", PERMIT2_TRANSFER_FROM_BATCH)
+	}
+
+	p.Details, err = DecodeAllowanceTransferDetails(calldata, offset+0x20, 0x00)
+	return p, err
 }
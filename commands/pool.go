@@ -1,12 +1,13 @@
 package commands
 
 import (
-	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/internal/jsonx"
 	"github.com/juztin/unidecode/pool"
 )
 
@@ -17,12 +18,24 @@ type V4InitializePool struct {
 
 func (p V4InitializePool) MarshalJSON() ([]byte, error) {
 	type Alias V4InitializePool
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), V4_INITIALIZE_POOL.String()})
 }
 
+func (p *V4InitializePool) UnmarshalJSON(data []byte) error {
+	type Alias V4InitializePool
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p V4InitializePool) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Key", Value: p.Key.ID(), Fields: p.Key.Fields()},
+		{Name: "SqrtPrice", Value: p.SqrtPrice},
+	}
+}
+
 func (V4InitializePool) Type() Type {
 	return V4_INITIALIZE_POOL
 }
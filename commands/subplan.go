@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+// ExecuteSubPlan is a plan of commands executed within a single command, so those allowed to revert revert only the
+// sub-plan.
+type ExecuteSubPlan struct {
+	Commands []Command `json:"commands"`
+}
+
+func (p ExecuteSubPlan) MarshalJSON() ([]byte, error) {
+	type Alias ExecuteSubPlan
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), EXECUTE_SUB_PLAN.String()})
+}
+
+func (p *ExecuteSubPlan) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Commands []json.RawMessage `json:"commands"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.Commands = make([]Command, len(v.Commands))
+	for i, b := range v.Commands {
+		c, err := Unmarshal(b)
+		if err != nil {
+			return fmt.Errorf("invalid %s command at index %d; %w", EXECUTE_SUB_PLAN, i, err)
+		}
+		p.Commands[i] = c
+	}
+	return nil
+}
+
+// Fields returns the commands of the sub-plan, each an item holding its type and fields, followed by its actions.
+func (p ExecuteSubPlan) Fields() []field.Field {
+	cmds := field.Field{Name: "Commands", Items: make([]field.Field, len(p.Commands))}
+	for i, cmd := range p.Commands {
+		fields := cmd.Fields()
+		if as := cmd.Actions(); len(as) > 0 {
+			actions := field.Field{Name: "Actions", Items: make([]field.Field, len(as))}
+			for j, a := range as {
+				actions.Items[j] = field.Field{Value: a.Type().String(), Fields: a.Fields()}
+			}
+			fields = append(fields, actions)
+		}
+		cmds.Items[i] = field.Field{Value: cmd.Type().String(), Fields: fields}
+	}
+	return []field.Field{cmds}
+}
+
+func (ExecuteSubPlan) Type() Type {
+	return EXECUTE_SUB_PLAN
+}
+
+func (ExecuteSubPlan) Actions() []actions.Action {
+	return nil
+}
+
+func DecodeExecuteSubPlan(calldata []byte, offset int) (ExecuteSubPlan, error) {
+	var p ExecuteSubPlan
+	if offset+0x20 > len(calldata) {
+		return p, fmt.Errorf("invalid %s data length", EXECUTE_SUB_PLAN)
+	}
+	dataLen, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid %s input count; %w", EXECUTE_SUB_PLAN, err)
+	} else if dataLen > len(calldata)-offset-0x20 {
+		return p, fmt.Errorf("invalid %s data length", EXECUTE_SUB_PLAN)
+	}
+
+	p.Commands, err = DecodePlan(calldata, offset+0x20)
+	return p, err
+}
+
+// PlanLayout is where each part of a plan, the ABI encoded `(bytes commands, bytes[] inputs)`, is within calldata.
+type PlanLayout struct {
+	// Offset is the location of the plan, whose first two words are the offsets of its commands and inputs.
+	Offset int
+	// CommandsAt is the location of the commands length, followed by the type of each command.
+	CommandsAt int
+	// InputsAt is the location of the inputs length, followed by the offset of each input.
+	InputsAt int
+	Types    []Type
+	// Inputs is the location of each input, its length followed by its bytes.
+	Inputs []int
+}
+
+// DecodePlanLayout decodes the layout of the plan whose first word is at offset. When an input is out of bounds, the
+// layout holds the inputs before it along with the error.
+func DecodePlanLayout(calldata []byte, offset int) (PlanLayout, error) {
+	l := PlanLayout{Offset: offset}
+	if offset < 0 || offset > len(calldata)-0x40 {
+		return l, fmt.Errorf("plan exceeds calldata bounds")
+	}
+
+	// Get command start location
+	commandStart, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return l, fmt.Errorf("invalid command start memory location; %w", err)
+	} else if commandStart > len(calldata)-offset-0x20 {
+		return l, fmt.Errorf("command start 0x%x exceeds calldata bounds", commandStart)
+	}
+	l.CommandsAt = offset + commandStart
+
+	commandLen, err := hex.Int(calldata[l.CommandsAt : l.CommandsAt+0x20])
+	if err != nil {
+		return l, fmt.Errorf("invalid command length value; %w", err)
+	} else if commandLen > len(calldata)-l.CommandsAt-0x20 {
+		return l, fmt.Errorf("command length %d exceeds calldata bounds", commandLen)
+	}
+	l.Types, err = DecodeType(calldata[l.CommandsAt+0x20 : l.CommandsAt+0x20+commandLen])
+	if err != nil {
+		return l, fmt.Errorf("invalid commands; %w", err)
+	}
+
+	// Get inputs start location
+	inputsStart, err := hex.Int(calldata[offset+0x20 : offset+0x40])
+	if err != nil {
+		return l, fmt.Errorf("invalid inputs start memory location; %w", err)
+	} else if inputsStart > len(calldata)-offset-0x20 {
+		return l, fmt.Errorf("inputs start 0x%x exceeds calldata bounds", inputsStart)
+	}
+	l.InputsAt = offset + inputsStart
+
+	inputsLen, err := hex.Int(calldata[l.InputsAt : l.InputsAt+0x20])
+	if err != nil {
+		return l, fmt.Errorf("invalid inputs length; %w", err)
+	} else if inputsLen != commandLen {
+		return l, fmt.Errorf("inputs length mismatch; got %d but expected %d", inputsLen, commandLen)
+	}
+	offset = l.InputsAt + 0x20
+	if inputsLen > (len(calldata)-offset)/0x20 {
+		return l, fmt.Errorf("inputs length %d exceeds calldata bounds", inputsLen)
+	}
+
+	for i := range l.Types {
+		inputOffset := offset + i*0x20
+		loc, err := hex.Int(calldata[inputOffset : inputOffset+0x20])
+		if err != nil {
+			return l, fmt.Errorf("invalid input location for input %d; %w", i, err)
+		}
+		// Each input is a length followed by its bytes, which must be within the calldata
+		if loc > len(calldata)-offset-0x20 {
+			return l, fmt.Errorf("input %d exceeds calldata bounds", i)
+		} else if n, err := hex.Int(calldata[offset+loc : offset+loc+0x20]); err != nil || n > len(calldata)-offset-loc-0x20 {
+			return l, fmt.Errorf("input %d length exceeds calldata bounds", i)
+		}
+		l.Inputs = append(l.Inputs, offset+loc)
+	}
+	return l, nil
+}
+
+// DecodePlan decodes the commands of a plan whose first word is at offset. The arguments of an `execute` call, and the
+// input of an EXECUTE_SUB_PLAN command, are plans.
+func DecodePlan(calldata []byte, offset int) ([]Command, error) {
+	l, layoutErr := DecodePlanLayout(calldata, offset)
+	var cmds []Command
+	for i, loc := range l.Inputs {
+		c, err := Decode(l.Types[i], calldata, loc)
+		if err != nil {
+			return cmds, fmt.Errorf("invalid command data at index %d for %s; %w", i, l.Types[i], err)
+		}
+		cmds = append(cmds, c)
+	}
+	return cmds, layoutErr
+}
+
+// Flatten returns cmds with the commands of each sub-plan following it, in the order they're executed.
+func Flatten(cmds []Command) []Command {
+	var flat []Command
+	for _, cmd := range cmds {
+		flat = append(flat, cmd)
+		if p, ok := cmd.(ExecuteSubPlan); ok {
+			flat = append(flat, Flatten(p.Commands)...)
+		}
+	}
+	return flat
+}
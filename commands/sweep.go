@@ -1,14 +1,15 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type Sweep struct {
@@ -19,12 +20,25 @@ type Sweep struct {
 
 func (s Sweep) MarshalJSON() ([]byte, error) {
 	type Alias Sweep
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), SWEEP.String()})
 }
 
+func (s *Sweep) UnmarshalJSON(data []byte) error {
+	type Alias Sweep
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s Sweep) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: s.Token},
+		{Name: "Recipient", Value: s.Recipient},
+		{Name: "AmountMin", Value: s.AmountMin},
+	}
+}
+
 func (Sweep) Type() Type {
 	return SWEEP
 }
@@ -37,7 +51,7 @@ func DecodeSweep(calldata []byte, offset int) (Sweep, error) {
 	var s Sweep
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return s, fmt.Errorf("invalid %s input count; %w", err)
+		return s, fmt.Errorf("invalid %s input count; %w", SWEEP, err)
 	} else if count != 0x60 {
 		return s, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", SWEEP, count)
 	}
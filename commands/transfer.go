@@ -1,30 +1,44 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type Transfer struct {
 	Token     common.Address `json:"token"`
-	Recipient common.Address `json:"recipeient"`
+	Recipient common.Address `json:"recipient"`
 	Value     *big.Int       `json:"value"`
 }
 
 func (t Transfer) MarshalJSON() ([]byte, error) {
 	type Alias Transfer
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(t), TRANSFER.String()})
 }
 
+func (t *Transfer) UnmarshalJSON(data []byte) error {
+	type Alias Transfer
+	return jsonx.Unmarshal(data, (*Alias)(t))
+}
+
+func (t Transfer) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: t.Token},
+		{Name: "Recipient", Value: t.Recipient},
+		{Name: "Value", Value: t.Value},
+	}
+}
+
 func (Transfer) Type() Type {
 	return TRANSFER
 }
@@ -37,7 +51,7 @@ func DecodeTransfer(calldata []byte, offset int) (Transfer, error) {
 	var t Transfer
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return t, fmt.Errorf("invalid %s input count; %w", err)
+		return t, fmt.Errorf("invalid %s input count; %w", TRANSFER, err)
 	} else if count != 0x60 {
 		return t, fmt.Errorf("invalid %s input count; expected 0x60 but got 0x%x", TRANSFER, count)
 	}
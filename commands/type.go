@@ -137,6 +137,59 @@ func Parse(b byte) (t Type, err error) {
 	return
 }
 
+// ParseName parses the command type named name, as returned by String.
+func ParseName(name string) (t Type, err error) {
+	switch name {
+	case "FLAG_ALLOW_REVERT":
+		t = FLAG_ALLOW_REVERT
+	case "COMMAND_TYPE_MASK":
+		t = COMMAND_TYPE_MASK
+	case "V3_SWAP_EXACT_IN":
+		t = V3_SWAP_EXACT_IN
+	case "V3_SWAP_EXACT_OUT":
+		t = V3_SWAP_EXACT_OUT
+	case "PERMIT2_TRANSFER_FROM":
+		t = PERMIT2_TRANSFER_FROM
+	case "PERMIT2_PERMIT_BATCH":
+		t = PERMIT2_PERMIT_BATCH
+	case "SWEEP":
+		t = SWEEP
+	case "TRANSFER":
+		t = TRANSFER
+	case "PAY_PORTION":
+		t = PAY_PORTION
+	case "V2_SWAP_EXACT_IN":
+		t = V2_SWAP_EXACT_IN
+	case "V2_SWAP_EXACT_OUT":
+		t = V2_SWAP_EXACT_OUT
+	case "PERMIT2_PERMIT":
+		t = PERMIT2_PERMIT
+	case "WRAP_ETH":
+		t = WRAP_ETH
+	case "UNWRAP_WETH":
+		t = UNWRAP_WETH
+	case "PERMIT2_TRANSFER_FROM_BATCH":
+		t = PERMIT2_TRANSFER_FROM_BATCH
+	case "BALANCE_CHECK_ERC20":
+		t = BALANCE_CHECK_ERC20
+	case "V4_SWAP":
+		t = V4_SWAP
+	case "V3_POSITION_MANAGER_PERMIT":
+		t = V3_POSITION_MANAGER_PERMIT
+	case "V3_POSITION_MANAGER_CALL":
+		t = V3_POSITION_MANAGER_CALL
+	case "V4_INITIALIZE_POOL":
+		t = V4_INITIALIZE_POOL
+	case "V4_POSITION_MANAGER_CALL":
+		t = V4_POSITION_MANAGER_CALL
+	case "EXECUTE_SUB_PLAN":
+		t = EXECUTE_SUB_PLAN
+	default:
+		err = fmt.Errorf("invalid command type %q", name)
+	}
+	return
+}
+
 func DecodeType(b []byte) ([]Type, error) {
 	var s []Type
 	for i := 0; i < len(b); i++ {
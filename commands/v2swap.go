@@ -1,12 +1,16 @@
 package commands
 
 import (
-	"encoding/json"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/path"
 )
 
 type V2SwapExactIn struct {
@@ -15,22 +19,36 @@ type V2SwapExactIn struct {
 	//       1: msg.sender
 	//       2: this
 	//   other: value
-	Recipient    common.Address `json:"recipient"`
-	AmountIn     *big.Int       `json:"amountIn"`
-	AmountOutMin *big.Int       `json:"amountOutMin"`
-	PayerIsUser  bool           `json:"payerIsUser"` // payer = PayerIsUser ? msg.sender : this
-	// TODO Implement v2 path decoding
-	// Path ...
+	Recipient    common.Address   `json:"recipient"`
+	AmountIn     *big.Int         `json:"amountIn"`
+	AmountOutMin *big.Int         `json:"amountOutMin"`
+	Path         []common.Address `json:"path"`
+	PayerIsUser  bool             `json:"payerIsUser"` // payer = PayerIsUser ? msg.sender : this
 }
 
 func (s V2SwapExactIn) MarshalJSON() ([]byte, error) {
 	type Alias V2SwapExactIn
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), V2_SWAP_EXACT_IN.String()})
 }
 
+func (s *V2SwapExactIn) UnmarshalJSON(data []byte) error {
+	type Alias V2SwapExactIn
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s V2SwapExactIn) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Recipient", Value: s.Recipient},
+		{Name: "AmountIn", Value: s.AmountIn},
+		{Name: "AmountOutMin", Value: s.AmountOutMin},
+		field.Values("Path", s.Path),
+		{Name: "PayerIsUser", Value: s.PayerIsUser},
+	}
+}
+
 func (V2SwapExactIn) Type() Type {
 	return V2_SWAP_EXACT_IN
 }
@@ -51,9 +69,9 @@ func DecodeV2SwapExactIn(calldata []byte, offset int) (V2SwapExactIn, error) {
 		AmountOutMin: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
 	}
 
-	// TODO Implement v2 path decoding
-
-	return s, nil
+	var err error
+	s.Path, s.PayerIsUser, err = decodeV2PathAndPayer(calldata, offset)
+	return s, err
 }
 
 type V2SwapExactOut struct {
@@ -62,22 +80,36 @@ type V2SwapExactOut struct {
 	//       1: msg.sender
 	//       2: this
 	//   other: value
-	Recipient   common.Address `json:"recipient"`
-	AmountOut   *big.Int       `json:"amountOut"`
-	AmountInMin *big.Int       `json:"amountInMin"`
-	PayerIsUser bool           `json:"payerIsUser"` // payer = PayerIsUser ? msg.sender : this
-	// TODO Implement v2 path decoding
-	// Path ...
+	Recipient   common.Address   `json:"recipient"`
+	AmountOut   *big.Int         `json:"amountOut"`
+	AmountInMin *big.Int         `json:"amountInMin"`
+	Path        []common.Address `json:"path"`
+	PayerIsUser bool             `json:"payerIsUser"` // payer = PayerIsUser ? msg.sender : this
 }
 
 func (s V2SwapExactOut) MarshalJSON() ([]byte, error) {
 	type Alias V2SwapExactOut
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), V2_SWAP_EXACT_OUT.String()})
 }
 
+func (s *V2SwapExactOut) UnmarshalJSON(data []byte) error {
+	type Alias V2SwapExactOut
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s V2SwapExactOut) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Recipient", Value: s.Recipient},
+		{Name: "AmountOut", Value: s.AmountOut},
+		{Name: "AmountInMin", Value: s.AmountInMin},
+		field.Values("Path", s.Path),
+		{Name: "PayerIsUser", Value: s.PayerIsUser},
+	}
+}
+
 func (V2SwapExactOut) Type() Type {
 	return V2_SWAP_EXACT_OUT
 }
@@ -97,5 +129,31 @@ func DecodeV2SwapExactOut(calldata []byte, offset int) (V2SwapExactOut, error) {
 		AmountOut:   new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
 		AmountInMin: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
 	}
-	return s, nil
+
+	var err error
+	s.Path, s.PayerIsUser, err = decodeV2PathAndPayer(calldata, offset)
+	return s, err
+}
+
+// decodeV2PathAndPayer decodes the `address[] path` and `bool payerIsUser` parameters of a V2 swap input starting at
+// offset.
+//
+// see: universal-router/contracts/base/Dispatcher.sol
+func decodeV2PathAndPayer(calldata []byte, offset int) ([]common.Address, bool, error) {
+	if offset+0xa0 > len(calldata) {
+		return nil, false, fmt.Errorf("v2 swap input exceeds calldata bounds")
+	}
+	pathStart, err := hex.Int(calldata[offset+0x60 : offset+0x80])
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid path start loc; %w", err)
+	}
+	payerIsUser, err := hex.Bool(calldata[offset+0x80 : offset+0xa0])
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid payerIsUser value; %w", err)
+	}
+	p, err := path.DecodeV2(calldata, offset+pathStart)
+	if err != nil {
+		return nil, payerIsUser, fmt.Errorf("failed to decode path; %w", err)
+	}
+	return p, payerIsUser, nil
 }
@@ -10,11 +10,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 // permit(address,uint256,uint256,uint8,bytes32,bytes32) | keccak256
-var permitSig = []byte{0x7a, 0xc2, 0xff, 0x7b}
+var V3PermitSig = []byte{0x7a, 0xc2, 0xff, 0x7b}
 
 type Sig struct {
 	V uint8 `json:"v"`
@@ -24,6 +26,15 @@ type Sig struct {
 	S [32]byte `json:"s"`
 }
 
+// Bytes returns the signature as its 65 byte encoding, R || S || V.
+func (s Sig) Bytes() []byte {
+	b := make([]byte, 65)
+	copy(b[:32], s.R[:])
+	copy(b[32:64], s.S[:])
+	b[64] = s.V
+	return b
+}
+
 type V3PositionManagerPermit struct {
 	Spender  common.Address `json:"spender"`
 	Amount   *big.Int       `json:"amount"`
@@ -33,12 +44,26 @@ type V3PositionManagerPermit struct {
 
 func (p V3PositionManagerPermit) MarshalJSON() ([]byte, error) {
 	type Alias V3PositionManagerPermit
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), V3_POSITION_MANAGER_PERMIT.String()})
 }
 
+func (p *V3PositionManagerPermit) UnmarshalJSON(data []byte) error {
+	type Alias V3PositionManagerPermit
+	return jsonx.Unmarshal(data, (*Alias)(p))
+}
+
+func (p V3PositionManagerPermit) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Spender", Value: p.Spender},
+		{Name: "Amount", Value: p.Amount},
+		{Name: "Deadline", Value: p.Deadline},
+		{Name: "Sig", Value: p.Sig.Bytes()},
+	}
+}
+
 func (V3PositionManagerPermit) Type() Type {
 	return V3_POSITION_MANAGER_PERMIT
 }
@@ -53,13 +78,22 @@ func DecodeV3PositionManagerPermit(calldata []byte, offset int) (V3PositionManag
 	if err != nil {
 		return p, err
 	} else if length > len(calldata) {
-		return p, fmt.Errorf("invalid %s data length", V4_POSITION_MANAGER_CALL)
+		return p, fmt.Errorf("invalid %s data length", V3_POSITION_MANAGER_PERMIT)
 	}
 	offset += 0x20
 
 	methodSig := calldata[offset : offset+0x04]
-	if bytes.Compare(methodSig, permitSig) != 0 {
-		return p, fmt.Errorf("invalid function selector; expected %x but got %x", permitSig, methodSig)
+	if bytes.Compare(methodSig, V3PermitSig) != 0 {
+		return p, fmt.Errorf("invalid function selector; expected %x but got %x", V3PermitSig, methodSig)
+	}
+	return DecodeV3Permit(calldata, offset)
+}
+
+// DecodeV3Permit decodes a NonfungiblePositionManager `permit` call whose method signature starts at offset.
+func DecodeV3Permit(calldata []byte, offset int) (V3PositionManagerPermit, error) {
+	var p V3PositionManagerPermit
+	if offset+0xc4 > len(calldata) {
+		return p, fmt.Errorf("invalid %s data length", V3_POSITION_MANAGER_PERMIT)
 	}
 	offset += 0x04
 
@@ -67,11 +101,7 @@ func DecodeV3PositionManagerPermit(calldata []byte, offset int) (V3PositionManag
 		Spender: common.BytesToAddress(calldata[offset : offset+0x20]),
 		Amount:  new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
 	}
-	deadline, err := hex.Int64(calldata[offset+0x40 : offset+0x60])
-	if err != nil {
-		return p, fmt.Errorf("invalid deadline; %w", err)
-	}
-	p.Deadline = time.Unix(deadline, 0)
+	p.Deadline = hex.Time(calldata[offset+0x40 : offset+0x60])
 
 	// Grabbing just the last 8 bytes (+24bytes/0x18)
 	v, err := hex.Int(calldata[offset+0x60+0x18 : offset+0x80])
@@ -97,12 +127,27 @@ type V3PositionManagerCall struct {
 
 func (p V3PositionManagerCall) MarshalJSON() ([]byte, error) {
 	type Alias V3PositionManagerCall
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(p), V3_POSITION_MANAGER_CALL.String()})
 }
 
+func (p *V3PositionManagerCall) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Action json.RawMessage `json:"action"`
+	}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	p.Action, err = actions.Unmarshal(v.Action)
+	if err != nil {
+		return fmt.Errorf("invalid %s action; %w", V3_POSITION_MANAGER_CALL, err)
+	}
+	return nil
+}
+
 func (V3PositionManagerCall) Type() Type {
 	return V3_POSITION_MANAGER_CALL
 }
@@ -111,24 +156,58 @@ func (p V3PositionManagerCall) Actions() []actions.Action {
 	return []actions.Action{p.Action}
 }
 
+func (V3PositionManagerCall) Fields() []field.Field {
+	return nil
+}
+
 func DecodeV3PositionManagerCall(calldata []byte, offset int) (V3PositionManagerCall, error) {
 	var p V3PositionManagerCall
+	if offset+0x20 > len(calldata) {
+		return p, fmt.Errorf("invalid %s data length", V3_POSITION_MANAGER_CALL)
+	}
 	length, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
 		return p, err
-	} else if length > len(calldata) {
+	} else if length > len(calldata)-offset-0x20 {
 		return p, fmt.Errorf("invalid %s data length", V3_POSITION_MANAGER_CALL)
 	}
+	return DecodeV3Call(calldata, offset+0x20)
+}
 
-	methodSig, err := hex.Int64(calldata[offset+0x20 : offset+0x24])
+// v3CallLen is the length of the parameters of each NonfungiblePositionManager call, following its method signature.
+var v3CallLen = map[actions.Type]int{
+	actions.V3_MINT:               0x160,
+	actions.V3_INCREASE_LIQUIDITY: 0xc0,
+	actions.V3_DECREASE_LIQUIDITY: 0xa0,
+	actions.V3_COLLECT:            0x80,
+	actions.V3_BURN:               0x20,
+
+	actions.V3_CREATE_AND_INITIALIZE_POOL:       0x80,
+	actions.V3_SELF_PERMIT:                      0xc0,
+	actions.V3_SELF_PERMIT_IF_NECESSARY:         0xc0,
+	actions.V3_SELF_PERMIT_ALLOWED:              0xc0,
+	actions.V3_SELF_PERMIT_ALLOWED_IF_NECESSARY: 0xc0,
+}
+
+// DecodeV3Call decodes a NonfungiblePositionManager `mint`, `increaseLiquidity`, `decreaseLiquidity`, `collect`,
+// `burn`, `createAndInitializePoolIfNecessary` or `selfPermit` call whose method signature starts at offset.
+func DecodeV3Call(calldata []byte, offset int) (V3PositionManagerCall, error) {
+	var p V3PositionManagerCall
+	if offset+0x04 > len(calldata) {
+		return p, fmt.Errorf("invalid %s data length", V3_POSITION_MANAGER_CALL)
+	}
+
+	methodSig, err := hex.Int64(calldata[offset : offset+0x04])
 	if err != nil {
 		return p, err
 	}
-	offset += 0x24
+	offset += 0x04
 
 	t, err := actions.ParseV3(methodSig)
 	if err != nil {
 		return p, err
+	} else if offset+v3CallLen[t] > len(calldata) {
+		return p, fmt.Errorf("invalid %s data length", t)
 	}
 	a, err := actions.Decode(t, calldata, offset)
 	if err != nil {
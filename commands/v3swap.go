@@ -1,12 +1,16 @@
 package commands
 
 import (
-	"encoding/json"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/path"
 )
 
 type V3SwapExactIn struct {
@@ -18,9 +22,10 @@ type V3SwapExactIn struct {
 	Recipient    common.Address `json:"recipient"`
 	AmountIn     *big.Int       `json:"amountIn"`
 	AmountOutMin *big.Int       `json:"amountOutMin"`
+	Path         []path.V3Hop   `json:"path"`
 	PayerIsUser  bool           `json:"payerIsUser"` // payer = PayerIsUser ? msg.sender : this
-	// TODO Implement v3 path decoding
-	// Path ...
+	// Only set by SwapRouter02 `exactInputSingle` calls
+	SqrtPriceLimitX96 *big.Int `json:"sqrtPriceLimitX96,omitempty"`
 }
 
 func (V3SwapExactIn) Type() Type {
@@ -29,12 +34,31 @@ func (V3SwapExactIn) Type() Type {
 
 func (s V3SwapExactIn) MarshalJSON() ([]byte, error) {
 	type Alias V3SwapExactIn
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), V3_SWAP_EXACT_IN.String()})
 }
 
+func (s *V3SwapExactIn) UnmarshalJSON(data []byte) error {
+	type Alias V3SwapExactIn
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s V3SwapExactIn) Fields() []field.Field {
+	fields := []field.Field{
+		{Name: "Recipient", Value: s.Recipient},
+		{Name: "AmountIn", Value: s.AmountIn},
+		{Name: "AmountOutMin", Value: s.AmountOutMin},
+		field.List("Path", s.Path),
+		{Name: "PayerIsUser", Value: s.PayerIsUser},
+	}
+	if s.SqrtPriceLimitX96 != nil {
+		fields = append(fields, field.Field{Name: "SqrtPriceLimitX96", Value: s.SqrtPriceLimitX96})
+	}
+	return fields
+}
+
 func (V3SwapExactIn) Actions() []actions.Action {
 	return nil
 }
@@ -51,15 +75,9 @@ func DecodeV3SwapExactIn(calldata []byte, offset int) (V3SwapExactIn, error) {
 		AmountOutMin: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
 	}
 
-	// TODO Implement v3 path decoding
-	//pathCount, err := hex.Int(calldata[offset+0x60: offset+0x80])
-	//if err != nil {
-	//}
-	//pathItems, err := hex.Int(calldata[offset+0x80: offset+0xa0])
-	//if err != nil {
-	//}
-
-	return s, nil
+	var err error
+	s.Path, s.PayerIsUser, err = decodeV3PathAndPayer(calldata, offset)
+	return s, err
 }
 
 type V3SwapExactOut struct {
@@ -71,9 +89,10 @@ type V3SwapExactOut struct {
 	Recipient   common.Address `json:"recipient"`
 	AmountOut   *big.Int       `json:"amountOut"`
 	AmountInMin *big.Int       `json:"amountInMin"`
+	Path        []path.V3Hop   `json:"path"`        // encoded in reverse; the first hop's TokenIn is the token bought
 	PayerIsUser bool           `json:"payerIsUser"` // payer = PayerIsUser ? msg.sender : this
-	// TODO Implement v3 path decoding
-	// Path ...
+	// Only set by SwapRouter02 `exactOutputSingle` calls
+	SqrtPriceLimitX96 *big.Int `json:"sqrtPriceLimitX96,omitempty"`
 }
 
 func (V3SwapExactOut) Type() Type {
@@ -82,12 +101,31 @@ func (V3SwapExactOut) Type() Type {
 
 func (s V3SwapExactOut) MarshalJSON() ([]byte, error) {
 	type Alias V3SwapExactOut
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(s), V3_SWAP_EXACT_OUT.String()})
 }
 
+func (s *V3SwapExactOut) UnmarshalJSON(data []byte) error {
+	type Alias V3SwapExactOut
+	return jsonx.Unmarshal(data, (*Alias)(s))
+}
+
+func (s V3SwapExactOut) Fields() []field.Field {
+	fields := []field.Field{
+		{Name: "Recipient", Value: s.Recipient},
+		{Name: "AmountOut", Value: s.AmountOut},
+		{Name: "AmountInMin", Value: s.AmountInMin},
+		field.List("Path", s.Path),
+		{Name: "PayerIsUser", Value: s.PayerIsUser},
+	}
+	if s.SqrtPriceLimitX96 != nil {
+		fields = append(fields, field.Field{Name: "SqrtPriceLimitX96", Value: s.SqrtPriceLimitX96})
+	}
+	return fields
+}
+
 func (V3SwapExactOut) Actions() []actions.Action {
 	return nil
 }
@@ -103,5 +141,31 @@ func DecodeV3SwapExactOut(calldata []byte, offset int) (V3SwapExactOut, error) {
 		AmountOut:   new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
 		AmountInMin: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
 	}
-	return s, nil
+
+	var err error
+	s.Path, s.PayerIsUser, err = decodeV3PathAndPayer(calldata, offset)
+	return s, err
+}
+
+// decodeV3PathAndPayer decodes the `bytes path` and `bool payerIsUser` parameters of a V3 swap input starting at
+// offset.
+//
+// see: universal-router/contracts/base/Dispatcher.sol
+func decodeV3PathAndPayer(calldata []byte, offset int) ([]path.V3Hop, bool, error) {
+	if offset+0xa0 > len(calldata) {
+		return nil, false, fmt.Errorf("v3 swap input exceeds calldata bounds")
+	}
+	payerIsUser, err := hex.Bool(calldata[offset+0x80 : offset+0xa0])
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid payerIsUser value; %w", err)
+	}
+	b, err := hex.Bytes(calldata, offset, 0x60)
+	if err != nil {
+		return nil, payerIsUser, fmt.Errorf("invalid path; %w", err)
+	}
+	p, err := path.DecodeV3(b)
+	if err != nil {
+		return nil, payerIsUser, fmt.Errorf("failed to decode path; %w", err)
+	}
+	return p, payerIsUser, nil
 }
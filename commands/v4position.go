@@ -7,14 +7,20 @@ import (
 	"time"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
-// modifyLiquidities(bytes,uint256) | keccak256
-var modifyLiquiditiesSig = []byte{0xdd, 0x46, 0x50, 0x8f}
+var (
+	// modifyLiquidities(bytes,uint256) | keccak256
+	ModifyLiquiditiesSig = []byte{0xdd, 0x46, 0x50, 0x8f}
+	// modifyLiquiditiesWithoutUnlock(bytes,bytes[]) | keccak256
+	ModifyLiquiditiesWithoutUnlockSig = []byte{0x4a, 0xfe, 0x39, 0x3c}
+)
 
 type V4PositionManagerCall struct {
-	Deadline time.Time
+	Deadline time.Time `json:"deadline"`
 	actions  []actions.Action
 }
 
@@ -23,11 +29,36 @@ func (V4PositionManagerCall) Type() Type {
 }
 
 func (s V4PositionManagerCall) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&struct {
-		Type     string           `json:"type"`
-		Deadline time.Time        `json:"deadline"`
-		Actions  []actions.Action `json:"actions"`
-	}{V4_POSITION_MANAGER_CALL.String(), s.Deadline, s.actions})
+	type Alias V4PositionManagerCall
+	return jsonx.Marshal(&struct {
+		Alias
+		Type    string           `json:"type"`
+		Actions []actions.Action `json:"actions"`
+	}{(Alias)(s), V4_POSITION_MANAGER_CALL.String(), s.actions})
+}
+
+func (p *V4PositionManagerCall) UnmarshalJSON(data []byte) error {
+	type Alias V4PositionManagerCall
+	var v struct {
+		Alias
+		Actions json.RawMessage `json:"actions"`
+	}
+	err := jsonx.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	*p = V4PositionManagerCall(v.Alias)
+	p.actions, err = actions.UnmarshalMany(v.Actions)
+	if err != nil {
+		return fmt.Errorf("invalid %s actions; %w", V4_POSITION_MANAGER_CALL, err)
+	}
+	return nil
+}
+
+func (s V4PositionManagerCall) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Deadline", Value: s.Deadline},
+	}
 }
 
 func (p V4PositionManagerCall) Actions() []actions.Action {
@@ -46,80 +77,58 @@ func DecodeV4PositionManagerCall(calldata []byte, offset int) (V4PositionManager
 
 	methodSig := calldata[offset : offset+0x04]
 	// if (selector != V4_POSITION_MANAGER.modifyLiquidities.selector) {  // universal-router/contracts/modules/V3ToV4Migrator.sol:79
-	if bytes.Compare(methodSig, modifyLiquiditiesSig) != 0 {
-		return p, fmt.Errorf("invalid function selector; expected %x but got %x", modifyLiquiditiesSig, methodSig)
+	if bytes.Compare(methodSig, ModifyLiquiditiesSig) != 0 {
+		return p, fmt.Errorf("invalid function selector; expected %x but got %x", ModifyLiquiditiesSig, methodSig)
+	}
+	return DecodeModifyLiquidities(calldata, offset)
+}
+
+// DecodeModifyLiquidities decodes a PositionManager `modifyLiquidities` call whose method signature starts at offset.
+func DecodeModifyLiquidities(calldata []byte, offset int) (V4PositionManagerCall, error) {
+	var p V4PositionManagerCall
+	if offset < 0 || offset > len(calldata)-0x44 {
+		return p, fmt.Errorf("invalid %s data length", V4_POSITION_MANAGER_CALL)
 	}
 	offset += 0x04
 
 	// ModifyLiquidities(bytes calldata unlockData, uint256 deadline)
-	unlockDataStart, err := hex.Int(calldata[offset : offset+0x20])
 	//   [arg0] unlockData
-	deadline, err := hex.Int64(calldata[offset+0x20 : offset+0x40])
+	unlockDataStart, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		return p, fmt.Errorf("invalid deadline; %w", err)
+		return p, fmt.Errorf("invalid unlockData start memory location; %w", err)
 	}
 	//   [arg1] deadline
-	p.Deadline = time.Unix(deadline, 0)
+	p.Deadline = hex.Time(calldata[offset+0x20 : offset+0x40])
 	// Advance offset to start of unlockData bytes
+	if unlockDataStart > len(calldata)-offset-0x20 {
+		return p, fmt.Errorf("invalid %s data length", V4_POSITION_MANAGER_CALL)
+	}
 	offset += unlockDataStart
 
 	dataLen, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
 		return p, fmt.Errorf("invalid %s input count; %w", V4_POSITION_MANAGER_CALL, err)
-	} else if dataLen > len(calldata)-offset-(0x40-0x04) { // 0x40-0x4 (first 2 blocks and method sig bytes)
+	} else if dataLen > len(calldata)-offset-0x20 {
 		return p, fmt.Errorf("invalid %s data length", V4_POSITION_MANAGER_CALL)
 	}
 	offset += 0x20
 
-	// Get action start location
-	actionStart, err := hex.Int(calldata[offset : offset+0x20])
-	if err != nil {
-		return p, fmt.Errorf("invalid action start memory location; %w", err)
-	}
-
-	actionLen, err := hex.Int(calldata[offset+actionStart : offset+actionStart+0x20])
-	if err != nil {
-		return p, fmt.Errorf("invalid action length value; %w", err)
-	}
-
-	offset = offset + actionStart + 0x20
-	actionTypes, err := actions.DecodeType(calldata[offset : offset+actionLen])
-	if err != nil {
-		//return p, fmt.Errorf("invalid actions %x; %w", calldata[offset:offset+actionLen], err)
-		return p, err
-	}
-
-	if actionLen > 0x20 {
-		// TODO
-	} else {
-		offset += 0x20
-	}
-
-	paramsLen, err := hex.Int(calldata[offset : offset+0x20])
-	if err != nil {
-		return p, fmt.Errorf("invalid params length; %w", err)
-	} else if paramsLen != actionLen {
-		return p, fmt.Errorf("params length mismatch; got %d but expected %d", paramsLen, actionLen)
-	}
-	offset += 0x20
-
-	var paramsLoc []int
-	for i := 0; i < paramsLen; i++ {
-		paramOffset := offset + i*0x20
-		loc, err := hex.Int(calldata[paramOffset : paramOffset+0x20])
-		if err != nil {
-			return p, fmt.Errorf("invalid param location for param %d; %w", i, err)
-		}
-		paramsLoc = append(paramsLoc, loc)
-	}
+	p.actions, err = actions.DecodeMany(calldata, offset)
+	return p, err
+}
 
-	for i, t := range actionTypes {
-		a, err := actions.Decode(t, calldata, offset+paramsLoc[i])
-		if err != nil {
-			return p, fmt.Errorf("invalid action data at index %d for %s; %w", i, t, err)
-		}
-		p.actions = append(p.actions, a)
+// DecodeModifyLiquiditiesWithoutUnlock decodes a PositionManager `modifyLiquiditiesWithoutUnlock` call whose method
+// signature starts at offset.
+//
+// The call has no deadline, so the returned Deadline is the zero time.
+func DecodeModifyLiquiditiesWithoutUnlock(calldata []byte, offset int) (V4PositionManagerCall, error) {
+	var p V4PositionManagerCall
+	if offset+0x44 > len(calldata) {
+		return p, fmt.Errorf("invalid %s data length", V4_POSITION_MANAGER_CALL)
 	}
 
-	return p, nil
+	// modifyLiquiditiesWithoutUnlock(bytes calldata actions, bytes[] calldata params)
+	var err error
+	p.actions, err = actions.DecodeMany(calldata, offset+0x04)
+	return p, err
 }
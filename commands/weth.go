@@ -1,14 +1,15 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 type WrapWETH struct {
@@ -22,10 +23,22 @@ func (WrapWETH) Type() Type {
 
 func (w WrapWETH) MarshalJSON() ([]byte, error) {
 	type Alias WrapWETH
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
-	}{(Alias)(w), V4_POSITION_MANAGER_CALL.String()})
+	}{(Alias)(w), WRAP_ETH.String()})
+}
+
+func (w *WrapWETH) UnmarshalJSON(data []byte) error {
+	type Alias WrapWETH
+	return jsonx.Unmarshal(data, (*Alias)(w))
+}
+
+func (w WrapWETH) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Recipient", Value: w.Recipient},
+		{Name: "AmountMin", Value: w.AmountMin},
+	}
 }
 
 func (WrapWETH) Actions() []actions.Action {
@@ -50,7 +63,7 @@ func DecodeWrapWETH(calldata []byte, offset int) (WrapWETH, error) {
 }
 
 type UnwrapWETH struct {
-	Recipient common.Address `json"recipient"`
+	Recipient common.Address `json:"recipient"`
 	AmountMin *big.Int       `json:"amountMin"`
 }
 
@@ -60,12 +73,24 @@ func (UnwrapWETH) Type() Type {
 
 func (w UnwrapWETH) MarshalJSON() ([]byte, error) {
 	type Alias UnwrapWETH
-	return json.Marshal(&struct {
+	return jsonx.Marshal(&struct {
 		Alias
 		Type string `json:"type"`
 	}{(Alias)(w), UNWRAP_WETH.String()})
 }
 
+func (w *UnwrapWETH) UnmarshalJSON(data []byte) error {
+	type Alias UnwrapWETH
+	return jsonx.Unmarshal(data, (*Alias)(w))
+}
+
+func (w UnwrapWETH) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Recipient", Value: w.Recipient},
+		{Name: "AmountMin", Value: w.AmountMin},
+	}
+}
+
 func (UnwrapWETH) Actions() []actions.Action {
 	return nil
 }
@@ -0,0 +1,536 @@
+package unidecode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// decodeRevertArgs decodes the arguments of the errors that carry them, returning nil when code isn't one of them.
+func decodeRevertArgs(code string, a *revertArgs) error {
+	switch code {
+	// Solidity
+	case "0x08c379a0":
+		return RevertReason{string(a.bytes(0))}
+	case "0x4e487b71":
+		return Panic{a.uint(0)}
+
+	// Universal Router
+	case "0x2c4029e9":
+		message := a.bytes(1)
+		return ExecutionFailed{a.uint(0), message, revertError(message)}
+	case "0xd76a1e9e":
+		return InvalidCommandType{a.uint(0)}
+	case "0xbb25d4c5":
+		return NotAuthorizedForToken{a.uint(0)}
+	case "0xf801e525":
+		return InvalidAction{a.selector(0)}
+	case "0x6d1eca28":
+		return InvalidAddressLength{a.uint(0)}
+	case "0x8b063d73":
+		return V4TooLittleReceived{a.uint(0), a.uint(1)}
+	case "0x12bacdd3":
+		return V4TooMuchRequested{a.uint(0), a.uint(1)}
+	case "0x5cda29d7":
+		return UnsupportedAction{a.uint(0)}
+	case "0xecbd9804":
+		return QuoteSwap{a.uint(0)}
+	case "0x6190b2b0":
+		data := a.bytes(0)
+		return UnexpectedRevertBytes{data, revertError(data)}
+
+	// V4 PositionManager
+	case "0xbfb22adf":
+		return DeadlinePassed{a.uint(0)}
+	case "0x0ca968d8":
+		return NotApproved{a.address(0)}
+	case "0xed15e6cf":
+		return InvalidTokenId{a.uint(0)}
+	case "0x25fbd8be":
+		return AlreadySubscribed{a.uint(0), a.address(1)}
+	case "0x6f05727d":
+		return NotAuthorizedNotifer{a.address(0)}
+	case "0x31e30ad0":
+		return MaximumAmountExceeded{a.uint(0), a.uint(1)}
+	case "0x12816f22":
+		return MinimumAmountInsufficient{a.uint(0), a.uint(1)}
+	case "0x81ea5e9e":
+		reason := a.bytes(1)
+		return SubscriptionReverted{a.address(0), reason, revertError(reason)}
+	case "0xe94f10e2":
+		reason := a.bytes(1)
+		return ModifyLiquidityNotificationReverted{a.address(0), reason, revertError(reason)}
+	case "0xace94481":
+		reason := a.bytes(1)
+		return BurnNotificationReverted{a.address(0), reason, revertError(reason)}
+
+	// V4 PoolManager
+	case "0xb70024f8":
+		return TickSpacingTooLarge{a.int(0)}
+	case "0xe9e90588":
+		return TickSpacingTooSmall{a.int(0)}
+	case "0x6e6c9830":
+		return CurrenciesOutOfOrderOrEqual{a.address(0), a.address(1)}
+	case "0xc4433ed5":
+		return TicksMisordered{a.int(0), a.int(1)}
+	case "0xd5e2f7ab":
+		return TickLowerOutOfBounds{a.int(0)}
+	case "0x1ad777f8":
+		return TickUpperOutOfBounds{a.int(0)}
+	case "0xb8e3c385":
+		return TickLiquidityOverflow{a.int(0)}
+	case "0x7c9c6e8f":
+		return PriceLimitAlreadyExceeded{a.uint(0), a.uint(1)}
+	case "0x9e4d7cc7":
+		return PriceLimitOutOfBounds{a.uint(0)}
+	case "0x8b86327a":
+		return InvalidTick{a.int(0)}
+	case "0x61487524":
+		return InvalidSqrtPrice{a.uint(0)}
+	case "0xd4d8f3e6":
+		return TickMisaligned{a.int(0), a.int(1)}
+	case "0x14002113":
+		return LPFeeTooLarge{a.uint(0)}
+	case "0xa7abe2f7":
+		return ProtocolFeeTooLarge{a.uint(0)}
+	case "0xe65af6a0":
+		return HookAddressNotValid{a.address(0)}
+	case "0x90bfb865":
+		reason, details := a.bytes(2), a.bytes(3)
+		return WrappedError{a.address(0), a.selector(1), reason, details, revertError(reason), revertError(details)}
+
+	// Permit2
+	case "0xd81b2f2e":
+		return AllowanceExpired{a.uint(0)}
+	case "0xf96fb071":
+		return InsufficientAllowance{a.uint(0)}
+	case "0x3728b83d":
+		return InvalidAmount{a.uint(0)}
+	case "0xcd21db4f":
+		return SignatureExpired{a.uint(0)}
+
+	// UniswapX
+	case "0x75c1bb14":
+		return NotExclusiveFiller{a.address(0)}
+	case "0x82e75656":
+		return FeeTooLarge{a.address(0), a.uint(1), a.address(2)}
+	case "0xeddf07f5":
+		return InvalidFeeToken{a.address(0)}
+	case "0xfff08303":
+		return DuplicateFeeOutput{a.address(0)}
+	}
+	return nil
+}
+
+// ExecutionFailed is reverted by the Universal Router when a command that is not allowed to revert fails.
+type ExecutionFailed struct {
+	CommandIndex *big.Int
+	Message      []byte
+	Reason       error
+}
+
+func (e ExecutionFailed) Error() string {
+	return fmt.Sprintf("ExecutionFailed(commandIndex: %s, message: %s)", e.CommandIndex, revertReason(e.Reason, e.Message))
+}
+
+func (e ExecutionFailed) Unwrap() error {
+	return e.Reason
+}
+
+type InvalidCommandType struct {
+	CommandType *big.Int
+}
+
+func (e InvalidCommandType) Error() string {
+	return fmt.Sprintf("InvalidCommandType(commandType: 0x%02x)", e.CommandType)
+}
+
+type NotAuthorizedForToken struct {
+	TokenID *big.Int
+}
+
+func (e NotAuthorizedForToken) Error() string {
+	return fmt.Sprintf("NotAuthorizedForToken(tokenId: %s)", e.TokenID)
+}
+
+type InvalidAction struct {
+	Action string
+}
+
+func (e InvalidAction) Error() string {
+	return fmt.Sprintf("InvalidAction(action: %s)", e.Action)
+}
+
+type InvalidAddressLength struct {
+	Len *big.Int
+}
+
+func (e InvalidAddressLength) Error() string {
+	return fmt.Sprintf("InvalidAddressLength(len: %s)", e.Len)
+}
+
+type V4TooLittleReceived struct {
+	MinAmountOutReceived *big.Int
+	AmountReceived       *big.Int
+}
+
+func (e V4TooLittleReceived) Error() string {
+	return fmt.Sprintf("V4TooLittleReceived(minAmountOutReceived: %s, amountReceived: %s)", e.MinAmountOutReceived, e.AmountReceived)
+}
+
+type V4TooMuchRequested struct {
+	MaxAmountInRequested *big.Int
+	AmountRequested      *big.Int
+}
+
+func (e V4TooMuchRequested) Error() string {
+	return fmt.Sprintf("V4TooMuchRequested(maxAmountInRequested: %s, amountRequested: %s)", e.MaxAmountInRequested, e.AmountRequested)
+}
+
+type UnsupportedAction struct {
+	Action *big.Int
+}
+
+func (e UnsupportedAction) Error() string {
+	return fmt.Sprintf("UnsupportedAction(action: 0x%02x)", e.Action)
+}
+
+// QuoteSwap is reverted by the V4 Quoter to return the quoted amount.
+type QuoteSwap struct {
+	Amount *big.Int
+}
+
+func (e QuoteSwap) Error() string {
+	return fmt.Sprintf("QuoteSwap(amount: %s)", e.Amount)
+}
+
+type UnexpectedRevertBytes struct {
+	RevertData []byte
+	Reason     error
+}
+
+func (e UnexpectedRevertBytes) Error() string {
+	return fmt.Sprintf("UnexpectedRevertBytes(revertData: %s)", revertReason(e.Reason, e.RevertData))
+}
+
+func (e UnexpectedRevertBytes) Unwrap() error {
+	return e.Reason
+}
+
+type DeadlinePassed struct {
+	Deadline *big.Int
+}
+
+func (e DeadlinePassed) Error() string {
+	return fmt.Sprintf("DeadlinePassed(deadline: %s)", e.Deadline)
+}
+
+type NotApproved struct {
+	Caller common.Address
+}
+
+func (e NotApproved) Error() string {
+	return fmt.Sprintf("NotApproved(caller: %s)", e.Caller)
+}
+
+type InvalidTokenId struct {
+	TokenID *big.Int
+}
+
+func (e InvalidTokenId) Error() string {
+	return fmt.Sprintf("InvalidTokenId(tokenId: %s)", e.TokenID)
+}
+
+type AlreadySubscribed struct {
+	TokenID    *big.Int
+	Subscriber common.Address
+}
+
+func (e AlreadySubscribed) Error() string {
+	return fmt.Sprintf("AlreadySubscribed(tokenId: %s, subscriber: %s)", e.TokenID, e.Subscriber)
+}
+
+type NotAuthorizedNotifer struct {
+	Notifier common.Address
+}
+
+func (e NotAuthorizedNotifer) Error() string {
+	return fmt.Sprintf("NotAuthorizedNotifer(notifier: %s)", e.Notifier)
+}
+
+type MaximumAmountExceeded struct {
+	MaximumAmount   *big.Int
+	AmountRequested *big.Int
+}
+
+func (e MaximumAmountExceeded) Error() string {
+	return fmt.Sprintf("MaximumAmountExceeded(maximumAmount: %s, amountRequested: %s)", e.MaximumAmount, e.AmountRequested)
+}
+
+type MinimumAmountInsufficient struct {
+	MinimumAmount  *big.Int
+	AmountReceived *big.Int
+}
+
+func (e MinimumAmountInsufficient) Error() string {
+	return fmt.Sprintf("MinimumAmountInsufficient(minimumAmount: %s, amountReceived: %s)", e.MinimumAmount, e.AmountReceived)
+}
+
+type SubscriptionReverted struct {
+	Subscriber common.Address
+	ReasonData []byte
+	Reason     error
+}
+
+func (e SubscriptionReverted) Error() string {
+	return fmt.Sprintf("SubscriptionReverted(subscriber: %s, reason: %s)", e.Subscriber, revertReason(e.Reason, e.ReasonData))
+}
+
+func (e SubscriptionReverted) Unwrap() error {
+	return e.Reason
+}
+
+type ModifyLiquidityNotificationReverted struct {
+	Subscriber common.Address
+	ReasonData []byte
+	Reason     error
+}
+
+func (e ModifyLiquidityNotificationReverted) Error() string {
+	return fmt.Sprintf("ModifyLiquidityNotificationReverted(subscriber: %s, reason: %s)", e.Subscriber, revertReason(e.Reason, e.ReasonData))
+}
+
+func (e ModifyLiquidityNotificationReverted) Unwrap() error {
+	return e.Reason
+}
+
+type BurnNotificationReverted struct {
+	Subscriber common.Address
+	ReasonData []byte
+	Reason     error
+}
+
+func (e BurnNotificationReverted) Error() string {
+	return fmt.Sprintf("BurnNotificationReverted(subscriber: %s, reason: %s)", e.Subscriber, revertReason(e.Reason, e.ReasonData))
+}
+
+func (e BurnNotificationReverted) Unwrap() error {
+	return e.Reason
+}
+
+type TickSpacingTooLarge struct {
+	TickSpacing int64
+}
+
+func (e TickSpacingTooLarge) Error() string {
+	return fmt.Sprintf("TickSpacingTooLarge(tickSpacing: %d)", e.TickSpacing)
+}
+
+type TickSpacingTooSmall struct {
+	TickSpacing int64
+}
+
+func (e TickSpacingTooSmall) Error() string {
+	return fmt.Sprintf("TickSpacingTooSmall(tickSpacing: %d)", e.TickSpacing)
+}
+
+type CurrenciesOutOfOrderOrEqual struct {
+	Currency0 common.Address
+	Currency1 common.Address
+}
+
+func (e CurrenciesOutOfOrderOrEqual) Error() string {
+	return fmt.Sprintf("CurrenciesOutOfOrderOrEqual(currency0: %s, currency1: %s)", e.Currency0, e.Currency1)
+}
+
+type TicksMisordered struct {
+	TickLower int64
+	TickUpper int64
+}
+
+func (e TicksMisordered) Error() string {
+	return fmt.Sprintf("TicksMisordered(tickLower: %d, tickUpper: %d)", e.TickLower, e.TickUpper)
+}
+
+type TickLowerOutOfBounds struct {
+	TickLower int64
+}
+
+func (e TickLowerOutOfBounds) Error() string {
+	return fmt.Sprintf("TickLowerOutOfBounds(tickLower: %d)", e.TickLower)
+}
+
+type TickUpperOutOfBounds struct {
+	TickUpper int64
+}
+
+func (e TickUpperOutOfBounds) Error() string {
+	return fmt.Sprintf("TickUpperOutOfBounds(tickUpper: %d)", e.TickUpper)
+}
+
+type TickLiquidityOverflow struct {
+	Tick int64
+}
+
+func (e TickLiquidityOverflow) Error() string {
+	return fmt.Sprintf("TickLiquidityOverflow(tick: %d)", e.Tick)
+}
+
+type PriceLimitAlreadyExceeded struct {
+	SqrtPriceCurrentX96 *big.Int
+	SqrtPriceLimitX96   *big.Int
+}
+
+func (e PriceLimitAlreadyExceeded) Error() string {
+	return fmt.Sprintf("PriceLimitAlreadyExceeded(sqrtPriceCurrentX96: %s, sqrtPriceLimitX96: %s)", e.SqrtPriceCurrentX96, e.SqrtPriceLimitX96)
+}
+
+type PriceLimitOutOfBounds struct {
+	SqrtPriceLimitX96 *big.Int
+}
+
+func (e PriceLimitOutOfBounds) Error() string {
+	return fmt.Sprintf("PriceLimitOutOfBounds(sqrtPriceLimitX96: %s)", e.SqrtPriceLimitX96)
+}
+
+type InvalidTick struct {
+	Tick int64
+}
+
+func (e InvalidTick) Error() string {
+	return fmt.Sprintf("InvalidTick(tick: %d)", e.Tick)
+}
+
+type InvalidSqrtPrice struct {
+	SqrtPriceX96 *big.Int
+}
+
+func (e InvalidSqrtPrice) Error() string {
+	return fmt.Sprintf("InvalidSqrtPrice(sqrtPriceX96: %s)", e.SqrtPriceX96)
+}
+
+type TickMisaligned struct {
+	Tick        int64
+	TickSpacing int64
+}
+
+func (e TickMisaligned) Error() string {
+	return fmt.Sprintf("TickMisaligned(tick: %d, tickSpacing: %d)", e.Tick, e.TickSpacing)
+}
+
+type LPFeeTooLarge struct {
+	Fee *big.Int
+}
+
+func (e LPFeeTooLarge) Error() string {
+	return fmt.Sprintf("LPFeeTooLarge(fee: %s)", e.Fee)
+}
+
+type ProtocolFeeTooLarge struct {
+	Fee *big.Int
+}
+
+func (e ProtocolFeeTooLarge) Error() string {
+	return fmt.Sprintf("ProtocolFeeTooLarge(fee: %s)", e.Fee)
+}
+
+type HookAddressNotValid struct {
+	Hooks common.Address
+}
+
+func (e HookAddressNotValid) Error() string {
+	return fmt.Sprintf("HookAddressNotValid(hooks: %s)", e.Hooks)
+}
+
+// WrappedError is reverted by the PoolManager when an external call, to a hook or token, reverts. Both the revert
+// reason and the additional details are themselves revert data, and are decoded when known.
+type WrappedError struct {
+	Target      common.Address
+	Selector    string
+	ReasonData  []byte
+	DetailsData []byte
+	Reason      error
+	Details     error
+}
+
+func (e WrappedError) Error() string {
+	return fmt.Sprintf("WrappedError(target: %s, selector: %s, reason: %s, details: %s)",
+		e.Target, e.Selector, revertReason(e.Reason, e.ReasonData), revertReason(e.Details, e.DetailsData))
+}
+
+func (e WrappedError) Unwrap() []error {
+	var errs []error
+	for _, err := range []error{e.Reason, e.Details} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+type AllowanceExpired struct {
+	Deadline *big.Int
+}
+
+func (e AllowanceExpired) Error() string {
+	return fmt.Sprintf("AllowanceExpired(deadline: %s)", e.Deadline)
+}
+
+type InsufficientAllowance struct {
+	Amount *big.Int
+}
+
+func (e InsufficientAllowance) Error() string {
+	return fmt.Sprintf("InsufficientAllowance(amount: %s)", e.Amount)
+}
+
+type InvalidAmount struct {
+	MaxAmount *big.Int
+}
+
+func (e InvalidAmount) Error() string {
+	return fmt.Sprintf("InvalidAmount(maxAmount: %s)", e.MaxAmount)
+}
+
+type SignatureExpired struct {
+	SignatureDeadline *big.Int
+}
+
+func (e SignatureExpired) Error() string {
+	return fmt.Sprintf("SignatureExpired(signatureDeadline: %s)", e.SignatureDeadline)
+}
+
+type NotExclusiveFiller struct {
+	Filler common.Address
+}
+
+func (e NotExclusiveFiller) Error() string {
+	return fmt.Sprintf("NotExclusiveFiller(filler: %s)", e.Filler)
+}
+
+type FeeTooLarge struct {
+	Token     common.Address
+	Amount    *big.Int
+	Recipient common.Address
+}
+
+func (e FeeTooLarge) Error() string {
+	return fmt.Sprintf("FeeTooLarge(token: %s, amount: %s, recipient: %s)", e.Token, e.Amount, e.Recipient)
+}
+
+type InvalidFeeToken struct {
+	FeeToken common.Address
+}
+
+func (e InvalidFeeToken) Error() string {
+	return fmt.Sprintf("InvalidFeeToken(feeToken: %s)", e.FeeToken)
+}
+
+type DuplicateFeeOutput struct {
+	Token common.Address
+}
+
+func (e DuplicateFeeOutput) Error() string {
+	return fmt.Sprintf("DuplicateFeeOutput(token: %s)", e.Token)
+}
@@ -2,9 +2,9 @@ package unidecode
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"math/big"
 	"time"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/juztin/unidecode/actions"
 	"github.com/juztin/unidecode/commands"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 )
 
 var (
@@ -33,12 +34,33 @@ type messageType int
 const (
 	UnknownMessage messageType = iota
 	ExecuteMessage
+	PositionManagerMessage
+	V3PositionManagerMessage
+	SwapRouter02Message
+	V2RouterMessage
+	Permit2Message
+	UniswapXMessage
+	WalletMessage
 )
 
 func (t messageType) String() string {
 	switch t {
 	case ExecuteMessage:
 		return "EXECUTE"
+	case PositionManagerMessage:
+		return "POSITION_MANAGER"
+	case V3PositionManagerMessage:
+		return "V3_POSITION_MANAGER"
+	case SwapRouter02Message:
+		return "SWAP_ROUTER_02"
+	case V2RouterMessage:
+		return "V2_ROUTER_02"
+	case Permit2Message:
+		return "PERMIT2"
+	case UniswapXMessage:
+		return "UNISWAPX"
+	case WalletMessage:
+		return "WALLET"
 	default:
 		return "UNKNOWN"
 	}
@@ -49,57 +71,68 @@ type Execute struct {
 	Deadline *time.Time         `json:"deadline"`
 }
 
-//func (e Execute) MarshalJSON() ([]byte, error) {
-//	return json.Marshal(&struct {
-//		//Execute struct {
-//		Commands []commands.Command `json:"commands"`
-//		Deadline *time.Time         `json:"deadline"`
-//		//} `json:"execute"`
-//	}{
-//		Commands: e.Commands,
-//		Deadline: e.Deadline,
-//	})
-//}
+func (e Execute) MarshalJSON() ([]byte, error) {
+	type Alias Execute
+	return jsonx.Marshal((Alias)(e))
+}
 
-func (e Execute) Swap() *commands.V4Swap {
-	for i := range e.Commands {
-		if e.Commands[i].Type() == commands.V4_SWAP {
-			cmd := e.Commands[i]
-			swap := cmd.(commands.V4Swap)
-			return &swap
+// UnmarshalJSON unmarshals an Execute encoded by MarshalJSON, with each command of the type named by its "type".
+func (e *Execute) UnmarshalJSON(data []byte) error {
+	type Alias Execute
+	var v struct {
+		Alias
+		Commands []json.RawMessage `json:"commands"`
+	}
+	if err := jsonx.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = Execute(v.Alias)
+	for i, b := range v.Commands {
+		cmd, err := commands.Unmarshal(b)
+		if err != nil {
+			return fmt.Errorf("invalid command %d; %w", i, err)
 		}
+		e.Commands = append(e.Commands, cmd)
 	}
 	return nil
 }
 
+// Swap returns the first V4_SWAP command of e, or nil when there's none.
+func (e Execute) Swap() *commands.V4Swap {
+	var swap *commands.V4Swap
+	Walk(e, func(_ Path, node interface{}) error {
+		if s, ok := node.(commands.V4Swap); ok {
+			swap = &s
+			return SkipAll
+		}
+		return SkipChildren
+	})
+	return swap
+}
+
+// EthIn returns the input amount of the first exact input V4 swap of e when it sells native ETH, or nil otherwise.
 func (e Execute) EthIn() *big.Int {
-	var zeroAddress = common.Address{}
-
-	for i := range e.Commands {
-		cmd := e.Commands[i]
-		switch cmd.Type() {
-		case commands.V2_SWAP_EXACT_IN,
-			commands.V3_SWAP_EXACT_IN,
-			commands.V4_SWAP:
-			for _, action := range cmd.Actions() {
-				switch action.Type() {
-				case actions.SWAP_EXACT_IN:
-					a := action.(actions.SwapExactIn)
-					if a.CurrencyIn == zeroAddress {
-						return a.AmountIn
-					}
-					return nil
-				case actions.SWAP_EXACT_IN_SINGLE:
-					a := action.(actions.SwapExactInSingle)
-					if a.PoolKey.Currency0 == zeroAddress {
-						return a.AmountIn
-					}
-					return nil
-				}
+	var amountIn *big.Int
+	Walk(e, func(_ Path, node interface{}) error {
+		switch n := node.(type) {
+		case commands.Command:
+			if n.Type() != commands.V4_SWAP {
+				return SkipChildren
+			}
+		case actions.SwapExactIn:
+			if n.CurrencyIn == (common.Address{}) {
+				amountIn = n.AmountIn
+			}
+			return SkipAll
+		case actions.SwapExactInSingle:
+			if n.PoolKey.Currency0 == (common.Address{}) {
+				amountIn = n.AmountIn
 			}
+			return SkipAll
 		}
-	}
-	return nil
+		return nil
+	})
+	return amountIn
 }
 
 func MessageType(calldata []byte) messageType {
@@ -111,11 +144,55 @@ func MessageType(calldata []byte) messageType {
 	switch sig {
 	case executeSigStr, executeWithDeadlineSigStr:
 		return ExecuteMessage
+	case modifyLiquiditiesSigStr, modifyLiquiditiesWithoutUnlockSigStr, initializePoolSigStr:
+		return PositionManagerMessage
+	case v3PermitSigStr:
+		return V3PositionManagerMessage
+	case multicallSigStr:
+		return multicallType(calldata)
+	}
+	if isV3PositionManagerSig(hex.MethodSig(calldata)) {
+		return V3PositionManagerMessage
+	} else if isSwapRouter02Sig(sig) {
+		return SwapRouter02Message
+	} else if isV2RouterSig(sig) {
+		return V2RouterMessage
+	} else if isPermit2Sig(hex.MethodSig(calldata)) {
+		return Permit2Message
+	} else if isUniswapXSig(hex.MethodSig(calldata)) {
+		return UniswapXMessage
+	} else if isWalletSig(hex.MethodSig(calldata)) {
+		return WalletMessage
 	}
 	return UnknownMessage
 }
 
-var maxDeadline = big.NewInt(math.MaxInt64)
+// Decode decodes calldata of any supported message type, except Permit2, UniswapX and wallet calls which are decoded
+// by DecodePermit2, DecodeUniswapX and DecodeWallet.
+func Decode(calldata []byte) (Execute, error) {
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+
+	switch t := MessageType(calldata); t {
+	case ExecuteMessage:
+		return DecodeExecute(calldata)
+	case PositionManagerMessage:
+		return DecodePositionManager(calldata)
+	case V3PositionManagerMessage:
+		return DecodeV3PositionManager(calldata)
+	case SwapRouter02Message:
+		return DecodeSwapRouter02(calldata)
+	case V2RouterMessage:
+		return DecodeV2Router(calldata)
+	}
+	return Execute{}, fmt.Errorf("%w; unsupported method %x", ErrIncorrectMethodSig, hex.MethodSig(calldata))
+}
+
+func decodeDeadline(b []byte) *time.Time {
+	deadline := hex.Time(b)
+	return &deadline
+}
 
 func DecodeExecute(calldata []byte) (Execute, error) {
 	var e Execute
@@ -134,65 +211,12 @@ func DecodeExecute(calldata []byte) (Execute, error) {
 	// Remove method signature
 	calldata = calldata[4:]
 
-	// Get command start location
-	commandStart, err := hex.Int(calldata[:0x20])
-	if err != nil {
-		return e, fmt.Errorf("invalid command start memory location; %w", err)
-	}
-
-	commandLen, err := hex.Int(calldata[commandStart : commandStart+0x20])
-	if err != nil {
-		return e, fmt.Errorf("invalid command length value; %w", err)
-	}
-
 	e = Execute{}
-	if commandStart == 0x60 {
-		epoch := new(big.Int).SetBytes(calldata[0x40:0x60])
-		var deadline time.Time
-		if epoch.Cmp(maxDeadline) > 0 {
-			deadline = time.Unix(maxDeadline.Int64(), 0)
-		} else {
-			deadline = time.Unix(epoch.Int64(), 0)
-		}
-		e.Deadline = &deadline
-	}
-
-	offset := commandStart + 0x20
-	commandTypes, err := commands.DecodeType(calldata[offset : offset+commandLen])
-	if err != nil {
-		return e, fmt.Errorf("invalid commands; %w", err)
-	}
-
-	if commandLen > 0x20 {
-		// TODO
-	} else {
-		offset += 0x20
-	}
-
-	inputsLen, err := hex.Int(calldata[offset : offset+0x20])
-	if err != nil {
-		return e, fmt.Errorf("invalid inputs length; %w", err)
-	} else if inputsLen != commandLen {
-		return e, fmt.Errorf("inputs length mismatch; got %d but expected %d", inputsLen, commandLen)
-	}
-	offset += 0x20
-
-	var inputsLoc []int
-	for i := 0; i < inputsLen; i++ {
-		inputOffset := offset + i*0x20
-		loc, err := hex.Int(calldata[inputOffset : inputOffset+0x20])
-		if err != nil {
-			return e, fmt.Errorf("invalid input location for intput %d; %w", i, err)
-		}
-		inputsLoc = append(inputsLoc, loc)
+	if bytes.Equal(sig, executeWithDeadlineSig) && len(calldata) >= 0x60 {
+		e.Deadline = decodeDeadline(calldata[0x40:0x60])
 	}
 
-	for i, t := range commandTypes {
-		c, err := commands.Decode(t, calldata, offset+inputsLoc[i])
-		if err != nil {
-			return e, fmt.Errorf("invalid command data at index %d for %s; %w", i, t, err)
-		}
-		e.Commands = append(e.Commands, c)
-	}
-	return e, nil
+	var err error
+	e.Commands, err = commands.DecodePlan(calldata, 0)
+	return e, err
 }
@@ -0,0 +1,214 @@
+// Package diff compares decoded calls structurally, reporting the commands, actions, orders and fields added,
+// removed or changed between them.
+package diff
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/permit2"
+	"github.com/juztin/unidecode/uniswapx"
+)
+
+// Kind is how a field differs.
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Change is a field which differs, with its values as held by the field.Field of each call.
+type Change struct {
+	Kind Kind
+	// Path is the field's names from the call, with list indexes, such as Commands[1].Actions[0].AmountIn. Indexes
+	// are those of the second call, other than for removed fields which are of the first.
+	Path string
+	// From is the value of the first call, or nil when the field was added.
+	From interface{}
+	// To is the value of the second call, or nil when the field was removed.
+	To interface{}
+}
+
+// CallFields returns the fields of a decoded call; an unidecode.Execute, unidecode.Wallet, uniswapx.Execute or
+// permit2.Call. Commands, actions, orders and wrapped calls are list items holding their type.
+func CallFields(v interface{}) []field.Field {
+	switch c := v.(type) {
+	case unidecode.Execute:
+		var deadline interface{}
+		if c.Deadline != nil {
+			deadline = *c.Deadline
+		}
+		cmds := field.Field{Name: "Commands", Items: make([]field.Field, len(c.Commands))}
+		for i, cmd := range c.Commands {
+			fields := cmd.Fields()
+			if as := cmd.Actions(); len(as) > 0 {
+				actions := field.Field{Name: "Actions", Items: make([]field.Field, len(as))}
+				for j, a := range as {
+					actions.Items[j] = field.Field{Value: a.Type().String(), Fields: a.Fields()}
+				}
+				fields = append(fields, actions)
+			}
+			cmds.Items[i] = field.Field{Value: cmd.Type().String(), Fields: fields}
+		}
+		return []field.Field{{Name: "Deadline", Value: deadline}, cmds}
+	case unidecode.Wallet:
+		calls := field.Field{Name: "Calls", Items: make([]field.Field, len(c.Calls))}
+		for i, wc := range c.Calls {
+			fields := []field.Field{{Name: "To", Value: wc.To}, {Name: "Value", Value: wc.Value}}
+			calls.Items[i] = field.Field{Value: wc.Message, Fields: append(fields, CallFields(wc.Call)...)}
+		}
+		return []field.Field{{Name: "Method", Value: c.Method.String()}, calls}
+	case uniswapx.Execute:
+		orders := field.Field{Name: "Orders", Items: make([]field.Field, len(c.Orders))}
+		for i, o := range c.Orders {
+			orders.Items[i] = field.Field{Value: o.Order.Type().String(), Fields: o.Fields()}
+		}
+		return []field.Field{
+			{Name: "Method", Value: c.Method.String()},
+			{Name: "CallbackData", Value: c.CallbackData},
+			orders,
+		}
+	case permit2.Call:
+		return append([]field.Field{{Name: "Method", Value: c.Type().String()}}, c.Fields()...)
+	}
+	return nil
+}
+
+// Calls returns the changes from the decoded call a to b, as fields of CallFields.
+func Calls(a, b interface{}) []Change {
+	return Fields(CallFields(a), CallFields(b))
+}
+
+// Fields returns the changes from the fields a to b. Fields are matched by name, and list items by their longest
+// common subsequence of values, so commands and actions inserted or removed are reported as such rather than as each
+// later item changing.
+func Fields(a, b []field.Field) []Change {
+	var d differ
+	d.fields("", a, b)
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+}
+
+func join(path, name string) string {
+	if path == "" || name == "" {
+		return path + name
+	}
+	return path + "." + name
+}
+
+func (d *differ) fields(path string, a, b []field.Field) {
+	matched := make([]bool, len(b))
+	for _, fa := range a {
+		j := -1
+		for i, fb := range b {
+			if !matched[i] && fb.Name == fa.Name {
+				j = i
+				break
+			}
+		}
+		if j < 0 {
+			d.all(Removed, join(path, fa.Name), fa)
+			continue
+		}
+		matched[j] = true
+		d.field(join(path, fa.Name), fa, b[j])
+	}
+	for i, fb := range b {
+		if !matched[i] {
+			d.all(Added, join(path, fb.Name), fb)
+		}
+	}
+}
+
+func (d *differ) field(path string, a, b field.Field) {
+	if text(a.Value) != text(b.Value) {
+		switch {
+		case a.Value == nil:
+			d.changes = append(d.changes, Change{Added, path, nil, b.Value})
+		case b.Value == nil:
+			d.changes = append(d.changes, Change{Removed, path, a.Value, nil})
+		default:
+			d.changes = append(d.changes, Change{Changed, path, a.Value, b.Value})
+		}
+	}
+	d.fields(path, a.Fields, b.Fields)
+	d.items(path, a.Items, b.Items)
+}
+
+// items compares the lists a and b, matching items of their longest common subsequence of values.
+func (d *differ) items(path string, a, b []field.Field) {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if text(a[i].Value) == text(b[j].Value) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && text(a[i].Value) == text(b[j].Value):
+			d.field(fmt.Sprintf("%s[%d]", path, j), a[i], b[j])
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			d.all(Removed, fmt.Sprintf("%s[%d]", path, i), a[i])
+			i++
+		default:
+			d.all(Added, fmt.Sprintf("%s[%d]", path, j), b[j])
+			j++
+		}
+	}
+}
+
+// all records the field f, and each of its nested fields and items, as added or removed.
+func (d *differ) all(kind Kind, path string, f field.Field) {
+	if f.Value != nil {
+		c := Change{Kind: kind, Path: path}
+		if kind == Added {
+			c.To = f.Value
+		} else {
+			c.From = f.Value
+		}
+		d.changes = append(d.changes, c)
+	}
+	for _, nested := range f.Fields {
+		d.all(kind, join(path, nested.Name), nested)
+	}
+	for i, item := range f.Items {
+		d.all(kind, fmt.Sprintf("%s[%d]", path, i), item)
+	}
+}
+
+// text returns the text values are compared by.
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return fmt.Sprintf("%x", v)
+	case *big.Int:
+		if v == nil {
+			return ""
+		}
+	case time.Time:
+		return fmt.Sprint(v.Unix())
+	}
+	return fmt.Sprint(v)
+}
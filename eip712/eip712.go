@@ -0,0 +1,102 @@
+package eip712
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+var (
+	// keccak256("EIP712Domain(string name,uint256 chainId,address verifyingContract)")
+	domainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)"))
+	// keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")
+	domainVersionTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+)
+
+// Domain is an EIP-712 domain, where an empty Version is omitted from the type.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract common.Address
+}
+
+// Separator returns the domain separator of d.
+func (d Domain) Separator() common.Hash {
+	chainID := new(big.Int).SetUint64(d.ChainID)
+	if d.Version == "" {
+		return crypto.Keccak256Hash(
+			domainTypeHash[:],
+			crypto.Keccak256([]byte(d.Name)),
+			word(chainID),
+			word(d.VerifyingContract),
+		)
+	}
+	return crypto.Keccak256Hash(
+		domainVersionTypeHash[:],
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		word(chainID),
+		word(d.VerifyingContract),
+	)
+}
+
+// Digest returns the hash signed for structHash within domain d.
+func (d Domain) Digest(structHash common.Hash) common.Hash {
+	sep := d.Separator()
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, sep[:], structHash[:])
+}
+
+// Recover returns the address that signed digest.
+//
+// Supports 65 byte `r | s | v` signatures, where v is either 0/1 or 27/28, and 64 byte EIP-2098 `r | vs` compact
+// signatures.
+func Recover(digest common.Hash, sig []byte) (common.Address, error) {
+	var rsv [65]byte
+	switch len(sig) {
+	case 65:
+		copy(rsv[:], sig)
+		if rsv[64] >= 27 {
+			rsv[64] -= 27
+		}
+	case 64:
+		// vs = (v - 27) << 255 | s
+		copy(rsv[:64], sig)
+		rsv[64] = sig[32] >> 7
+		rsv[32] &= 0x7f
+	default:
+		return common.Address{}, fmt.Errorf("%w; unexpected length %d", ErrInvalidSignature, len(sig))
+	}
+	if rsv[64] > 1 {
+		return common.Address{}, fmt.Errorf("%w; invalid v value %d", ErrInvalidSignature, rsv[64]+27)
+	}
+
+	pub, err := crypto.SigToPub(digest[:], rsv[:])
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w; %w", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// word returns v ABI encoded as a single 32 byte word.
+func word(v interface{}) []byte {
+	switch v := v.(type) {
+	case common.Address:
+		return common.LeftPadBytes(v[:], 32)
+	case *big.Int:
+		if v == nil {
+			return make([]byte, 32)
+		}
+		return common.LeftPadBytes(v.Bytes(), 32)
+	case int64:
+		return common.LeftPadBytes(big.NewInt(v).Bytes(), 32)
+	case uint64:
+		return common.LeftPadBytes(new(big.Int).SetUint64(v).Bytes(), 32)
+	}
+	panic(fmt.Sprintf("eip712: unsupported word type %T", v))
+}
@@ -0,0 +1,87 @@
+package eip712
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/juztin/unidecode/commands"
+)
+
+// Permit2
+//
+// see: permit2/src/libraries/PermitHash.sol
+var (
+	permitDetailsTypeHash = crypto.Keccak256Hash([]byte(
+		"PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	permitSingleTypeHash = crypto.Keccak256Hash([]byte(
+		"PermitSingle(PermitDetails details,address spender,uint256 sigDeadline)" +
+			"PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	permitBatchTypeHash = crypto.Keccak256Hash([]byte(
+		"PermitBatch(PermitDetails[] details,address spender,uint256 sigDeadline)" +
+			"PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+)
+
+// NonfungiblePositionManager
+//
+// see: v3-periphery/contracts/base/ERC721Permit.sol
+var v3PermitTypeHash = crypto.Keccak256Hash([]byte("Permit(address spender,uint256 tokenId,uint256 nonce,uint256 deadline)"))
+
+// Permit2Domain returns the EIP-712 domain of the Permit2 contract at permit2 on chainID.
+func Permit2Domain(chainID uint64, permit2 common.Address) Domain {
+	return Domain{Name: "Permit2", ChainID: chainID, VerifyingContract: permit2}
+}
+
+// V3PositionsDomain returns the EIP-712 domain of the NonfungiblePositionManager at npm on chainID.
+func V3PositionsDomain(chainID uint64, npm common.Address) Domain {
+	return Domain{Name: "Uniswap V3 Positions NFT-V1", Version: "1", ChainID: chainID, VerifyingContract: npm}
+}
+
+func hashPermitDetails(d commands.PermitDetails) []byte {
+	return crypto.Keccak256(
+		permitDetailsTypeHash[:],
+		word(d.Token),
+		word(d.Amount),
+		word(d.Expiration.Unix()),
+		word(d.Nonce),
+	)
+}
+
+// HashPermitSingle returns the struct hash of a Permit2 `PermitSingle`.
+func HashPermitSingle(details commands.PermitDetails, spender common.Address, sigDeadline *big.Int) common.Hash {
+	return crypto.Keccak256Hash(
+		permitSingleTypeHash[:],
+		hashPermitDetails(details),
+		word(spender),
+		word(sigDeadline),
+	)
+}
+
+// HashPermitBatch returns the struct hash of a Permit2 `PermitBatch`.
+func HashPermitBatch(details []commands.PermitDetails, spender common.Address, sigDeadline *big.Int) common.Hash {
+	var hashes []byte
+	for _, d := range details {
+		hashes = append(hashes, hashPermitDetails(d)...)
+	}
+	return crypto.Keccak256Hash(
+		permitBatchTypeHash[:],
+		crypto.Keccak256(hashes),
+		word(spender),
+		word(sigDeadline),
+	)
+}
+
+// HashV3Permit returns the struct hash of a NonfungiblePositionManager `Permit`.
+//
+// The nonce isn't part of the calldata; it's the position's nonce prior to the permit being used.
+func HashV3Permit(spender common.Address, tokenID, nonce *big.Int, deadline time.Time) common.Hash {
+	return crypto.Keccak256Hash(
+		v3PermitTypeHash[:],
+		word(spender),
+		word(tokenID),
+		word(nonce),
+		word(deadline.Unix()),
+	)
+}
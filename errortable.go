@@ -0,0 +1,161 @@
+// Code generated by internal/errgen from abi; DO NOT EDIT.
+
+package unidecode
+
+var errorTable = map[string]ErrorSig{
+	"0x00bfc921": {Name: "InvalidPrice", Signature: "InvalidPrice()", Contracts: []string{"V4Core"}},
+	"0x01b673a7": {Name: "AddLiquidityDirectToHook", Signature: "AddLiquidityDirectToHook()", Contracts: []string{"V4Periphery"}},
+	"0x0474c5c1": {Name: "CompetitionNotOver", Signature: "CompetitionNotOver(uint256,uint256)", Contracts: []string{"V4Periphery"}},
+	"0x06ee9878": {Name: "OrdersLengthIncorrect", Signature: "OrdersLengthIncorrect()", Contracts: []string{"UniswapX"}},
+	"0x0a85dc29": {Name: "HookNotImplemented", Signature: "HookNotImplemented()", Contracts: []string{"V4Periphery"}},
+	"0x0ace433b": {Name: "ExactInputNotSupported", Signature: "ExactInputNotSupported()", Contracts: []string{"V4Periphery"}},
+	"0x0ca968d8": {Name: "NotApproved", Signature: "NotApproved(address)", Contracts: []string{"V4Periphery"}},
+	"0x0d89438e": {Name: "DelegateCallNotAllowed", Signature: "DelegateCallNotAllowed()", Contracts: []string{"V4Core"}},
+	"0x0e996766": {Name: "InvalidDecayCurve", Signature: "InvalidDecayCurve()", Contracts: []string{"UniswapX"}},
+	"0x1213a0ab": {Name: "InvalidPoolFee", Signature: "InvalidPoolFee()", Contracts: []string{"UniversalRouter"}},
+	"0x1231ae40": {Name: "ETHNotAccepted", Signature: "ETHNotAccepted()", Contracts: []string{"UniversalRouter"}},
+	"0x12816f22": {Name: "MinimumAmountInsufficient", Signature: "MinimumAmountInsufficient(uint128,uint128)", Contracts: []string{"V4Periphery"}},
+	"0x12bacdd3": {Name: "V4TooMuchRequested", Signature: "V4TooMuchRequested(uint256,uint256)", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0x14002113": {Name: "LPFeeTooLarge", Signature: "LPFeeTooLarge(uint24)", Contracts: []string{"V4Core"}},
+	"0x1ad777f8": {Name: "TickUpperOutOfBounds", Signature: "TickUpperOutOfBounds(int24)", Contracts: []string{"V4Core"}},
+	"0x1e048e1d": {Name: "InvalidHookResponse", Signature: "InvalidHookResponse()", Contracts: []string{"V4Core"}},
+	"0x1fb09b80": {Name: "NonceAlreadyUsed", Signature: "NonceAlreadyUsed()", Contracts: []string{"V4Periphery"}},
+	"0x20db8267": {Name: "InvalidPath", Signature: "InvalidPath()", Contracts: []string{"UniversalRouter"}},
+	"0x21b865b3": {Name: "ExactOutputNotSupported", Signature: "ExactOutputNotSupported()", Contracts: []string{"V4Periphery"}},
+	"0x23639643": {Name: "InvalidBytecode", Signature: "InvalidBytecode()", Contracts: []string{"V4Periphery"}},
+	"0x237e6c28": {Name: "NotSubscribed", Signature: "NotSubscribed()", Contracts: []string{"V4Periphery"}},
+	"0x24d35a26": {Name: "ExcessiveInvalidation", Signature: "ExcessiveInvalidation()", Contracts: []string{"Permit2"}},
+	"0x25fbd8be": {Name: "AlreadySubscribed", Signature: "AlreadySubscribed(uint256,address)", Contracts: []string{"V4Periphery"}},
+	"0x29551db8": {Name: "WorseAddress", Signature: "WorseAddress(address,address,uint256,uint256)", Contracts: []string{"V4Periphery"}},
+	"0x29c3b7ee": {Name: "NotSelf", Signature: "NotSelf()", Contracts: []string{"V4Periphery"}},
+	"0x2c4029e9": {Name: "ExecutionFailed", Signature: "ExecutionFailed(uint256,bytes)", Contracts: []string{"UniversalRouter"}},
+	"0x30d21641": {Name: "UnauthorizedDynamicLPFeeUpdate", Signature: "UnauthorizedDynamicLPFeeUpdate()", Contracts: []string{"V4Core"}},
+	"0x316cf0eb": {Name: "V3InvalidSwap", Signature: "V3InvalidSwap()", Contracts: []string{"UniversalRouter"}},
+	"0x31e30ad0": {Name: "MaximumAmountExceeded", Signature: "MaximumAmountExceeded(uint128,uint128)", Contracts: []string{"V4Periphery"}},
+	"0x32b13d91": {Name: "V3InvalidCaller", Signature: "V3InvalidCaller()", Contracts: []string{"UniversalRouter"}},
+	"0x3351b260": {Name: "DeltaNotNegative", Signature: "DeltaNotNegative(address)", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0x3728b83d": {Name: "InvalidAmount", Signature: "InvalidAmount(uint256)", Contracts: []string{"Permit2"}},
+	"0x38bbd576": {Name: "InvalidEthSender", Signature: "InvalidEthSender()", Contracts: []string{"UniversalRouter"}},
+	"0x39d35496": {Name: "V3TooLittleReceived", Signature: "V3TooLittleReceived()", Contracts: []string{"UniversalRouter"}},
+	"0x3b99b53d": {Name: "SliceOutOfBounds", Signature: "SliceOutOfBounds()", Contracts: []string{"UniversalRouter"}},
+	"0x41ca9285": {Name: "InvalidArrLength", Signature: "InvalidArrLength()", Contracts: []string{"UniversalRouter"}},
+	"0x43133453": {Name: "EndTimeBeforeStartTime", Signature: "EndTimeBeforeStartTime()", Contracts: []string{"UniswapX"}},
+	"0x4323a555": {Name: "NotEnoughLiquidity", Signature: "NotEnoughLiquidity()", Contracts: []string{"V4Core"}},
+	"0x486aa307": {Name: "PoolNotInitialized", Signature: "PoolNotInitialized()", Contracts: []string{"V4Core"}},
+	"0x48f5c3ed": {Name: "InvalidCaller", Signature: "InvalidCaller()", Contracts: []string{"V4Core"}},
+	"0x4be6321b": {Name: "InvalidSignatureLength", Signature: "InvalidSignatureLength()", Contracts: []string{"Permit2", "V4Periphery"}},
+	"0x4c085bf1": {Name: "DeltaNotPositive", Signature: "DeltaNotPositive(address)", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0x4ddf4a64": {Name: "InvalidReactor", Signature: "InvalidReactor()", Contracts: []string{"UniswapX"}},
+	"0x4e23d035": {Name: "IndexOutOfBounds", Signature: "IndexOutOfBounds()", Contracts: []string{"UniversalRouter"}},
+	"0x4f2461b8": {Name: "InvalidPriceOrLiquidity", Signature: "InvalidPriceOrLiquidity()", Contracts: []string{"V4Core"}},
+	"0x5090d6c6": {Name: "AlreadyUnlocked", Signature: "AlreadyUnlocked()", Contracts: []string{"V4Core"}},
+	"0x5212cba1": {Name: "CurrencyNotSettled", Signature: "CurrencyNotSettled()", Contracts: []string{"V4Core"}},
+	"0x54e3ca0d": {Name: "ManagerLocked", Signature: "ManagerLocked()", Contracts: []string{"V4Core"}},
+	"0x5a8b05b0": {Name: "NoSwapOccurred", Signature: "NoSwapOccurred()", Contracts: []string{"UniversalRouter"}},
+	"0x5a9165ff": {Name: "SignatureDeadlineExpired", Signature: "SignatureDeadlineExpired()", Contracts: []string{"V4Periphery"}},
+	"0x5bf6f916": {Name: "TransactionDeadlinePassed", Signature: "TransactionDeadlinePassed()", Contracts: []string{"UniversalRouter"}},
+	"0x5cda29d7": {Name: "UnsupportedAction", Signature: "UnsupportedAction(uint256)", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0x5d1d0f9f": {Name: "OnlyMintAllowed", Signature: "OnlyMintAllowed()", Contracts: []string{"UniversalRouter"}},
+	"0x61487524": {Name: "InvalidSqrtPrice", Signature: "InvalidSqrtPrice(uint160)", Contracts: []string{"V4Core"}},
+	"0x6190b2b0": {Name: "UnexpectedRevertBytes", Signature: "UnexpectedRevertBytes(bytes)", Contracts: []string{"V4Periphery"}},
+	"0x675cae38": {Name: "InsufficientToken", Signature: "InsufficientToken()", Contracts: []string{"UniversalRouter"}},
+	"0x6a12f104": {Name: "InsufficientETH", Signature: "InsufficientETH()", Contracts: []string{"UniversalRouter"}},
+	"0x6d1eca28": {Name: "InvalidAddressLength", Signature: "InvalidAddressLength(uint256)", Contracts: []string{"V4Periphery"}},
+	"0x6e6c9830": {Name: "CurrenciesOutOfOrderOrEqual", Signature: "CurrenciesOutOfOrderOrEqual(address,address)", Contracts: []string{"V4Core"}},
+	"0x6f05727d": {Name: "NotAuthorizedNotifer", Signature: "NotAuthorizedNotifer(address)", Contracts: []string{"V4Periphery"}},
+	"0x6f5ffb7e": {Name: "ContractLocked", Signature: "ContractLocked()", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0x739dbe52": {Name: "V3TooMuchRequested", Signature: "V3TooMuchRequested()", Contracts: []string{"UniversalRouter"}},
+	"0x756688fe": {Name: "InvalidNonce", Signature: "InvalidNonce()", Contracts: []string{"Permit2"}},
+	"0x75c1bb14": {Name: "NotExclusiveFiller", Signature: "NotExclusiveFiller(address)", Contracts: []string{"UniswapX"}},
+	"0x769d11e4": {Name: "InvalidDeadline", Signature: "InvalidDeadline()", Contracts: []string{"UniswapX"}},
+	"0x773a6187": {Name: "DeadlineBeforeEndTime", Signature: "DeadlineBeforeEndTime()", Contracts: []string{"UniswapX"}},
+	"0x78895c13": {Name: "LiquidityNotAllowed", Signature: "LiquidityNotAllowed()", Contracts: []string{"V4Periphery"}},
+	"0x7983c051": {Name: "PoolAlreadyInitialized", Signature: "PoolAlreadyInitialized()", Contracts: []string{"V4Core"}},
+	"0x7a5ed734": {Name: "NotEnoughLiquidity", Signature: "NotEnoughLiquidity(bytes32)", Contracts: []string{"V4Periphery"}},
+	"0x7b9c8916": {Name: "InvalidReserves", Signature: "InvalidReserves()", Contracts: []string{"UniversalRouter"}},
+	"0x7c1f8113": {Name: "IncorrectAmounts", Signature: "IncorrectAmounts()", Contracts: []string{"UniswapX"}},
+	"0x7c402b21": {Name: "NoCodeSubscriber", Signature: "NoCodeSubscriber()", Contracts: []string{"V4Periphery"}},
+	"0x7c9c6e8f": {Name: "PriceLimitAlreadyExceeded", Signature: "PriceLimitAlreadyExceeded(uint160,uint160)", Contracts: []string{"V4Core"}},
+	"0x80e05c00": {Name: "NoSelfPermit", Signature: "NoSelfPermit()", Contracts: []string{"V4Periphery"}},
+	"0x815e1d64": {Name: "InvalidSigner", Signature: "InvalidSigner()", Contracts: []string{"Permit2", "V4Periphery"}},
+	"0x81ea5e9e": {Name: "SubscriptionReverted", Signature: "SubscriptionReverted(address,bytes)", Contracts: []string{"V4Periphery"}},
+	"0x82b42900": {Name: "Unauthorized", Signature: "Unauthorized()", Contracts: []string{"V4Core"}},
+	"0x82e75656": {Name: "FeeTooLarge", Signature: "FeeTooLarge(address,uint256,address)", Contracts: []string{"UniswapX"}},
+	"0x849eaf98": {Name: "V2TooLittleReceived", Signature: "V2TooLittleReceived()", Contracts: []string{"UniversalRouter"}},
+	"0x8ab0bc16": {Name: "V2TooMuchRequested", Signature: "V2TooMuchRequested()", Contracts: []string{"UniversalRouter"}},
+	"0x8b063d73": {Name: "V4TooLittleReceived", Signature: "V4TooLittleReceived(uint256,uint256)", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0x8b86327a": {Name: "InvalidTick", Signature: "InvalidTick(int24)", Contracts: []string{"V4Core"}},
+	"0x8baa579f": {Name: "InvalidSignature", Signature: "InvalidSignature()", Contracts: []string{"Permit2", "V4Periphery"}},
+	"0x8c6e5d71": {Name: "CallerNotWhitelisted", Signature: "CallerNotWhitelisted()", Contracts: []string{"UniswapX"}},
+	"0x9096cccb": {Name: "KeyNotSet", Signature: "KeyNotSet()", Contracts: []string{"V4Periphery"}},
+	"0x90bfb865": {Name: "WrappedError", Signature: "WrappedError(address,bytes4,bytes,bytes)", Contracts: []string{"V4Core"}},
+	"0x933fe52f": {Name: "MsgSenderNotReactor", Signature: "MsgSenderNotReactor()", Contracts: []string{"UniswapX"}},
+	"0x93dafdf1": {Name: "SafeCastOverflow", Signature: "SafeCastOverflow()", Contracts: []string{"V4Core"}},
+	"0x96206246": {Name: "InvalidFeeForExactOut", Signature: "InvalidFeeForExactOut()", Contracts: []string{"V4Core"}},
+	"0x9e4d7cc7": {Name: "PriceLimitOutOfBounds", Signature: "PriceLimitOutOfBounds(uint160)", Contracts: []string{"V4Core"}},
+	"0xa305df82": {Name: "InvalidCosignerOutput", Signature: "InvalidCosignerOutput()", Contracts: []string{"UniswapX"}},
+	"0xa3281672": {Name: "BalanceTooLow", Signature: "BalanceTooLow()", Contracts: []string{"UniversalRouter"}},
+	"0xa6b844f5": {Name: "InputOutputScaling", Signature: "InputOutputScaling()", Contracts: []string{"UniswapX"}},
+	"0xa74f97ab": {Name: "NoLiquidityToReceiveFees", Signature: "NoLiquidityToReceiveFees()", Contracts: []string{"V4Core"}},
+	"0xa7abe2f7": {Name: "ProtocolFeeTooLarge", Signature: "ProtocolFeeTooLarge(uint24)", Contracts: []string{"V4Core"}},
+	"0xa9e35b2f": {Name: "HookCallFailed", Signature: "HookCallFailed()", Contracts: []string{"V4Core"}},
+	"0xaaad13f7": {Name: "InputLengthMismatch", Signature: "InputLengthMismatch()", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0xac9143e7": {Name: "InvalidCosignerInput", Signature: "InvalidCosignerInput()", Contracts: []string{"UniswapX"}},
+	"0xace94481": {Name: "BurnNotificationReverted", Signature: "BurnNotificationReverted(address,bytes)", Contracts: []string{"V4Periphery"}},
+	"0xae18210a": {Name: "NotPoolManager", Signature: "NotPoolManager()", Contracts: []string{"UniversalRouter", "V4Periphery"}},
+	"0xae52ad0c": {Name: "V2InvalidPath", Signature: "V2InvalidPath()", Contracts: []string{"UniversalRouter"}},
+	"0xaefeb924": {Name: "CannotUpdateEmptyPosition", Signature: "CannotUpdateEmptyPosition()", Contracts: []string{"V4Core"}},
+	"0xafa178b0": {Name: "CompetitionOver", Signature: "CompetitionOver(uint256,uint256)", Contracts: []string{"V4Periphery"}},
+	"0xb0669cbc": {Name: "InvalidContractSignature", Signature: "InvalidContractSignature()", Contracts: []string{"Permit2", "V4Periphery"}},
+	"0xb08ce5b3": {Name: "DeadlineReached", Signature: "DeadlineReached()", Contracts: []string{"UniswapX"}},
+	"0xb0ec849e": {Name: "NonzeroNativeValue", Signature: "NonzeroNativeValue()", Contracts: []string{"V4Core"}},
+	"0xb418cb98": {Name: "NotAllowedReenter", Signature: "NotAllowedReenter()", Contracts: []string{"V4Periphery"}},
+	"0xb70024f8": {Name: "TickSpacingTooLarge", Signature: "TickSpacingTooLarge(int24)", Contracts: []string{"V4Core"}},
+	"0xb8e3c385": {Name: "TickLiquidityOverflow", Signature: "TickLiquidityOverflow(int24)", Contracts: []string{"V4Core"}},
+	"0xb9ec1e96": {Name: "NoExclusiveOverride", Signature: "NoExclusiveOverride()", Contracts: []string{"UniswapX"}},
+	"0xbb25d4c5": {Name: "NotAuthorizedForToken", Signature: "NotAuthorizedForToken(uint256)", Contracts: []string{"UniversalRouter"}},
+	"0xbda73abf": {Name: "MustClearExactPositiveDelta", Signature: "MustClearExactPositiveDelta()", Contracts: []string{"V4Core"}},
+	"0xbe8b8507": {Name: "SwapAmountCannotBeZero", Signature: "SwapAmountCannotBeZero()", Contracts: []string{"V4Core"}},
+	"0xbfb22adf": {Name: "DeadlinePassed", Signature: "DeadlinePassed(uint256)", Contracts: []string{"V4Periphery"}},
+	"0xc419b2d2": {Name: "NotAllowedToDeploy", Signature: "NotAllowedToDeploy(address,address)", Contracts: []string{"V4Periphery"}},
+	"0xc4433ed5": {Name: "TicksMisordered", Signature: "TicksMisordered(int24,int24)", Contracts: []string{"V4Core"}},
+	"0xc4bd89a9": {Name: "UnsafeCast", Signature: "UnsafeCast()", Contracts: []string{"UniversalRouter"}},
+	"0xc6035520": {Name: "OrderNotFillable", Signature: "OrderNotFillable()", Contracts: []string{"UniswapX"}},
+	"0xc79e5948": {Name: "ProtocolFeeCurrencySynced", Signature: "ProtocolFeeCurrencySynced()", Contracts: []string{"V4Core"}},
+	"0xcd21db4f": {Name: "SignatureExpired", Signature: "SignatureExpired(uint256)", Contracts: []string{"Permit2"}},
+	"0xd0df97cc": {Name: "ActionNotSupported", Signature: "ActionNotSupported()", Contracts: []string{"V4Periphery"}},
+	"0xd303758b": {Name: "InputAndOutputDecay", Signature: "InputAndOutputDecay()", Contracts: []string{"UniswapX"}},
+	"0xd4b05fe0": {Name: "PoolManagerMustBeLocked", Signature: "PoolManagerMustBeLocked()", Contracts: []string{"V4Periphery"}},
+	"0xd4d8f3e6": {Name: "TickMisaligned", Signature: "TickMisaligned(int24,int24)", Contracts: []string{"V4Core"}},
+	"0xd4e0248e": {Name: "V3InvalidAmountOut", Signature: "V3InvalidAmountOut()", Contracts: []string{"UniversalRouter"}},
+	"0xd5e2f7ab": {Name: "TickLowerOutOfBounds", Signature: "TickLowerOutOfBounds(int24)", Contracts: []string{"V4Core"}},
+	"0xd6234725": {Name: "NotImplemented", Signature: "NotImplemented()", Contracts: []string{"UniversalRouter"}},
+	"0xd76a1e9e": {Name: "InvalidCommandType", Signature: "InvalidCommandType(uint256)", Contracts: []string{"UniversalRouter"}},
+	"0xd7815be1": {Name: "InvalidCosignature", Signature: "InvalidCosignature()", Contracts: []string{"UniswapX"}},
+	"0xd81b2f2e": {Name: "AllowanceExpired", Signature: "AllowanceExpired(uint256)", Contracts: []string{"Permit2"}},
+	"0xdcdedda9": {Name: "InvalidPoolToken", Signature: "InvalidPoolToken()", Contracts: []string{"UniversalRouter"}},
+	"0xdeaa01e6": {Name: "InvalidBips", Signature: "InvalidBips()", Contracts: []string{"UniversalRouter"}},
+	"0xe0752a5a": {Name: "UnexpectedCallSuccess", Signature: "UnexpectedCallSuccess()", Contracts: []string{"V4Periphery"}},
+	"0xe65af6a0": {Name: "HookAddressNotValid", Signature: "HookAddressNotValid(address)", Contracts: []string{"V4Core"}},
+	"0xe7002877": {Name: "FromAddressIsNotOwner", Signature: "FromAddressIsNotOwner()", Contracts: []string{"UniversalRouter"}},
+	"0xe70ff93c": {Name: "Permit2NotDeployed", Signature: "Permit2NotDeployed()", Contracts: []string{"UniversalRouter"}},
+	"0xe94f10e2": {Name: "ModifyLiquidityNotificationReverted", Signature: "ModifyLiquidityNotificationReverted(address,bytes)", Contracts: []string{"V4Periphery"}},
+	"0xe9e90588": {Name: "TickSpacingTooSmall", Signature: "TickSpacingTooSmall(int24)", Contracts: []string{"V4Core"}},
+	"0xea3559ef": {Name: "UnsupportedProtocolError", Signature: "UnsupportedProtocolError()", Contracts: []string{"UniversalRouter"}},
+	"0xecbd9804": {Name: "QuoteSwap", Signature: "QuoteSwap(uint256)", Contracts: []string{"V4Periphery"}},
+	"0xed15e6cf": {Name: "InvalidTokenId", Signature: "InvalidTokenId(uint256)", Contracts: []string{"V4Periphery"}},
+	"0xed43c3a6": {Name: "GasLimitTooLow", Signature: "GasLimitTooLow()", Contracts: []string{"V4Periphery"}},
+	"0xedc7e2e4": {Name: "InputAndOutputFees", Signature: "InputAndOutputFees()", Contracts: []string{"UniswapX"}},
+	"0xeddf07f5": {Name: "InvalidFeeToken", Signature: "InvalidFeeToken(address)", Contracts: []string{"UniswapX"}},
+	"0xee3b3d4b": {Name: "OrderAlreadyFilled", Signature: "OrderAlreadyFilled()", Contracts: []string{"UniswapX"}},
+	"0xf27f64e4": {Name: "ERC20TransferFailed", Signature: "ERC20TransferFailed()", Contracts: []string{"V4Core"}},
+	"0xf3eb44e5": {Name: "InvalidGasPrice", Signature: "InvalidGasPrice()", Contracts: []string{"UniswapX"}},
+	"0xf4b3b1bc": {Name: "NativeTransferFailed", Signature: "NativeTransferFailed()", Contracts: []string{"UniswapX", "V4Core"}},
+	"0xf4d678b8": {Name: "InsufficientBalance", Signature: "InsufficientBalance()", Contracts: []string{"UniversalRouter"}},
+	"0xf5c787f1": {Name: "PriceOverflow", Signature: "PriceOverflow()", Contracts: []string{"V4Core"}},
+	"0xf801e525": {Name: "InvalidAction", Signature: "InvalidAction(bytes4)", Contracts: []string{"UniversalRouter"}},
+	"0xf96fb071": {Name: "InsufficientAllowance", Signature: "InsufficientAllowance(uint256)", Contracts: []string{"Permit2"}},
+	"0xfa0b71d6": {Name: "HookDeltaExceedsSwapAmount", Signature: "HookDeltaExceedsSwapAmount()", Contracts: []string{"V4Core"}},
+	"0xff633a38": {Name: "LengthMismatch", Signature: "LengthMismatch()", Contracts: []string{"Permit2", "UniversalRouter"}},
+	"0xfff08303": {Name: "DuplicateFeeOutput", Signature: "DuplicateFeeOutput(address)", Contracts: []string{"UniswapX"}},
+}
@@ -0,0 +1,21 @@
+package unidecode
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestErrorTableSelectors checks each selector of the generated error table is that of its signature.
+func TestErrorTableSelectors(t *testing.T) {
+	for selector, sig := range errorTable {
+		if got := fmt.Sprintf("0x%x", crypto.Keccak256([]byte(sig.Signature))[:4]); got != selector {
+			t.Errorf("%s: selector of %s is %s", selector, sig.Signature, got)
+		}
+		if !strings.HasPrefix(sig.Signature, sig.Name+"(") {
+			t.Errorf("%s: name %s doesn't match signature %s", selector, sig.Name, sig.Signature)
+		}
+	}
+}
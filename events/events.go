@@ -0,0 +1,427 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+var (
+	ErrUnknownEvent = errors.New("unknown event")
+	ErrInvalidLog   = errors.New("invalid log")
+)
+
+// Event is a decoded receipt log.
+type Event interface {
+	Type() Type
+}
+
+// Decode decodes a receipt log, returning ErrUnknownEvent when it isn't one of the supported events.
+//
+// Deposit and Withdrawal logs are only decoded when emitted by weth, the chain's wrapped native currency, as other
+// tokens share their signatures. Logs whose topics or data don't fit their event, being another contract's event of
+// the same signature, are also unknown.
+func Decode(log *types.Log, weth common.Address) (Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	t, err := Parse(log.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var e Event
+	switch t {
+	case V2_SWAP:
+		e, err = DecodeV2Swap(log)
+	case V3_SWAP:
+		e, err = DecodeV3Swap(log)
+	case V4_SWAP:
+		e, err = DecodeV4Swap(log)
+	case V4_MODIFY_LIQUIDITY:
+		e, err = DecodeV4ModifyLiquidity(log)
+	case V4_INITIALIZE:
+		e, err = DecodeV4Initialize(log)
+	case TRANSFER:
+		// ERC-721 transfers share the signature but index the token ID
+		if len(log.Topics) != 3 {
+			return nil, ErrUnknownEvent
+		}
+		e, err = DecodeTransfer(log)
+	case DEPOSIT:
+		if log.Address != weth {
+			return nil, ErrUnknownEvent
+		}
+		e, err = DecodeDeposit(log)
+	case WITHDRAWAL:
+		if log.Address != weth {
+			return nil, ErrUnknownEvent
+		}
+		e, err = DecodeWithdrawal(log)
+	}
+	if errors.Is(err, ErrInvalidLog) {
+		return nil, ErrUnknownEvent
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid %s log; %w", t, err)
+	}
+	return e, nil
+}
+
+// DecodeLogs decodes each supported log, skipping the rest, with the Deposit and Withdrawal logs of weth.
+func DecodeLogs(logs []*types.Log, weth common.Address) ([]Event, error) {
+	var events []Event
+	for _, log := range logs {
+		e, err := Decode(log, weth)
+		if errors.Is(err, ErrUnknownEvent) {
+			continue
+		} else if err != nil {
+			return events, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// requireLog returns ErrInvalidLog when log doesn't hold the topic count and data length of its event.
+func requireLog(log *types.Log, topics, n int) error {
+	if len(log.Topics) != topics || len(log.Data) < n {
+		return fmt.Errorf("%w; expected %d topics and %d bytes but got %d and %d", ErrInvalidLog, topics, n, len(log.Topics), len(log.Data))
+	}
+	return nil
+}
+
+func word(b []byte, i int) []byte {
+	return b[i*0x20 : (i+1)*0x20]
+}
+
+// V2Swap Solidity representation
+//
+// see: v2-core/contracts/interfaces/IUniswapV2Pair.sol
+type V2Swap struct {
+	Pair       common.Address `json:"pair"`
+	Sender     common.Address `json:"sender"`
+	Amount0In  *big.Int       `json:"amount0In"`
+	Amount1In  *big.Int       `json:"amount1In"`
+	Amount0Out *big.Int       `json:"amount0Out"`
+	Amount1Out *big.Int       `json:"amount1Out"`
+	To         common.Address `json:"to"`
+}
+
+func (V2Swap) Type() Type {
+	return V2_SWAP
+}
+
+func (s V2Swap) MarshalJSON() ([]byte, error) {
+	type Alias V2Swap
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(s), V2_SWAP.String()})
+}
+
+func DecodeV2Swap(log *types.Log) (V2Swap, error) {
+	var s V2Swap
+	if err := requireLog(log, 3, 0x80); err != nil {
+		return s, err
+	}
+
+	s = V2Swap{
+		Pair:       log.Address,
+		Sender:     common.BytesToAddress(log.Topics[1].Bytes()),
+		Amount0In:  new(big.Int).SetBytes(word(log.Data, 0)),
+		Amount1In:  new(big.Int).SetBytes(word(log.Data, 1)),
+		Amount0Out: new(big.Int).SetBytes(word(log.Data, 2)),
+		Amount1Out: new(big.Int).SetBytes(word(log.Data, 3)),
+		To:         common.BytesToAddress(log.Topics[2].Bytes()),
+	}
+	return s, nil
+}
+
+// V3Swap Solidity representation, where positive amounts are received by the pool.
+//
+// see: v3-core/contracts/interfaces/pool/IUniswapV3PoolEvents.sol
+type V3Swap struct {
+	Pool         common.Address `json:"pool"`
+	Sender       common.Address `json:"sender"`
+	Recipient    common.Address `json:"recipient"`
+	Amount0      *big.Int       `json:"amount0"`
+	Amount1      *big.Int       `json:"amount1"`
+	SqrtPriceX96 *big.Int       `json:"sqrtPriceX96"`
+	Liquidity    *big.Int       `json:"liquidity"`
+	Tick         *big.Int       `json:"tick"`
+}
+
+func (V3Swap) Type() Type {
+	return V3_SWAP
+}
+
+func (s V3Swap) MarshalJSON() ([]byte, error) {
+	type Alias V3Swap
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(s), V3_SWAP.String()})
+}
+
+func DecodeV3Swap(log *types.Log) (V3Swap, error) {
+	var s V3Swap
+	if err := requireLog(log, 3, 0xa0); err != nil {
+		return s, err
+	}
+
+	s = V3Swap{
+		Pool:         log.Address,
+		Sender:       common.BytesToAddress(log.Topics[1].Bytes()),
+		Recipient:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Amount0:      hex.SignedInt(word(log.Data, 0)),
+		Amount1:      hex.SignedInt(word(log.Data, 1)),
+		SqrtPriceX96: new(big.Int).SetBytes(word(log.Data, 2)),
+		Liquidity:    new(big.Int).SetBytes(word(log.Data, 3)),
+		Tick:         hex.SignedInt(word(log.Data, 4)),
+	}
+	return s, nil
+}
+
+// V4Swap Solidity representation, where negative amounts are paid by the swapper.
+//
+// see: v4-core/src/interfaces/IPoolManager.sol
+type V4Swap struct {
+	PoolManager  common.Address `json:"poolManager"`
+	ID           common.Hash    `json:"id"`
+	Sender       common.Address `json:"sender"`
+	Amount0      *big.Int       `json:"amount0"`
+	Amount1      *big.Int       `json:"amount1"`
+	SqrtPriceX96 *big.Int       `json:"sqrtPriceX96"`
+	Liquidity    *big.Int       `json:"liquidity"`
+	Tick         *big.Int       `json:"tick"`
+	Fee          *big.Int       `json:"fee"`
+}
+
+func (V4Swap) Type() Type {
+	return V4_SWAP
+}
+
+func (s V4Swap) MarshalJSON() ([]byte, error) {
+	type Alias V4Swap
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(s), V4_SWAP.String()})
+}
+
+func DecodeV4Swap(log *types.Log) (V4Swap, error) {
+	var s V4Swap
+	if err := requireLog(log, 3, 0xc0); err != nil {
+		return s, err
+	}
+
+	s = V4Swap{
+		PoolManager:  log.Address,
+		ID:           log.Topics[1],
+		Sender:       common.BytesToAddress(log.Topics[2].Bytes()),
+		Amount0:      hex.SignedInt(word(log.Data, 0)),
+		Amount1:      hex.SignedInt(word(log.Data, 1)),
+		SqrtPriceX96: new(big.Int).SetBytes(word(log.Data, 2)),
+		Liquidity:    new(big.Int).SetBytes(word(log.Data, 3)),
+		Tick:         hex.SignedInt(word(log.Data, 4)),
+		Fee:          new(big.Int).SetBytes(word(log.Data, 5)),
+	}
+	return s, nil
+}
+
+// V4ModifyLiquidity Solidity representation
+//
+// see: v4-core/src/interfaces/IPoolManager.sol
+type V4ModifyLiquidity struct {
+	PoolManager    common.Address `json:"poolManager"`
+	ID             common.Hash    `json:"id"`
+	Sender         common.Address `json:"sender"`
+	TickLower      *big.Int       `json:"tickLower"`
+	TickUpper      *big.Int       `json:"tickUpper"`
+	LiquidityDelta *big.Int       `json:"liquidityDelta"`
+	Salt           common.Hash    `json:"salt"`
+}
+
+func (V4ModifyLiquidity) Type() Type {
+	return V4_MODIFY_LIQUIDITY
+}
+
+func (m V4ModifyLiquidity) MarshalJSON() ([]byte, error) {
+	type Alias V4ModifyLiquidity
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(m), V4_MODIFY_LIQUIDITY.String()})
+}
+
+func DecodeV4ModifyLiquidity(log *types.Log) (V4ModifyLiquidity, error) {
+	var m V4ModifyLiquidity
+	if err := requireLog(log, 3, 0x80); err != nil {
+		return m, err
+	}
+
+	m = V4ModifyLiquidity{
+		PoolManager:    log.Address,
+		ID:             log.Topics[1],
+		Sender:         common.BytesToAddress(log.Topics[2].Bytes()),
+		TickLower:      hex.SignedInt(word(log.Data, 0)),
+		TickUpper:      hex.SignedInt(word(log.Data, 1)),
+		LiquidityDelta: hex.SignedInt(word(log.Data, 2)),
+		Salt:           common.BytesToHash(word(log.Data, 3)),
+	}
+	return m, nil
+}
+
+// V4Initialize Solidity representation
+//
+// see: v4-core/src/interfaces/IPoolManager.sol
+type V4Initialize struct {
+	PoolManager  common.Address `json:"poolManager"`
+	ID           common.Hash    `json:"id"`
+	Currency0    common.Address `json:"currency0"`
+	Currency1    common.Address `json:"currency1"`
+	Fee          *big.Int       `json:"fee"`
+	TickSpacing  *big.Int       `json:"tickSpacing"`
+	Hooks        common.Address `json:"hooks"`
+	SqrtPriceX96 *big.Int       `json:"sqrtPriceX96"`
+	Tick         *big.Int       `json:"tick"`
+}
+
+func (V4Initialize) Type() Type {
+	return V4_INITIALIZE
+}
+
+func (i V4Initialize) MarshalJSON() ([]byte, error) {
+	type Alias V4Initialize
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(i), V4_INITIALIZE.String()})
+}
+
+func DecodeV4Initialize(log *types.Log) (V4Initialize, error) {
+	var i V4Initialize
+	if err := requireLog(log, 4, 0xa0); err != nil {
+		return i, err
+	}
+
+	i = V4Initialize{
+		PoolManager:  log.Address,
+		ID:           log.Topics[1],
+		Currency0:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Currency1:    common.BytesToAddress(log.Topics[3].Bytes()),
+		Fee:          new(big.Int).SetBytes(word(log.Data, 0)),
+		TickSpacing:  hex.SignedInt(word(log.Data, 1)),
+		Hooks:        common.BytesToAddress(word(log.Data, 2)),
+		SqrtPriceX96: new(big.Int).SetBytes(word(log.Data, 3)),
+		Tick:         hex.SignedInt(word(log.Data, 4)),
+	}
+	return i, nil
+}
+
+// Transfer is an ERC-20 Transfer.
+type Transfer struct {
+	Token common.Address `json:"token"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *big.Int       `json:"value"`
+}
+
+func (Transfer) Type() Type {
+	return TRANSFER
+}
+
+func (t Transfer) MarshalJSON() ([]byte, error) {
+	type Alias Transfer
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(t), TRANSFER.String()})
+}
+
+func DecodeTransfer(log *types.Log) (Transfer, error) {
+	var t Transfer
+	if err := requireLog(log, 3, 0x20); err != nil {
+		return t, err
+	}
+
+	t = Transfer{
+		Token: log.Address,
+		From:  common.BytesToAddress(log.Topics[1].Bytes()),
+		To:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Value: new(big.Int).SetBytes(word(log.Data, 0)),
+	}
+	return t, nil
+}
+
+// Deposit is a WETH9 Deposit, wrapping ETH.
+type Deposit struct {
+	Token common.Address `json:"token"`
+	Dst   common.Address `json:"dst"`
+	Wad   *big.Int       `json:"wad"`
+}
+
+func (Deposit) Type() Type {
+	return DEPOSIT
+}
+
+func (d Deposit) MarshalJSON() ([]byte, error) {
+	type Alias Deposit
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(d), DEPOSIT.String()})
+}
+
+func DecodeDeposit(log *types.Log) (Deposit, error) {
+	var d Deposit
+	if err := requireLog(log, 2, 0x20); err != nil {
+		return d, err
+	}
+
+	d = Deposit{
+		Token: log.Address,
+		Dst:   common.BytesToAddress(log.Topics[1].Bytes()),
+		Wad:   new(big.Int).SetBytes(word(log.Data, 0)),
+	}
+	return d, nil
+}
+
+// Withdrawal is a WETH9 Withdrawal, unwrapping ETH.
+type Withdrawal struct {
+	Token common.Address `json:"token"`
+	Src   common.Address `json:"src"`
+	Wad   *big.Int       `json:"wad"`
+}
+
+func (Withdrawal) Type() Type {
+	return WITHDRAWAL
+}
+
+func (w Withdrawal) MarshalJSON() ([]byte, error) {
+	type Alias Withdrawal
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(w), WITHDRAWAL.String()})
+}
+
+func DecodeWithdrawal(log *types.Log) (Withdrawal, error) {
+	var w Withdrawal
+	if err := requireLog(log, 2, 0x20); err != nil {
+		return w, err
+	}
+
+	w = Withdrawal{
+		Token: log.Address,
+		Src:   common.BytesToAddress(log.Topics[1].Bytes()),
+		Wad:   new(big.Int).SetBytes(word(log.Data, 0)),
+	}
+	return w, nil
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Type identifies a decoded event by its first topic, the keccak of its signature.
+type Type int
+
+const (
+	V2_SWAP             Type = iota // UniswapV2Pair
+	V3_SWAP                         // UniswapV3Pool
+	V4_SWAP                         // PoolManager
+	V4_MODIFY_LIQUIDITY             // PoolManager
+	V4_INITIALIZE                   // PoolManager
+	TRANSFER                        // ERC-20
+	DEPOSIT                         // WETH9
+	WITHDRAWAL                      // WETH9
+)
+
+var signatures = map[Type]string{
+	V2_SWAP:             "Swap(address,uint256,uint256,uint256,uint256,address)",
+	V3_SWAP:             "Swap(address,address,int256,int256,uint160,uint128,int24)",
+	V4_SWAP:             "Swap(bytes32,address,int128,int128,uint160,uint128,int24,uint24)",
+	V4_MODIFY_LIQUIDITY: "ModifyLiquidity(bytes32,address,int24,int24,int256,bytes32)",
+	V4_INITIALIZE:       "Initialize(bytes32,address,address,uint24,int24,address,uint160,int24)",
+	TRANSFER:            "Transfer(address,address,uint256)",
+	DEPOSIT:             "Deposit(address,uint256)",
+	WITHDRAWAL:          "Withdrawal(address,uint256)",
+}
+
+var topics = func() map[common.Hash]Type {
+	m := make(map[common.Hash]Type, len(signatures))
+	for t, sig := range signatures {
+		m[crypto.Keccak256Hash([]byte(sig))] = t
+	}
+	return m
+}()
+
+func (t Type) String() string {
+	switch t {
+	case V2_SWAP:
+		return "V2_SWAP"
+	case V3_SWAP:
+		return "V3_SWAP"
+	case V4_SWAP:
+		return "V4_SWAP"
+	case V4_MODIFY_LIQUIDITY:
+		return "V4_MODIFY_LIQUIDITY"
+	case V4_INITIALIZE:
+		return "V4_INITIALIZE"
+	case TRANSFER:
+		return "TRANSFER"
+	case DEPOSIT:
+		return "DEPOSIT"
+	case WITHDRAWAL:
+		return "WITHDRAWAL"
+	}
+	return "UNKNOWN"
+}
+
+// Topic returns the event's signature hash.
+func (t Type) Topic() common.Hash {
+	return crypto.Keccak256Hash([]byte(signatures[t]))
+}
+
+func Parse(topic common.Hash) (Type, error) {
+	t, ok := topics[topic]
+	if !ok {
+		return 0, fmt.Errorf("%w %s", ErrUnknownEvent, topic)
+	}
+	return t, nil
+}
@@ -0,0 +1,43 @@
+// Package field describes decoded values as ordered, named fields, so any command, action, Permit2 call or UniswapX
+// order can be rendered without knowing its type.
+package field
+
+// Field is a named argument of a decoded value.
+type Field struct {
+	Name string
+	// Value is a common.Address, common.Hash, *big.Int, []byte, bool, integer, string, time.Time or fmt.Stringer, or
+	// nil when the field only groups its Fields or Items.
+	Value interface{}
+	// Fields are the nested fields of a struct value, such as the currencies of a pool key.
+	Fields []Field
+	// Items are the elements of a list, each as an unnamed field holding a Value, Fields, or both.
+	Items []Field
+}
+
+// Fielder is implemented by decoded values, returning their fields in the order of their Solidity arguments.
+type Fielder interface {
+	Fields() []Field
+}
+
+// Struct returns the field name, holding the fields of v.
+func Struct(name string, v Fielder) Field {
+	return Field{Name: name, Fields: v.Fields()}
+}
+
+// List returns the field name, holding an item with the fields of each element of vs.
+func List[T Fielder](name string, vs []T) Field {
+	f := Field{Name: name, Items: make([]Field, len(vs))}
+	for i, v := range vs {
+		f.Items[i] = Field{Fields: v.Fields()}
+	}
+	return f
+}
+
+// Values returns the field name, holding an item with the value of each element of vs.
+func Values[T any](name string, vs []T) Field {
+	f := Field{Name: name, Items: make([]Field, len(vs))}
+	for i, v := range vs {
+		f.Items[i] = Field{Value: v}
+	}
+	return f
+}
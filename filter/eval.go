@@ -0,0 +1,208 @@
+package filter
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/field"
+)
+
+// scope is a command, and one of its actions when it has any, which an expression is evaluated against.
+type scope struct {
+	command commands.Command
+	action  actions.Action
+}
+
+type expr interface {
+	eval(s scope) bool
+}
+
+type orExpr struct{ x, y expr }
+
+func (e orExpr) eval(s scope) bool { return e.x.eval(s) || e.y.eval(s) }
+
+type andExpr struct{ x, y expr }
+
+func (e andExpr) eval(s scope) bool { return e.x.eval(s) && e.y.eval(s) }
+
+type notExpr struct{ x expr }
+
+func (e notExpr) eval(s scope) bool { return !e.x.eval(s) }
+
+// truthExpr is an operand used as a condition, true when it's the boolean true.
+type truthExpr struct{ x operand }
+
+func (e truthExpr) eval(s scope) bool {
+	v, ok := e.x.value(s)
+	return ok && v.num == nil && v.str == "true"
+}
+
+// compareExpr compares two operands, which is false when either is missing.
+type compareExpr struct {
+	op   string
+	x, y operand
+}
+
+func (e compareExpr) eval(s scope) bool {
+	x, ok := e.x.value(s)
+	if !ok {
+		return false
+	}
+	y, ok := e.y.value(s)
+	if !ok {
+		return false
+	}
+
+	switch e.op {
+	case "==":
+		return x.equal(y)
+	case "!=":
+		return !x.equal(y)
+	}
+	// Ordering only applies to numbers
+	if x.num == nil || y.num == nil {
+		return false
+	}
+	c := x.num.Cmp(y.num)
+	switch e.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// literal is a value, holding a number when it's numeric, such as amounts, addresses and hex, along with its text.
+type literal struct {
+	num *big.Rat
+	str string
+}
+
+// equal compares numerically when both are numbers, otherwise comparing text regardless of case.
+func (l literal) equal(o literal) bool {
+	if l.num != nil && o.num != nil {
+		return l.num.Cmp(o.num) == 0
+	}
+	return strings.EqualFold(l.str, o.str)
+}
+
+type operand interface {
+	// value returns the value of the operand, and false when it's missing from s.
+	value(s scope) (literal, bool)
+}
+
+func (l literal) value(scope) (literal, bool) {
+	return l, true
+}
+
+// step is a field name of a selector, along with an item index when it's not -1.
+type step struct {
+	name  string
+	index int
+}
+
+// selector is a field of the action or command of a scope. Selectors starting with `command` or `action` select
+// the type, or fields, of the command or action; otherwise the field of the action is selected, falling back to the
+// field of the command.
+type selector struct {
+	steps []step
+}
+
+func (sel selector) value(s scope) (literal, bool) {
+	first, rest := sel.steps[0], sel.steps[1:]
+	if first.index < 0 {
+		switch strings.ToLower(first.name) {
+		case "command":
+			if len(rest) == 0 {
+				return literal{str: s.command.Type().String()}, true
+			}
+			return lookup(s.command.Fields(), rest)
+		case "action":
+			if s.action == nil {
+				return literal{}, false
+			} else if len(rest) == 0 {
+				return literal{str: s.action.Type().String()}, true
+			}
+			return lookup(s.action.Fields(), rest)
+		}
+	}
+	if s.action != nil {
+		if v, ok := lookup(s.action.Fields(), sel.steps); ok {
+			return v, true
+		}
+	}
+	return lookup(s.command.Fields(), sel.steps)
+}
+
+// lookup returns the value of the field selected by steps, matching names regardless of case.
+func lookup(fields []field.Field, steps []step) (literal, bool) {
+	for _, f := range fields {
+		if !strings.EqualFold(f.Name, steps[0].name) {
+			continue
+		}
+		if steps[0].index >= 0 {
+			if steps[0].index >= len(f.Items) {
+				return literal{}, false
+			}
+			f = f.Items[steps[0].index]
+		}
+		if len(steps) > 1 {
+			return lookup(f.Fields, steps[1:])
+		}
+		return toLiteral(f.Value)
+	}
+	return literal{}, false
+}
+
+// toLiteral converts a field value, where times are numbers of seconds since the epoch, and zero times are missing.
+func toLiteral(v interface{}) (literal, bool) {
+	switch v := v.(type) {
+	case nil:
+		return literal{}, false
+	case *big.Int:
+		if v == nil {
+			return literal{}, false
+		}
+		return literal{num: new(big.Rat).SetInt(v), str: v.String()}, true
+	case *hexutil.Big:
+		return toLiteral(v.ToInt())
+	case common.Address:
+		return bytesLiteral(v.Bytes()), true
+	case common.Hash:
+		return bytesLiteral(v.Bytes()), true
+	case []byte:
+		return bytesLiteral(v), true
+	case bool:
+		return literal{str: fmt.Sprint(v)}, true
+	case time.Time:
+		if v.IsZero() {
+			return literal{}, false
+		}
+		return toLiteral(big.NewInt(v.Unix()))
+	case string:
+		return literal{str: v}, true
+	}
+
+	switch r := reflect.ValueOf(v); r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return toLiteral(big.NewInt(r.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return toLiteral(new(big.Int).SetUint64(r.Uint()))
+	}
+	return literal{str: fmt.Sprint(v)}, true
+}
+
+func bytesLiteral(b []byte) literal {
+	return literal{num: new(big.Rat).SetInt(new(big.Int).SetBytes(b)), str: hexutil.Encode(b)}
+}
@@ -0,0 +1,89 @@
+// Package filter evaluates filter expressions against decoded Universal Router calls, such as
+//
+//	command == V4_SWAP && action.currencyIn == 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 && amountIn > 1e18
+//
+// An expression compares selectors, or literals, with ==, !=, <, <=, > and >=, and combines comparisons with &&, ||
+// and !, grouped by parentheses.
+//
+// Selectors name the fields of a command or action, by the names of their JSON, regardless of case. `command` and
+// `action` select the type of the command and action, and `command.<field>` or `action.<field>` their fields, while
+// a bare field selects the field of the action, falling back to the command. Nested fields and list items are
+// selected by steps, such as poolKey.currency0 or path[1].fee.
+//
+// Literals are decimal numbers, such as 1e18 or 0.5, hex numbers and addresses, true, false, quoted strings and types
+// such as V4_SWAP, which are names in upper case or holding an underscore. Numbers, addresses and hex compare
+// numerically, and any other values by their text regardless of case; only numbers are ordered. Times compare as
+// seconds since the epoch. A comparison with a field which is missing is false, and a selector alone is true when
+// it's the boolean true.
+package filter
+
+import (
+	"fmt"
+
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+)
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	src  string
+	expr expr
+}
+
+// Parse parses the filter expression s.
+func Parse(s string) (*Filter, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter; %w", err)
+	}
+	p := parser{tokens: tokens}
+	x, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter; %w", err)
+	}
+	if t := p.next(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid filter; %w", p.unexpected(t))
+	}
+	return &Filter{s, x}, nil
+}
+
+// String returns the expression of f.
+func (f *Filter) String() string {
+	return f.src
+}
+
+// Match reports whether the expression of f holds for any action of the commands of e, or any command without
+// actions. Sub-plans are matched as commands, along with their commands.
+func (f *Filter) Match(e unidecode.Execute) bool {
+	var (
+		matched bool
+		cmd     commands.Command
+	)
+	unidecode.Walk(e, func(_ unidecode.Path, node interface{}) error {
+		switch n := node.(type) {
+		case commands.ExecuteSubPlan:
+			// Commands of the sub-plan are matched when the sub-plan itself isn't
+			if f.expr.eval(scope{command: n}) {
+				matched = true
+				return unidecode.SkipAll
+			}
+			return nil
+		case commands.Command:
+			cmd = n
+			if len(n.Actions()) > 0 {
+				return nil
+			}
+			matched = f.expr.eval(scope{command: n})
+		case actions.Action:
+			matched = f.expr.eval(scope{command: cmd, action: n})
+		default:
+			return nil
+		}
+		if matched {
+			return unidecode.SkipAll
+		}
+		return unidecode.SkipChildren
+	})
+	return matched
+}
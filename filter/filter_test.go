@@ -0,0 +1,112 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/juztin/unidecode"
+)
+
+// swap is a V4 swap of 1 ETH for at least 3000 USDC.
+const swap = `{"commands": [{"type": "V4_SWAP", "actions": [
+	{"type": "SWAP_EXACT_IN_SINGLE", "poolKey": {"currency0": "0x0000000000000000000000000000000000000000",
+		"currency1": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "fee": "500", "tickSpacing": "10",
+		"hooks": "0x0000000000000000000000000000000000000000"},
+		"zeroForOne": true, "amountIn": "1000000000000000000", "amountOutMinimum": "3000000000", "hookData": "0x00"},
+	{"type": "SETTLE_ALL", "currency": "0x0000000000000000000000000000000000000000", "maxAmount": "1000000000000000000"},
+	{"type": "TAKE_ALL", "currency": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "minAmount": "3000000000"}
+]}]}`
+
+// plan wraps 1 ETH, then sweeps USDC within a sub-plan.
+const plan = `{"commands": [
+	{"type": "WRAP_ETH", "recipient": "0x0000000000000000000000000000000000000002", "amountMin": "1000000000000000000"},
+	{"type": "EXECUTE_SUB_PLAN", "commands": [
+		{"type": "SWEEP", "token": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+			"recipient": "0x0000000000000000000000000000000000000001", "amountMin": "5"}
+	]}
+]}`
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		execute string
+		filter  string
+		want    bool
+	}{
+		{"command type", swap, "command == V4_SWAP", true},
+		{"command type string", swap, `command == "v4_swap"`, true},
+		{"action type", swap, "action == TAKE_ALL", true},
+		{"missing action type", swap, "action == SWEEP", false},
+
+		// && binds tighter than ||
+		{"and before or", plan, "command == WRAP_ETH || command == SWEEP && amountMin == 7", true},
+		{"parenthesized or", plan, "(command == WRAP_ETH || command == SWEEP) && amountMin == 7", false},
+		{"or of ands", swap, "action == TAKE_ALL && minAmount == 1 || action == SETTLE_ALL", true},
+
+		{"not", swap, "!(command == V4_SWAP)", false},
+		{"not of one action", swap, "!(action == SETTLE_ALL)", true},
+		{"not of boolean", swap, "action == SWAP_EXACT_IN_SINGLE && !zeroForOne", false},
+		{"boolean selector", swap, "zeroForOne", true},
+		{"double not", swap, "action == SWAP_EXACT_IN_SINGLE && !!zeroForOne", true},
+
+		// Comparisons with missing fields are false, whatever the operator
+		{"missing field equal", swap, "action == SETTLE_ALL && poolKey.fee == 500", false},
+		{"missing field not equal", swap, "action == SETTLE_ALL && poolKey.fee != 500", false},
+		{"missing field ordered", swap, "action == SETTLE_ALL && amountIn > 0", false},
+		{"not of missing field", swap, "action == SETTLE_ALL && !(poolKey.fee == 500)", true},
+		{"missing item", swap, "path[5].fee > 0", false},
+		{"missing command field", swap, "command.recipient == 0x2", false},
+
+		// Addresses and hex compare numerically, regardless of case and padding
+		{"zero address", swap, "action == SETTLE_ALL && currency == 0x0", true},
+		{"zero address decimal", swap, "action == SETTLE_ALL && currency == 0", true},
+		{"mixed case address", swap, "poolKey.currency1 == 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", true},
+		{"address selector case", swap, "POOLKEY.CURRENCY1 == 0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", true},
+		{"hex number", swap, "poolKey.fee == 0x1f4", true},
+		{"padded hex", swap, "hookData == 0x0000", true},
+		{"command field of action", plan, "command == WRAP_ETH && recipient == 0x02", true},
+
+		// Decimal literals may have exponents and fractions
+		{"exponent", swap, "amountIn == 1e18", true},
+		{"fractional exponent", swap, "amountIn >= 1.5e18", false},
+		{"decimal", swap, "maxAmount == 1000000000000000000", true},
+		{"ordered exponent", swap, "amountOutMinimum > 2.9e9 && amountOutMinimum <= 3e9", true},
+		{"fraction", swap, "minAmount < 3000000000.5", true},
+
+		{"sub-plan command", plan, "command == SWEEP && recipient == 0x1", true},
+		{"sub-plan", plan, "command == EXECUTE_SUB_PLAN", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e unidecode.Execute
+			if err := json.Unmarshal([]byte(tt.execute), &e); err != nil {
+				t.Fatal(err)
+			}
+			f, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Match(e); got != tt.want {
+				t.Errorf("%s = %t, want %t", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"amountIn >",
+		"(command == V4_SWAP",
+		"command == V4_SWAP)",
+		"command = V4_SWAP",
+		`command == "V4_SWAP`,
+		"command == V4_SWAP &&",
+		"amountIn > 1e",
+		"path[x].fee > 0",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", s)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package filter
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenHex
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return strconv.Quote(t.text)
+}
+
+// ops are the operators of a filter, longest first so "<=" isn't read as "<".
+var ops = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!"}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// lex splits s into tokens, ending with tokenEOF.
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", start})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", start})
+			i++
+			continue
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at offset %d", start)
+			}
+			tokens = append(tokens, token{tokenString, s[i+1 : i+1+end], start})
+			i += end + 2
+			continue
+		case c == '0' && i+1 < len(s) && (s[i+1] == 'x' || s[i+1] == 'X'):
+			i += 2
+			for i < len(s) && strings.IndexByte("0123456789abcdefABCDEF", s[i]) >= 0 {
+				i++
+			}
+			tokens = append(tokens, token{tokenHex, s[start:i], start})
+			continue
+		case isDigit(c) || c == '-' && i+1 < len(s) && isDigit(s[i+1]):
+			i++
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.' || s[i] == 'e' || s[i] == 'E' ||
+				(s[i] == '+' || s[i] == '-') && (s[i-1] == 'e' || s[i-1] == 'E')) {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, s[start:i], start})
+			continue
+		case isIdentStart(c):
+			// Selectors hold their steps, such as action.path[1].fee
+			for i < len(s) && (isIdentStart(s[i]) || isDigit(s[i]) || strings.IndexByte(".[]", s[i]) >= 0) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, s[start:i], start})
+			continue
+		}
+
+		op := ""
+		for _, o := range ops {
+			if strings.HasPrefix(s[i:], o) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, start)
+		}
+		tokens = append(tokens, token{tokenOp, op, start})
+		i += len(op)
+	}
+	return append(tokens, token{tokenEOF, "", len(s)}), nil
+}
+
+// parser is a recursive descent parser of the tokens of a filter.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	return fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokenOp && t.text == op
+}
+
+// or parses or := and ("||" and)*
+func (p *parser) or() (expr, error) {
+	x, err := p.and()
+	for err == nil && p.isOp("||") {
+		p.next()
+		var y expr
+		if y, err = p.and(); err == nil {
+			x = orExpr{x, y}
+		}
+	}
+	return x, err
+}
+
+// and parses and := unary ("&&" unary)*
+func (p *parser) and() (expr, error) {
+	x, err := p.unary()
+	for err == nil && p.isOp("&&") {
+		p.next()
+		var y expr
+		if y, err = p.unary(); err == nil {
+			x = andExpr{x, y}
+		}
+	}
+	return x, err
+}
+
+// unary parses unary := "!" unary | "(" or ")" | comparison
+func (p *parser) unary() (expr, error) {
+	if p.isOp("!") {
+		p.next()
+		x, err := p.unary()
+		return notExpr{x}, err
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, p.unexpected(t)
+		}
+		return x, nil
+	}
+	return p.comparison()
+}
+
+// comparison parses comparison := operand (("==" | "!=" | "<" | "<=" | ">" | ">=") operand)?
+func (p *parser) comparison() (expr, error) {
+	x, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != tokenOp {
+		return truthExpr{x}, nil
+	}
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		y, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{t.text, x, y}, nil
+	}
+	return truthExpr{x}, nil
+}
+
+// operand parses a selector or literal.
+func (p *parser) operand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		r, ok := new(big.Rat).SetString(t.text)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %s at offset %d", t, t.pos)
+		}
+		return literal{num: r, str: t.text}, nil
+	case tokenHex:
+		return hexLiteral(t)
+	case tokenString:
+		return literal{str: t.text}, nil
+	case tokenIdent:
+		if t.text == "true" || t.text == "false" || isType(t.text) {
+			return literal{str: t.text}, nil
+		}
+		return parseSelector(t)
+	}
+	return nil, p.unexpected(t)
+}
+
+// isType reports whether the name is a command or action type, such as V4_SWAP or settle_all, rather than a selector.
+func isType(name string) bool {
+	if strings.ContainsAny(name, ".[]") {
+		return false
+	}
+	return strings.ToUpper(name) == name || strings.Contains(name, "_")
+}
+
+func hexLiteral(t token) (literal, error) {
+	digits := t.text[2:]
+	n, ok := new(big.Int).SetString(digits, 16)
+	if digits == "" {
+		n, ok = new(big.Int), true
+	}
+	if !ok {
+		return literal{}, fmt.Errorf("invalid hex %s at offset %d", t, t.pos)
+	}
+	return literal{num: new(big.Rat).SetInt(n), str: strings.ToLower(t.text)}, nil
+}
+
+// parseSelector parses the steps of a selector, such as poolKey.currency0 or path[1].fee.
+func parseSelector(t token) (selector, error) {
+	var sel selector
+	for _, s := range strings.Split(t.text, ".") {
+		st := step{index: -1}
+		if i := strings.IndexByte(s, '['); i >= 0 {
+			if !strings.HasSuffix(s, "]") {
+				return sel, fmt.Errorf("invalid selector %s at offset %d", t, t.pos)
+			}
+			n, err := strconv.Atoi(s[i+1 : len(s)-1])
+			if err != nil || n < 0 {
+				return sel, fmt.Errorf("invalid index in selector %s at offset %d", t, t.pos)
+			}
+			s, st.index = s[:i], n
+		}
+		if s == "" || strings.ContainsAny(s, "[]") {
+			return sel, fmt.Errorf("invalid selector %s at offset %d", t, t.pos)
+		}
+		st.name = s
+		sel.steps = append(sel.steps, st)
+	}
+	return sel, nil
+}
@@ -2,11 +2,13 @@ package hex
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+	"time"
 )
 
 func MethodSig(calldata []byte) []byte {
-	if len(calldata) <= 4 {
+	if len(calldata) < 4 {
 		return nil
 	}
 	return calldata[:0x04]
@@ -31,3 +33,73 @@ func Bool(b []byte) (bool, error) {
 	}
 	return i == 1, nil
 }
+
+// maxTime is the latest time RFC 3339, and so JSON, can represent.
+var maxTime = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+// Time interprets b as a unix epoch, such as a deadline, capped at maxTime as deadlines are often
+// `type(uint256).max`.
+func Time(b []byte) time.Time {
+	epoch := new(big.Int).SetBytes(b)
+	if !epoch.IsInt64() || epoch.Int64() > maxTime.Unix() {
+		return time.Unix(maxTime.Unix(), 0)
+	}
+	return time.Unix(epoch.Int64(), 0)
+}
+
+// SignedInt64 interprets b as a big-endian two's complement integer, such as an ABI encoded int24.
+func SignedInt64(b []byte) int64 {
+	return SignedInt(b).Int64()
+}
+
+// SignedInt interprets b as a big-endian two's complement integer, such as an ABI encoded int256.
+func SignedInt(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return i
+}
+
+// Bytes returns the `bytes` value whose location, relative to start, is stored at start+head.
+func Bytes(calldata []byte, start, head int) ([]byte, error) {
+	if start < 0 || head < 0 || start > len(calldata)-0x20 || head > len(calldata)-start-0x20 {
+		return nil, fmt.Errorf("bytes location exceeds calldata bounds")
+	}
+	loc, err := Int(calldata[start+head : start+head+0x20])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytes start value; %w", err)
+	} else if loc > len(calldata)-start-0x20 {
+		return nil, fmt.Errorf("bytes length exceeds calldata bounds")
+	}
+	loc += start
+	length, err := Int(calldata[loc : loc+0x20])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytes length; %w", err)
+	} else if length > len(calldata)-loc-0x20 {
+		return nil, fmt.Errorf("bytes length %d exceeds calldata bounds", length)
+	}
+	return calldata[loc+0x20 : loc+0x20+length], nil
+}
+
+// Array returns the location of the first element, and the element count, of the array whose location, relative to
+// start, is stored at start+head. Each element is expected to be size bytes.
+func Array(calldata []byte, start, head, size int) (int, int, error) {
+	if start < 0 || head < 0 || start > len(calldata)-0x20 || head > len(calldata)-start-0x20 {
+		return 0, 0, fmt.Errorf("array location exceeds calldata bounds")
+	}
+	loc, err := Int(calldata[start+head : start+head+0x20])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid array start value; %w", err)
+	} else if loc > len(calldata)-start-0x20 {
+		return 0, 0, fmt.Errorf("array length exceeds calldata bounds")
+	}
+	loc += start
+	count, err := Int(calldata[loc : loc+0x20])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid array length; %w", err)
+	} else if count > (len(calldata)-loc-0x20)/size {
+		return 0, 0, fmt.Errorf("array length %d exceeds calldata bounds", count)
+	}
+	return loc + 0x20, count, nil
+}
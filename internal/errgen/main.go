@@ -0,0 +1,129 @@
+// Command errgen generates the custom error table from the bundled contract ABIs.
+//
+// Usage:
+//
+//	go run ./internal/errgen -abi abi -o errortable.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type entry struct {
+	Name      string
+	Signature string
+	Contracts []string
+}
+
+// abiError is the raw ABI JSON error fragment, used to verify the signature go-ethereum derives.
+type abiError struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Inputs []struct {
+		Type string `json:"type"`
+	} `json:"inputs"`
+}
+
+func main() {
+	dir := flag.String("abi", "abi", "directory of contract ABI JSON files")
+	out := flag.String("o", "errortable.go", "output file")
+	pkg := flag.String("pkg", "unidecode", "output package name")
+	flag.Parse()
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(files)
+
+	entries := make(map[string]*entry)
+	for _, file := range files {
+		contract := strings.TrimSuffix(filepath.Base(file), ".json")
+		if err := load(file, contract, entries); err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+	}
+
+	selectors := make([]string, 0, len(entries))
+	for selector := range entries {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/errgen from %s; DO NOT EDIT.\n\n", filepath.ToSlash(*dir))
+	fmt.Fprintf(&buf, "package %s\n\n", *pkg)
+	fmt.Fprintf(&buf, "var errorTable = map[string]ErrorSig{\n")
+	for _, selector := range selectors {
+		e := entries[selector]
+		fmt.Fprintf(&buf, "\t%q: {Name: %q, Signature: %q, Contracts: %#v},\n", selector, e.Name, e.Signature, e.Contracts)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load adds the errors of the ABI file to entries, failing when a selector isn't the keccak of its signature or two
+// signatures collide.
+func load(file, contract string, entries map[string]*entry) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	parsed, err := abi.JSON(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	var fragments []abiError
+	if err := json.Unmarshal(b, &fragments); err != nil {
+		return err
+	}
+
+	for _, f := range fragments {
+		if f.Type != "error" {
+			continue
+		}
+		types := make([]string, len(f.Inputs))
+		for i, input := range f.Inputs {
+			types[i] = input.Type
+		}
+		sig := fmt.Sprintf("%s(%s)", f.Name, strings.Join(types, ","))
+
+		e, ok := parsed.Errors[f.Name]
+		if !ok || e.Sig != sig {
+			return fmt.Errorf("error %s is overloaded or has tuple arguments", sig)
+		}
+		selector := fmt.Sprintf("0x%x", crypto.Keccak256([]byte(sig))[:4])
+		if id := fmt.Sprintf("0x%x", e.ID[:4]); id != selector {
+			return fmt.Errorf("error %s selector %s doesn't match %s", sig, id, selector)
+		}
+
+		if existing, ok := entries[selector]; ok {
+			if existing.Signature != sig {
+				return fmt.Errorf("error %s selector %s collides with %s", sig, selector, existing.Signature)
+			}
+			existing.Contracts = append(existing.Contracts, contract)
+			continue
+		}
+		entries[selector] = &entry{Name: f.Name, Signature: sig, Contracts: []string{contract}}
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+// Package jsonx encodes the decoded types as JSON following the unidecode JSON schema, in which integers held as
+// *big.Int are decimal strings, byte slices are 0x prefixed hex strings, and slices are empty rather than null.
+//
+// Each decoded type implements json.Marshaler by passing itself, as an alias without methods, to Marshal, and
+// json.Unmarshaler likewise through Unmarshal. Values are converted by reflection to and from mirrored types holding
+// the schema's representations, which encoding/json then encodes or decodes.
+package jsonx
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// decimal is a *big.Int encoded as a decimal string.
+type decimal big.Int
+
+func (d *decimal) MarshalText() ([]byte, error) {
+	return (*big.Int)(d).MarshalText()
+}
+
+// UnmarshalJSON accepts both decimal strings and numbers, as written before the schema.
+func (d *decimal) UnmarshalJSON(b []byte) error {
+	if s, err := strconv.Unquote(string(b)); err == nil {
+		b = []byte(s)
+	}
+	if err := (*big.Int)(d).UnmarshalText(b); err != nil {
+		return fmt.Errorf("invalid integer %s", b)
+	}
+	return nil
+}
+
+var (
+	bigIntType          = reflect.TypeOf((*big.Int)(nil))
+	decimalType         = reflect.TypeOf((*decimal)(nil))
+	bytesType           = reflect.TypeOf([]byte(nil))
+	hexBytesType        = reflect.TypeOf(hexutil.Bytes(nil))
+	anyType             = reflect.TypeOf((*interface{})(nil)).Elem()
+	marshalerType       = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Marshal returns the JSON encoding of v following the schema.
+func Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(convert(v))
+}
+
+// MarshalIndent is like Marshal, but indents the output as json.MarshalIndent does.
+func MarshalIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(convert(v), prefix, indent)
+}
+
+// Unmarshal parses JSON encoded by Marshal into the value v points to, replacing it.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	m := mirrorOf(rv.Type().Elem(), true)
+	if !m.changed {
+		return json.Unmarshal(data, v)
+	}
+	mv := reflect.New(m.to)
+	if err := json.Unmarshal(data, mv.Interface()); err != nil {
+		return err
+	}
+	rv.Elem().Set(m.revert(mv.Elem()))
+	return nil
+}
+
+func convert(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if m := mirrorOf(rv.Type(), false); m.changed {
+		return m.convert(rv).Interface()
+	}
+	return v
+}
+
+// field is a field of a struct, as encoding/json encodes it.
+type field struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	tagged    bool
+}
+
+// fields returns the encoded fields of the struct t, in order, promoting those of embedded structs as encoding/json
+// does.
+func fields(t reflect.Type) []field {
+	var all []field
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			idx := append(append([]int{}, index...), i)
+
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				walk(ft, idx)
+				continue
+			} else if !sf.IsExported() {
+				continue
+			}
+
+			f := field{name: name, index: idx, typ: sf.Type, tagged: name != ""}
+			if name == "" {
+				f.name = sf.Name
+			}
+			for _, opt := range strings.Split(opts, ",") {
+				f.omitEmpty = f.omitEmpty || opt == "omitempty"
+			}
+			all = append(all, f)
+		}
+	}
+	walk(t, nil)
+
+	// Of fields sharing a name the shallowest is encoded, or the only tagged one of those equally shallow
+	byName := make(map[string][]int)
+	for i, f := range all {
+		byName[f.name] = append(byName[f.name], i)
+	}
+	keep := make([]bool, len(all))
+	for _, named := range byName {
+		depth := len(all[named[0]].index)
+		for _, i := range named {
+			depth = min(depth, len(all[i].index))
+		}
+		var shallowest, tagged []int
+		for _, i := range named {
+			if len(all[i].index) == depth {
+				shallowest = append(shallowest, i)
+				if all[i].tagged {
+					tagged = append(tagged, i)
+				}
+			}
+		}
+		if len(shallowest) == 1 {
+			keep[shallowest[0]] = true
+		} else if len(tagged) == 1 {
+			keep[tagged[0]] = true
+		}
+	}
+
+	var result []field
+	for i, f := range all {
+		if keep[i] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// mirror is how values of a type are converted for encoding, or from values decoded.
+type mirror struct {
+	from, to reflect.Type
+	// changed is false when values are encoded as they are.
+	changed bool
+	// elem is the mirror of the element type of pointers and containers.
+	elem *mirror
+	// fields, and their mirrors, are those of structs.
+	fields       []field
+	fieldMirrors []*mirror
+}
+
+// mirrorKey identifies the mirror of a type, which differs for decoding as interfaces aren't converted.
+type mirrorKey struct {
+	t        reflect.Type
+	decoding bool
+}
+
+var (
+	mirrorsMu sync.Mutex
+	mirrors   = make(map[mirrorKey]*mirror)
+	building  = make(map[mirrorKey]bool)
+)
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || (t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(iface))
+}
+
+func isMarshaler(t reflect.Type) bool {
+	return implements(t, marshalerType) || implements(t, textMarshalerType)
+}
+
+func isUnmarshaler(t reflect.Type) bool {
+	return implements(t, unmarshalerType) || implements(t, textUnmarshalerType)
+}
+
+func mirrorOf(t reflect.Type, decoding bool) *mirror {
+	mirrorsMu.Lock()
+	defer mirrorsMu.Unlock()
+	return buildMirror(t, decoding)
+}
+
+func buildMirror(t reflect.Type, decoding bool) *mirror {
+	key := mirrorKey{t, decoding}
+	if m, ok := mirrors[key]; ok {
+		return m
+	} else if building[key] {
+		// Recursive types are encoded as they are below their first level, as they can't be mirrored
+		return &mirror{from: t, to: t}
+	}
+	building[key] = true
+	defer delete(building, key)
+
+	m := &mirror{from: t, to: t}
+	defer func() { mirrors[key] = m }()
+
+	switch {
+	case t == bigIntType:
+		m.to, m.changed = decimalType, true
+	case t == bytesType:
+		m.to, m.changed = hexBytesType, true
+	case !decoding && isMarshaler(t), decoding && isUnmarshaler(t):
+	case t.Kind() == reflect.Pointer:
+		if m.elem = buildMirror(t.Elem(), decoding); m.elem.changed {
+			m.to, m.changed = reflect.PointerTo(m.elem.to), true
+		}
+	case t.Kind() == reflect.Slice:
+		// Slices are always converted, so nil slices are encoded as empty
+		m.elem = buildMirror(t.Elem(), decoding)
+		m.to, m.changed = reflect.SliceOf(m.elem.to), true
+	case t.Kind() == reflect.Array:
+		if m.elem = buildMirror(t.Elem(), decoding); m.elem.changed {
+			m.to, m.changed = reflect.ArrayOf(t.Len(), m.elem.to), true
+		}
+	case t.Kind() == reflect.Map:
+		if m.elem = buildMirror(t.Elem(), decoding); m.elem.changed {
+			m.to, m.changed = reflect.MapOf(t.Key(), m.elem.to), true
+		}
+	case t.Kind() == reflect.Interface:
+		// The dynamic value is converted when encoding, while types holding interfaces decode them themselves
+		if !decoding {
+			m.to, m.changed = anyType, true
+		}
+	case t.Kind() == reflect.Struct:
+		m.fields = fields(t)
+		sfs := make([]reflect.StructField, len(m.fields))
+		m.fieldMirrors = make([]*mirror, len(m.fields))
+		for i, f := range m.fields {
+			fm := buildMirror(f.typ, decoding)
+			m.fieldMirrors[i] = fm
+			m.changed = m.changed || fm.changed
+			tag := strconv.Quote(f.name)
+			if f.omitEmpty {
+				tag = strconv.Quote(f.name + ",omitempty")
+			}
+			sfs[i] = reflect.StructField{Name: "F" + strconv.Itoa(i), Type: fm.to, Tag: reflect.StructTag("json:" + tag)}
+		}
+		if m.changed {
+			m.to = reflect.StructOf(sfs)
+		}
+	}
+	return m
+}
+
+// convert returns v, of type m.from, as a value of type m.to.
+func (m *mirror) convert(v reflect.Value) reflect.Value {
+	if !m.changed {
+		return v
+	}
+	if m.from == bigIntType || m.from == bytesType {
+		return v.Convert(m.to)
+	}
+
+	switch m.from.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(m.to)
+		}
+		p := reflect.New(m.to.Elem())
+		p.Elem().Set(m.elem.convert(v.Elem()))
+		return p
+	case reflect.Slice, reflect.Array:
+		n := v.Len()
+		var s reflect.Value
+		if m.from.Kind() == reflect.Slice {
+			s = reflect.MakeSlice(m.to, n, n)
+		} else {
+			s = reflect.New(m.to).Elem()
+		}
+		for i := 0; i < n; i++ {
+			s.Index(i).Set(m.elem.convert(v.Index(i)))
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(m.to)
+		}
+		mv := reflect.MakeMapWithSize(m.to, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			mv.SetMapIndex(it.Key(), m.elem.convert(it.Value()))
+		}
+		return mv
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(anyType)
+		}
+		e := v.Elem()
+		return mirrorOf(e.Type(), false).convert(e)
+	case reflect.Struct:
+		if !v.CanAddr() {
+			c := reflect.New(m.from).Elem()
+			c.Set(v)
+			v = c
+		}
+		s := reflect.New(m.to).Elem()
+		for i, f := range m.fields {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				// Fields of nil embedded pointers
+				continue
+			}
+			if !fv.CanInterface() {
+				// Fields promoted from unexported embedded structs
+				fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+			}
+			s.Field(i).Set(m.fieldMirrors[i].convert(fv))
+		}
+		return s
+	}
+	return v
+}
+
+// revert returns v, of type m.to, as a value of type m.from.
+func (m *mirror) revert(v reflect.Value) reflect.Value {
+	if !m.changed {
+		return v
+	}
+	if m.from == bigIntType || m.from == bytesType {
+		return v.Convert(m.from)
+	}
+
+	switch m.from.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(m.from)
+		}
+		p := reflect.New(m.from.Elem())
+		p.Elem().Set(m.elem.revert(v.Elem()))
+		return p
+	case reflect.Slice, reflect.Array:
+		var s reflect.Value
+		if m.from.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return reflect.Zero(m.from)
+			}
+			s = reflect.MakeSlice(m.from, v.Len(), v.Len())
+		} else {
+			s = reflect.New(m.from).Elem()
+		}
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(m.elem.revert(v.Index(i)))
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(m.from)
+		}
+		mv := reflect.MakeMapWithSize(m.from, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			mv.SetMapIndex(it.Key(), m.elem.revert(it.Value()))
+		}
+		return mv
+	case reflect.Struct:
+		s := reflect.New(m.from).Elem()
+		for i, f := range m.fields {
+			fv := v.Field(i)
+			if fv.IsZero() {
+				// Leaves nil embedded pointers unallocated
+				continue
+			}
+			dst := s
+			for _, x := range f.index {
+				if dst.Kind() == reflect.Pointer {
+					if dst.IsNil() {
+						dst.Set(reflect.New(dst.Type().Elem()))
+					}
+					dst = dst.Elem()
+				}
+				dst = dst.Field(x)
+				if !dst.CanSet() {
+					// Fields promoted from unexported embedded structs
+					dst = reflect.NewAt(dst.Type(), unsafe.Pointer(dst.UnsafeAddr())).Elem()
+				}
+			}
+			dst.Set(m.fieldMirrors[i].revert(fv))
+		}
+		return s
+	}
+	return v
+}
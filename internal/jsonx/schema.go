@@ -0,0 +1,292 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	bigFloatType = reflect.TypeOf((*big.Float)(nil))
+	addressType  = reflect.TypeOf(common.Address{})
+	hashType     = reflect.TypeOf(common.Hash{})
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// scalars are the definitions of the types encoded as strings by the schema.
+var scalars = map[string]map[string]interface{}{
+	"Integer": {
+		"description": "An integer of up to 256 bits, as a decimal string.",
+		"type":        "string",
+		"pattern":     "^-?[0-9]+$",
+	},
+	"Decimal": {
+		"description": "A decimal number as a string, which may use exponent notation.",
+		"type":        "string",
+		"pattern":     "^-?[0-9]+(\\.[0-9]+)?([eE][-+]?[0-9]+)?$",
+	},
+	"Address": {
+		"description": "A 20 byte address, as 0x prefixed hex.",
+		"type":        "string",
+		"pattern":     "^0x[0-9a-fA-F]{40}$",
+	},
+	"Hash": {
+		"description": "A 32 byte hash or word, as 0x prefixed hex.",
+		"type":        "string",
+		"pattern":     "^0x[0-9a-fA-F]{64}$",
+	},
+	"Bytes": {
+		"description": "Bytes, as 0x prefixed hex.",
+		"type":        "string",
+		"pattern":     "^0x([0-9a-fA-F]{2})*$",
+	},
+	"Time": {
+		"description": "A time, as RFC 3339.",
+		"type":        "string",
+		"format":      "date-time",
+	},
+}
+
+// Def describes a type encoding itself, through its MarshalJSON, beyond its fields.
+type Def struct {
+	// Types are the values of the type's "type" discriminator, when it has one.
+	Types []string
+	// Extra are the properties added to the type's fields, each given as a value of its Go type.
+	Extra map[string]interface{}
+	// Description documents the type.
+	Description string
+}
+
+// Generator builds the JSON Schema of types, as encoded by Marshal.
+type Generator struct {
+	defs   map[string]interface{}
+	names  map[reflect.Type]string
+	types  map[reflect.Type]Def
+	unions map[reflect.Type][]reflect.Type
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		defs:   make(map[string]interface{}),
+		names:  make(map[reflect.Type]string),
+		types:  make(map[reflect.Type]Def),
+		unions: make(map[reflect.Type][]reflect.Type),
+	}
+}
+
+// Define registers how v's type encodes beyond its fields, which is required of types implementing json.Marshaler.
+func (g *Generator) Define(v interface{}, d Def) {
+	g.types[reflect.TypeOf(v)] = d
+}
+
+// Name sets the name of the definition of v's type, which otherwise is its package and type name. The type of a union
+// is named by a pointer to its interface, as with Union.
+func (g *Generator) Name(v interface{}, name string) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Interface {
+		t = t.Elem()
+	}
+	g.names[t] = name
+}
+
+// Union registers the types of impls as those held by the interface iface points to, such as
+// `(*commands.Command)(nil)`.
+func (g *Generator) Union(iface interface{}, impls ...interface{}) {
+	t := reflect.TypeOf(iface).Elem()
+	for _, v := range impls {
+		g.unions[t] = append(g.unions[t], reflect.TypeOf(v))
+	}
+}
+
+// Schema returns the JSON Schema document identified by id, matching any of roots.
+func (g *Generator) Schema(id, title, description, version string, roots ...interface{}) ([]byte, error) {
+	var refs []interface{}
+	for _, v := range roots {
+		s, err := g.schema(reflect.TypeOf(v))
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, s)
+	}
+	doc := map[string]interface{}{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         id,
+		"title":       title,
+		"description": description,
+		"version":     version,
+		"anyOf":       refs,
+		"$defs":       g.defs,
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/$defs/" + name}
+}
+
+func nullable(s interface{}) interface{} {
+	return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+}
+
+// scalar returns a reference to the scalar definition name.
+func (g *Generator) scalar(name string) interface{} {
+	g.defs[name] = scalars[name]
+	return ref(name)
+}
+
+// name returns the name of the definition of t.
+func (g *Generator) name(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	pkg := t.PkgPath()
+	for i := len(pkg) - 1; i >= 0; i-- {
+		if pkg[i] == '/' {
+			pkg = pkg[i+1:]
+			break
+		}
+	}
+	if pkg == "" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
+
+func (g *Generator) schema(t reflect.Type) (interface{}, error) {
+	switch t {
+	case bigIntType:
+		return g.scalar("Integer"), nil
+	case bigFloatType:
+		return g.scalar("Decimal"), nil
+	case addressType:
+		return g.scalar("Address"), nil
+	case hashType:
+		return g.scalar("Hash"), nil
+	case bytesType, hexBytesType:
+		return g.scalar("Bytes"), nil
+	case timeType:
+		return g.scalar("Time"), nil
+	}
+
+	if t.Kind() == reflect.Pointer {
+		return g.schema(t.Elem())
+	} else if _, ok := g.types[t]; ok || (t.Kind() == reflect.Struct && !isMarshaler(t)) {
+		return g.object(t)
+	} else if _, ok := g.unions[t]; ok {
+		return g.union(t)
+	}
+	if t.Implements(textMarshalerType) {
+		return map[string]interface{}{"type": "string"}, nil
+	} else if isMarshaler(t) {
+		return nil, fmt.Errorf("missing definition of %s, which implements json.Marshaler", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Interface:
+		return nil, fmt.Errorf("missing union of %s", t)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// union defines the interface t as one of its implementations, returning a reference to its definition.
+func (g *Generator) union(t reflect.Type) (interface{}, error) {
+	name := g.name(t)
+	if name == "" {
+		return nil, fmt.Errorf("missing name of union %s", t)
+	} else if _, ok := g.defs[name]; ok {
+		return ref(name), nil
+	}
+	def := make(map[string]interface{})
+	g.defs[name] = def
+
+	var oneOf []interface{}
+	for _, impl := range g.unions[t] {
+		s, err := g.schema(impl)
+		if err != nil {
+			return nil, err
+		}
+		oneOf = append(oneOf, s)
+	}
+	def["oneOf"] = oneOf
+	return ref(name), nil
+}
+
+// object defines the struct t, returning a reference to its definition.
+func (g *Generator) object(t reflect.Type) (interface{}, error) {
+	name := g.name(t)
+	if _, ok := g.defs[name]; ok {
+		return ref(name), nil
+	}
+	// Defined before its properties, so recursive types refer to it
+	def := map[string]interface{}{"type": "object", "additionalProperties": false}
+	g.defs[name] = def
+
+	d := g.types[t]
+	if d.Description != "" {
+		def["description"] = d.Description
+	}
+	properties := make(map[string]interface{})
+	var required []string
+	for _, f := range fields(t) {
+		s, err := g.schema(f.typ)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s; %w", t, f.name, err)
+		}
+		// Nil pointers and interfaces are null
+		if k := f.typ.Kind(); !f.omitEmpty && (k == reflect.Pointer || k == reflect.Interface) {
+			s = nullable(s)
+		}
+		properties[f.name] = s
+		if !f.omitEmpty {
+			required = append(required, f.name)
+		}
+	}
+	for name, v := range d.Extra {
+		s, err := g.schema(reflect.TypeOf(v))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s; %w", t, name, err)
+		}
+		properties[name] = s
+		required = append(required, name)
+	}
+	switch len(d.Types) {
+	case 0:
+	case 1:
+		properties["type"] = map[string]interface{}{"const": d.Types[0]}
+		required = append(required, "type")
+	default:
+		properties["type"] = map[string]interface{}{"enum": d.Types}
+		required = append(required, "type")
+	}
+	sort.Strings(required)
+	def["properties"] = properties
+	def["required"] = required
+	return ref(name), nil
+}
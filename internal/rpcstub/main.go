@@ -0,0 +1,412 @@
+// Command rpcstub serves a local JSON-RPC stand-in of a chain holding generated Universal Router transactions, for
+// exercising the `tx`, `scan` and `watch` commands without a node.
+//
+//	go run ./internal/rpcstub -addr 127.0.0.1:8545 -blocks 100
+//	unidecode scan -rpc http://127.0.0.1:8545 -from 20000000 -receipts
+//	unidecode watch -rpc ws://127.0.0.1:8545 -command V4_SWAP
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/juztin/unidecode"
+	"github.com/juztin/unidecode/pool"
+	"github.com/juztin/unidecode/registry"
+)
+
+var (
+	weth   = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	usdc   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	v3Pool = common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	depositTopic  = crypto.Keccak256Hash([]byte("Deposit(address,uint256)"))
+	swapTopic     = crypto.Keccak256Hash([]byte("Swap(address,address,int256,int256,uint160,uint128,int24)"))
+	v4SwapTopic   = crypto.Keccak256Hash([]byte("Swap(bytes32,address,int128,int128,uint160,uint128,int24,uint24)"))
+
+	// ethUSDC is the native ETH/USDC V4 pool swapped through by v4SwapCall
+	ethUSDC = pool.NewKey(common.Address{}, usdc, big.NewInt(500), big.NewInt(10), common.Address{})
+)
+
+// chain is an in-memory chain of generated blocks.
+type chain struct {
+	id       *big.Int
+	first    uint64
+	blocks   []*types.Block
+	receipts map[common.Hash]*types.Receipt
+	interval time.Duration
+	txs      map[common.Hash]*types.Transaction
+	signer   types.Signer
+}
+
+func pack(types []string, vals ...interface{}) []byte {
+	var args abi.Arguments
+	for _, t := range types {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	b, err := args.Pack(vals...)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func word(v *big.Int) []byte {
+	if v.Sign() < 0 {
+		v = new(big.Int).Add(new(big.Int).Lsh(common.Big1, 256), v)
+	}
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
+
+func topic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+// swapCall returns the calldata of a router `execute` wrapping amountIn of ETH and swapping it for at-least
+// amountOutMin USDC.
+func swapCall(amountIn, amountOutMin *big.Int) []byte {
+	wrap := pack([]string{"address", "uint256"}, common.HexToAddress("0x2"), amountIn)
+	path := append(append(weth.Bytes(), 0x00, 0x01, 0xf4), usdc.Bytes()...)
+	swap := pack([]string{"address", "uint256", "uint256", "bytes", "bool"}, common.HexToAddress("0x1"), amountIn, amountOutMin, path, false)
+	return append([]byte{0x35, 0x93, 0x56, 0x4c}, pack([]string{"bytes", "bytes[]", "uint256"}, []byte{0x0b, 0x00}, [][]byte{wrap, swap}, big.NewInt(1800000000))...)
+}
+
+// v4SwapCall returns the calldata of a router `execute` swapping amountIn of native ETH for at-least amountOutMin
+// USDC through ethUSDC.
+func v4SwapCall(amountIn, amountOutMin *big.Int) []byte {
+	// ExactInputSingleParams is a struct with a dynamic member, so it's encoded with a leading offset
+	params := pack([]string{"uint256", "address", "address", "uint24", "int24", "address", "bool", "uint128", "uint128", "uint256", "uint256"},
+		big.NewInt(0x20), ethUSDC.Currency0, ethUSDC.Currency1, ethUSDC.Fee, ethUSDC.TickSpacing, ethUSDC.Hooks, true, amountIn, amountOutMin, big.NewInt(0x120), big.NewInt(0))
+	settle := pack([]string{"address", "uint256"}, ethUSDC.Currency0, amountIn)
+	take := pack([]string{"address", "uint256"}, ethUSDC.Currency1, amountOutMin)
+	input := pack([]string{"bytes", "bytes[]"}, []byte{0x06, 0x0c, 0x0f}, [][]byte{params, settle, take})
+	return append([]byte{0x35, 0x93, 0x56, 0x4c}, pack([]string{"bytes", "bytes[]", "uint256"}, []byte{0x10}, [][]byte{input}, big.NewInt(1800000000))...)
+}
+
+// v4SwapLogs returns the logs of a swap made by v4SwapCall.
+func v4SwapLogs(poolManager, router, recipient common.Address, amountIn, amountOut *big.Int) []*types.Log {
+	return []*types.Log{
+		{Address: poolManager, Topics: []common.Hash{v4SwapTopic, ethUSDC.ID(), topic(router)},
+			Data: append(append(append(append(append(word(new(big.Int).Neg(amountIn)), word(amountOut)...), word(big.NewInt(1<<60))...), word(big.NewInt(1<<50))...), word(big.NewInt(-195000))...), word(ethUSDC.Fee)...)},
+		{Address: usdc, Topics: []common.Hash{transferTopic, topic(poolManager), topic(recipient)}, Data: word(amountOut)},
+	}
+}
+
+// swapLogs returns the logs of a swap made by swapCall.
+func swapLogs(router, recipient common.Address, amountIn, amountOut *big.Int) []*types.Log {
+	return []*types.Log{
+		{Address: weth, Topics: []common.Hash{depositTopic, topic(router)}, Data: word(amountIn)},
+		{Address: usdc, Topics: []common.Hash{transferTopic, topic(v3Pool), topic(recipient)}, Data: word(amountOut)},
+		{Address: weth, Topics: []common.Hash{transferTopic, topic(router), topic(v3Pool)}, Data: word(amountIn)},
+		{Address: v3Pool, Topics: []common.Hash{swapTopic, topic(router), topic(recipient)},
+			Data: append(append(append(append(word(new(big.Int).Neg(amountOut)), word(amountIn)...), word(big.NewInt(1<<60))...), word(big.NewInt(1<<50))...), word(big.NewInt(-195000))...)},
+	}
+}
+
+// newChain generates count blocks starting at first, each holding a router swap, alternating between V3 and V4, an
+// ETH transfer, and every third block a router call of an unsupported method.
+func newChain(chainID, first, count uint64) *chain {
+	d, ok := registry.Default().Get(chainID)
+	if !ok {
+		log.Fatalf("no deployment is known for chain %d", chainID)
+	}
+
+	c := &chain{
+		id:       new(big.Int).SetUint64(chainID),
+		first:    first,
+		receipts: make(map[common.Hash]*types.Receipt),
+		txs:      make(map[common.Hash]*types.Transaction),
+	}
+	c.signer = types.LatestSignerForChainID(c.id)
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("unidecode rpcstub")))
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	var nonce uint64
+	parent := common.Hash{}
+	for n := first; n < first+count; n++ {
+		var (
+			txs      types.Transactions
+			receipts types.Receipts
+		)
+		add := func(to common.Address, value *big.Int, data []byte, status uint64, logs []*types.Log) {
+			tx, err := types.SignNewTx(key, c.signer, &types.DynamicFeeTx{
+				ChainID: c.id, Nonce: nonce, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 300000,
+				To: &to, Value: value, Data: data,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			nonce++
+			if logs == nil {
+				logs = []*types.Log{}
+			}
+			txs = append(txs, tx)
+			receipts = append(receipts, &types.Receipt{Type: tx.Type(), Status: status, GasUsed: 150000, Logs: logs,
+				TxHash: tx.Hash(), EffectiveGasPrice: big.NewInt(2)})
+		}
+
+		amountIn := big.NewInt(1e18)
+		amountOut := new(big.Int).SetUint64(3000e6 + (n%100)*1e6)
+		if n%2 == 0 {
+			add(d.UniversalRouter, amountIn, v4SwapCall(amountIn, big.NewInt(2990e6)), types.ReceiptStatusSuccessful, v4SwapLogs(d.PoolManager, d.UniversalRouter, sender, amountIn, amountOut))
+		} else {
+			add(d.UniversalRouter, amountIn, swapCall(amountIn, big.NewInt(2990e6)), types.ReceiptStatusSuccessful, swapLogs(d.UniversalRouter, sender, amountIn, amountOut))
+		}
+		add(common.HexToAddress("0xbeef"), big.NewInt(1e15), nil, types.ReceiptStatusSuccessful, nil)
+		if n%3 == 0 {
+			add(d.UniversalRouter, common.Big0, []byte{0xde, 0xad, 0xbe, 0xef}, types.ReceiptStatusFailed, nil)
+		}
+
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(n),
+			GasLimit:   30000000,
+			Time:       1700000000 + (n-first)*12,
+			Difficulty: common.Big0,
+			BaseFee:    common.Big1,
+		}
+		var cumulative uint64
+		for i, r := range receipts {
+			cumulative += r.GasUsed
+			r.CumulativeGasUsed = cumulative
+			r.Bloom = types.CreateBloom(r)
+			r.TransactionIndex = uint(i)
+		}
+		b := types.NewBlock(header, &types.Body{Transactions: txs}, receipts, trie.NewStackTrie(nil))
+		var index uint
+		for i, r := range receipts {
+			r.BlockHash, r.BlockNumber = b.Hash(), b.Number()
+			for _, l := range r.Logs {
+				l.TxHash, l.TxIndex, l.BlockHash, l.BlockNumber, l.Index = r.TxHash, uint(i), b.Hash(), n, index
+				index++
+			}
+			c.receipts[r.TxHash] = r
+			c.txs[r.TxHash] = txs[i]
+		}
+		c.blocks = append(c.blocks, b)
+		parent = b.Hash()
+	}
+	return c
+}
+
+func (c *chain) block(n rpc.BlockNumber) *types.Block {
+	switch {
+	case n == rpc.LatestBlockNumber, n == rpc.SafeBlockNumber, n == rpc.FinalizedBlockNumber, n == rpc.PendingBlockNumber:
+		return c.blocks[len(c.blocks)-1]
+	case n < 0 || uint64(n) < c.first || uint64(n)-c.first >= uint64(len(c.blocks)):
+		return nil
+	}
+	return c.blocks[uint64(n)-c.first]
+}
+
+// marshalTx returns the RPC representation of the transaction at index within b.
+func (c *chain) marshalTx(b *types.Block, index int) (map[string]interface{}, error) {
+	tx := b.Transactions()[index]
+	j, err := tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(j, &m); err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(c.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	m["from"] = from
+	m["blockHash"] = b.Hash()
+	m["blockNumber"] = (*hexutil.Big)(b.Number())
+	m["transactionIndex"] = hexutil.Uint64(index)
+	return m, nil
+}
+
+// eth is the `eth` namespace of the stand-in.
+type eth struct {
+	c *chain
+}
+
+func (e eth) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(e.c.id)
+}
+
+func (e eth) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(e.c.blocks[len(e.c.blocks)-1].NumberU64())
+}
+
+func (e eth) GetBlockByNumber(n rpc.BlockNumber, full bool) (map[string]interface{}, error) {
+	b := e.c.block(n)
+	if b == nil {
+		return nil, nil
+	}
+	j, err := b.Header().MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(j, &m); err != nil {
+		return nil, err
+	}
+	txs := make([]interface{}, len(b.Transactions()))
+	for i, tx := range b.Transactions() {
+		if !full {
+			txs[i] = tx.Hash()
+			continue
+		}
+		if txs[i], err = e.c.marshalTx(b, i); err != nil {
+			return nil, err
+		}
+	}
+	m["transactions"] = txs
+	m["uncles"] = []common.Hash{}
+	m["size"] = hexutil.Uint64(b.Size())
+	return m, nil
+}
+
+func (e eth) GetTransactionByHash(hash common.Hash) (map[string]interface{}, error) {
+	r, ok := e.c.receipts[hash]
+	if !ok {
+		return nil, nil
+	}
+	return e.c.marshalTx(e.c.block(rpc.BlockNumber(r.BlockNumber.Int64())), int(r.TransactionIndex))
+}
+
+func (e eth) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	return e.c.receipts[hash], nil
+}
+
+// Call only answers `decimals()`, which is 6 for USDC and 18 otherwise.
+func (e eth) Call(ctx context.Context, args struct {
+	To   *common.Address `json:"to"`
+	Data hexutil.Bytes   `json:"data"`
+	// Some clients send the calldata as `input`
+	Input hexutil.Bytes `json:"input"`
+}, block *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	data := args.Data
+	if len(data) == 0 {
+		data = args.Input
+	}
+	if args.To == nil || len(data) < 4 || hexutil.Encode(data[:4]) != "0x313ce567" {
+		return nil, errors.New("execution reverted")
+	}
+	if *args.To == usdc {
+		return word(big.NewInt(6)), nil
+	}
+	return word(big.NewInt(18)), nil
+}
+
+// NewPendingTransactions serves `eth_subscribe("newPendingTransactions", fullTx)`, replaying the transactions of the
+// chain in order, one each interval, as though pending.
+func (e eth) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		ticker := time.NewTicker(e.c.interval)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			b := e.c.blocks[i%len(e.c.blocks)]
+			for j := range b.Transactions() {
+				select {
+				case <-ticker.C:
+				case <-sub.Err():
+					return
+				}
+				var v interface{} = b.Transactions()[j].Hash()
+				if fullTx != nil && *fullTx {
+					m, err := e.c.marshalTx(b, j)
+					if err != nil {
+						return
+					}
+					// Pending transactions aren't included in a block yet
+					m["blockHash"], m["blockNumber"], m["transactionIndex"] = nil, nil, nil
+					v = m
+				}
+				if err := notifier.Notify(sub.ID, v); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// debug is the `debug` namespace of the stand-in.
+type debug struct {
+	c *chain
+}
+
+// TraceTransaction returns a callTracer frame of the transaction's top-level call.
+func (d debug) TraceTransaction(hash common.Hash, config map[string]interface{}) (*unidecode.CallFrame, error) {
+	tx, ok := d.c.txs[hash]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+	from, err := types.Sender(d.c.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	f := &unidecode.CallFrame{Type: "CALL", From: from, To: tx.To(), Value: (*hexutil.Big)(tx.Value()), Input: tx.Data()}
+	if d.c.receipts[hash].Status != types.ReceiptStatusSuccessful {
+		f.Error = "execution reverted"
+	}
+	return f, nil
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8545", "address to listen on")
+	chainID := flag.Uint64("chain", registry.Mainnet, "chain ID, whose registry deployment the transactions are sent to")
+	first := flag.Uint64("first", 20000000, "number of the first block")
+	count := flag.Uint64("blocks", 100, "number of blocks to generate")
+	interval := flag.Duration("interval", time.Second, "interval between pending transactions of subscriptions")
+	flag.Parse()
+	if *count == 0 {
+		log.Fatal("-blocks must be at-least 1")
+	}
+
+	c := newChain(*chainID, *first, *count)
+	c.interval = *interval
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", eth{c}); err != nil {
+		log.Fatal(err)
+	}
+	if err := server.RegisterName("debug", debug{c}); err != nil {
+		log.Fatal(err)
+	}
+
+	// Websocket upgrades are served alongside HTTP, as subscriptions require them
+	ws := server.WebsocketHandler([]string{"*"})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		server.ServeHTTP(w, r)
+	})
+
+	log.Printf("serving chain %d, blocks %d-%d, on http://%s and ws://%s", *chainID, *first, *first+*count-1, *addr, *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
@@ -0,0 +1,183 @@
+// Package yamlx converts JSON to YAML, keeping the order of object keys, so the YAML output matches the JSON schema.
+package yamlx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// member is a key of an object and its value.
+type member struct {
+	key   string
+	value interface{}
+}
+
+// object is a JSON object, in key order.
+type object []member
+
+// FromJSON returns the YAML document of the JSON value data.
+func FromJSON(data []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	v, err := decode(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON; %w", err)
+	}
+
+	var b bytes.Buffer
+	switch v.(type) {
+	case object, []interface{}:
+		if !isEmpty(v) {
+			write(&b, v, 0)
+			return b.Bytes(), nil
+		}
+	}
+	fmt.Fprintf(&b, "%s\n", scalar(v))
+	return b.Bytes(), nil
+}
+
+// decode decodes the next JSON value of d, holding objects as object and numbers as json.Number.
+func decode(d *json.Decoder) (interface{}, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('{'):
+		o := object{}
+		for d.More() {
+			k, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decode(d)
+			if err != nil {
+				return nil, err
+			}
+			o = append(o, member{k.(string), v})
+		}
+		_, err = d.Token()
+		return o, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for d.More() {
+			v, err := decode(d)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		_, err = d.Token()
+		return a, err
+	}
+	return t, nil
+}
+
+// isEmpty reports whether v is an empty object or array.
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case object:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// write writes the non-empty object or array v as a block indented by indent spaces. The first line is written
+// without indentation, as it may follow a sequence entry's "- ".
+func write(w io.Writer, v interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch v := v.(type) {
+	case object:
+		for i, m := range v {
+			if i > 0 {
+				io.WriteString(w, pad)
+			}
+			io.WriteString(w, quote(m.key)+":")
+			writeValue(w, m.value, indent, false)
+		}
+	case []interface{}:
+		for i, e := range v {
+			if i > 0 {
+				io.WriteString(w, pad)
+			}
+			io.WriteString(w, "-")
+			writeValue(w, e, indent, true)
+		}
+	}
+}
+
+// writeValue writes the value following a key or sequence entry indented by indent spaces.
+func writeValue(w io.Writer, v interface{}, indent int, inSequence bool) {
+	if isEmpty(v) {
+		fmt.Fprintf(w, " %s\n", scalar(v))
+		return
+	}
+	switch v.(type) {
+	case object, []interface{}:
+		if inSequence {
+			// Members of a collection within a sequence align with its first member
+			io.WriteString(w, " ")
+		} else {
+			fmt.Fprintf(w, "\n%s", strings.Repeat(" ", indent+2))
+		}
+		write(w, v, indent+2)
+	default:
+		fmt.Fprintf(w, " %s\n", scalar(v))
+	}
+}
+
+// scalar returns the YAML representation of the scalar, or empty collection, v.
+func scalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		return quote(v)
+	case object:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	}
+	return fmt.Sprint(v)
+}
+
+// reserved are the plain scalars YAML resolves to other than strings.
+var reserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true, "y": true, "n": true,
+	"null": true, "~": true,
+}
+
+// quote returns s as a plain scalar when it can only be read back as the same string, or double quoted otherwise.
+// Strings which look like numbers, such as hex and decimal amounts, are always quoted.
+func quote(s string) string {
+	if s == "" || reserved[strings.ToLower(s)] {
+		return doubleQuote(s)
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '.' || r == '/'):
+		default:
+			return doubleQuote(s)
+		}
+	}
+	return s
+}
+
+// doubleQuote double quotes s; JSON strings are valid YAML double quoted scalars.
+func doubleQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,69 @@
+package unidecode
+
+import (
+	"fmt"
+
+	"github.com/juztin/unidecode/hex"
+)
+
+// multicall(bytes[]) | keccak256
+var multicallSig = []byte{0xac, 0x96, 0x50, 0xd8}
+
+var multicallSigStr = fmt.Sprintf("%x", multicallSig)
+
+// decodeCalls returns the elements of the `bytes[]` whose location, relative to start, is stored at start+head.
+func decodeCalls(calldata []byte, start, head int) ([][]byte, error) {
+	if start < 0 || head < 0 || start > len(calldata)-0x20 || head > len(calldata)-start-0x20 {
+		return nil, fmt.Errorf("%w; calls exceed calldata bounds", ErrInvalidCallData)
+	}
+	loc, err := hex.Int(calldata[start+head : start+head+0x20])
+	if err != nil {
+		return nil, fmt.Errorf("invalid calls start memory location; %w", err)
+	} else if loc > len(calldata)-start-0x20 {
+		return nil, fmt.Errorf("%w; calls exceed calldata bounds", ErrInvalidCallData)
+	}
+	offset := start + loc
+
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return nil, fmt.Errorf("invalid calls length; %w", err)
+	}
+	offset += 0x20
+	if count > (len(calldata)-offset)/0x20 {
+		return nil, fmt.Errorf("%w; calls length %d exceeds calldata bounds", ErrInvalidCallData, count)
+	}
+
+	var calls [][]byte
+	for i := 0; i < count; i++ {
+		callOffset, err := hex.Int(calldata[offset+i*0x20 : offset+i*0x20+0x20])
+		if err != nil {
+			return calls, fmt.Errorf("invalid call location for call %d; %w", i, err)
+		}
+		if callOffset > len(calldata)-offset-0x20 {
+			return calls, fmt.Errorf("%w; call %d exceeds calldata bounds", ErrInvalidCallData, i)
+		}
+		callOffset += offset
+		callLen, err := hex.Int(calldata[callOffset : callOffset+0x20])
+		if err != nil {
+			return calls, fmt.Errorf("invalid call length for call %d; %w", i, err)
+		} else if callLen > len(calldata)-callOffset-0x20 {
+			return calls, fmt.Errorf("%w; call %d exceeds calldata bounds", ErrInvalidCallData, i)
+		}
+		calls = append(calls, calldata[callOffset+0x20:callOffset+0x20+callLen])
+	}
+	return calls, nil
+}
+
+// multicallType returns the message type of the first recognised call within a `multicall(bytes[])`.
+func multicallType(calldata []byte) messageType {
+	calls, err := decodeCalls(calldata, 0x04, 0x00)
+	if err != nil {
+		return UnknownMessage
+	}
+	for _, call := range calls {
+		if t := MessageType(call); t != UnknownMessage {
+			return t
+		}
+	}
+	return UnknownMessage
+}
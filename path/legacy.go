@@ -0,0 +1,72 @@
+package path
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+)
+
+// V3Hop is a single pool of a V3 encoded path.
+//
+// For exact output swaps the path is encoded in reverse, so TokenIn is the token being bought.
+type V3Hop struct {
+	TokenIn  common.Address `json:"tokenIn"`
+	Fee      *big.Int       `json:"fee"`
+	TokenOut common.Address `json:"tokenOut"`
+}
+
+func (v V3Hop) Fields() []field.Field {
+	return []field.Field{
+		{Name: "TokenIn", Value: v.TokenIn},
+		{Name: "Fee", Value: v.Fee},
+		{Name: "TokenOut", Value: v.TokenOut},
+	}
+}
+
+// DecodeV3 decodes a packed V3 path of `token (20) | fee (3) | token (20) | ...`.
+//
+// see: v3-periphery/contracts/libraries/Path.sol
+func DecodeV3(b []byte) ([]V3Hop, error) {
+	const (
+		addrSize = 20
+		feeSize  = 3
+		hopSize  = addrSize + feeSize
+	)
+	if len(b) < hopSize+addrSize || (len(b)-addrSize)%hopSize != 0 {
+		return nil, fmt.Errorf("invalid v3 path length %d", len(b))
+	}
+
+	var hops []V3Hop
+	for i := 0; i+hopSize+addrSize <= len(b); i += hopSize {
+		hops = append(hops, V3Hop{
+			TokenIn:  common.BytesToAddress(b[i : i+addrSize]),
+			Fee:      new(big.Int).SetBytes(b[i+addrSize : i+hopSize]),
+			TokenOut: common.BytesToAddress(b[i+hopSize : i+hopSize+addrSize]),
+		})
+	}
+	return hops, nil
+}
+
+// DecodeV2 decodes the `address[]` path whose length is stored at offset.
+func DecodeV2(calldata []byte, offset int) ([]common.Address, error) {
+	if offset < 0 || offset > len(calldata)-0x20 {
+		return nil, fmt.Errorf("v2 path exceeds calldata bounds")
+	}
+	count, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return nil, fmt.Errorf("invalid v2 path length; %w", err)
+	} else if count > (len(calldata)-offset-0x20)/0x20 {
+		return nil, fmt.Errorf("invalid v2 path length; %d exceeds calldata bounds", count)
+	}
+	offset += 0x20
+
+	var tokens []common.Address
+	for i := 0; i < count; i++ {
+		tokens = append(tokens, common.BytesToAddress(calldata[offset+i*0x20:offset+i*0x20+0x20]))
+	}
+	return tokens, nil
+}
@@ -1,13 +1,14 @@
 package path
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/juztin/unidecode/field"
 	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
 	"github.com/juztin/unidecode/pool"
 )
 
@@ -21,10 +22,22 @@ type Key struct {
 
 func (k Key) MarshalJSON() ([]byte, error) {
 	type Alias Key
-	return json.Marshal(&struct {
-		Alias
-		HookData string `json:"hookData"`
-	}{(Alias)(k), fmt.Sprintf("0x%x", k.HookData)})
+	return jsonx.Marshal((Alias)(k))
+}
+
+func (k *Key) UnmarshalJSON(data []byte) error {
+	type Alias Key
+	return jsonx.Unmarshal(data, (*Alias)(k))
+}
+
+func (k Key) Fields() []field.Field {
+	return []field.Field{
+		{Name: "IntermediateCurrency", Value: k.IntermediateCurrency},
+		{Name: "Fee", Value: k.Fee},
+		{Name: "TickSpacing", Value: k.TickSpacing},
+		{Name: "Hooks", Value: k.Hooks},
+		{Name: "HookData", Value: k.HookData},
+	}
 }
 
 func PoolAndSwapDirection(k Key, currencyIn common.Address) (pool.Key, bool) {
@@ -41,6 +54,9 @@ func PoolAndSwapDirection(k Key, currencyIn common.Address) (pool.Key, bool) {
 
 func Decode(calldata []byte, offset int) (Key, error) {
 	var k Key
+	if offset < 0 || offset > len(calldata)-0xa0 {
+		return k, fmt.Errorf("path-key exceeds calldata bounds")
+	}
 	k.IntermediateCurrency = common.BytesToAddress(calldata[offset : offset+0x20])
 	k.Fee = new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40])
 	k.TickSpacing = new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60])
@@ -48,12 +64,16 @@ func Decode(calldata []byte, offset int) (Key, error) {
 	hookDataStart, err := hex.Int(calldata[offset+0x80 : offset+0xa0])
 	if err != nil {
 		return k, fmt.Errorf("invalid hookData start value; %w", err)
+	} else if hookDataStart > len(calldata)-offset-0x20 {
+		return k, fmt.Errorf("hook-data exceeds calldata bounds; start 0x%x", hookDataStart)
 	}
 
 	offset = offset + hookDataStart
 	hookDataLen, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
-		err = fmt.Errorf("invalid hook-data length: 0x%x; %w", calldata[offset:offset+0x20], err)
+		return k, fmt.Errorf("invalid hook-data length: 0x%x; %w", calldata[offset:offset+0x20], err)
+	} else if hookDataLen > len(calldata)-offset-0x20 {
+		return k, fmt.Errorf("hook-data exceeds calldata bounds; length 0x%x", hookDataLen)
 	} else if hookDataLen == 0 {
 		k.HookData = []byte{0x0}
 	} else {
@@ -63,9 +83,14 @@ func Decode(calldata []byte, offset int) (Key, error) {
 }
 
 func DecodeMany(calldata []byte, offset int) ([]Key, error) {
+	if offset < 0 || offset > len(calldata)-0x20 {
+		return nil, fmt.Errorf("path-keys exceed calldata bounds")
+	}
 	count, err := hex.Int(calldata[offset : offset+0x20])
 	if err != nil {
 		return nil, fmt.Errorf("invalid path-key count; %w", err)
+	} else if count > (len(calldata)-offset-0x20)/0x20 {
+		return nil, fmt.Errorf("path-key count %d exceeds calldata bounds", count)
 	}
 	offset += 0x20
 
@@ -74,6 +99,8 @@ func DecodeMany(calldata []byte, offset int) ([]Key, error) {
 		keyOffset, err := hex.Int(calldata[offset+0x20*i : offset+0x20*i+0x20])
 		if err != nil {
 			return keys, fmt.Errorf("invalid path-key index start location for index %d; %w", i, err)
+		} else if keyOffset > len(calldata)-offset {
+			return keys, fmt.Errorf("path-key %d exceeds calldata bounds", i)
 		}
 		key, err := Decode(calldata, offset+keyOffset)
 		if err != nil {
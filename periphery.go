@@ -0,0 +1,105 @@
+package unidecode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/hex"
+)
+
+// PeripheryPayments methods shared by the V3 NonfungiblePositionManager and SwapRouter02.
+//
+// see: v3-periphery/contracts/base/PeripheryPayments.sol
+var (
+	// refundETH() | keccak256
+	refundETHSig = []byte{0x12, 0x21, 0x0e, 0x8a}
+	// unwrapWETH9(uint256,address) | keccak256
+	unwrapWETH9Sig = []byte{0x49, 0x40, 0x4b, 0x7c}
+	// sweepToken(address,uint256,address) | keccak256
+	sweepTokenSig = []byte{0xdf, 0x2a, 0xb5, 0xbb}
+
+	// SwapRouter02 only
+	//
+	// see: swap-router-contracts/contracts/base/PeripheryPaymentsExtended.sol
+
+	// unwrapWETH9(uint256) | keccak256
+	unwrapWETH9SenderSig = []byte{0x49, 0x61, 0x69, 0x97}
+	// sweepToken(address,uint256) | keccak256
+	sweepTokenSenderSig = []byte{0xe9, 0x0a, 0x18, 0x2f}
+	// wrapETH(uint256) | keccak256
+	wrapETHSig = []byte{0x1c, 0x58, 0xdb, 0x4f}
+
+	refundETHSigStr         = fmt.Sprintf("%x", refundETHSig)
+	unwrapWETH9SigStr       = fmt.Sprintf("%x", unwrapWETH9Sig)
+	sweepTokenSigStr        = fmt.Sprintf("%x", sweepTokenSig)
+	unwrapWETH9SenderSigStr = fmt.Sprintf("%x", unwrapWETH9SenderSig)
+	sweepTokenSenderSigStr  = fmt.Sprintf("%x", sweepTokenSenderSig)
+	wrapETHSigStr           = fmt.Sprintf("%x", wrapETHSig)
+)
+
+// decodePeripheryPayment decodes a PeripheryPayments call as its equivalent Universal Router command.
+//
+// The returned bool is false when calldata isn't a PeripheryPayments call.
+func decodePeripheryPayment(calldata []byte) (commands.Command, bool, error) {
+	switch fmt.Sprintf("%x", hex.MethodSig(calldata)) {
+	case refundETHSigStr:
+		// Refunding is sweeping the entire native balance back to the sender
+		return commands.Sweep{
+			Token:     actions.NATIVE,
+			Recipient: commands.MSG_SENDER,
+			AmountMin: new(big.Int),
+		}, true, nil
+	case unwrapWETH9SigStr:
+		// unwrapWETH9(uint256 amountMinimum, address recipient)
+		if len(calldata) < 0x44 {
+			return nil, true, ErrInvalidCallData
+		}
+		return commands.UnwrapWETH{
+			Recipient: common.BytesToAddress(calldata[0x24:0x44]),
+			AmountMin: new(big.Int).SetBytes(calldata[0x04:0x24]),
+		}, true, nil
+	case sweepTokenSigStr:
+		// sweepToken(address token, uint256 amountMinimum, address recipient)
+		if len(calldata) < 0x64 {
+			return nil, true, ErrInvalidCallData
+		}
+		return commands.Sweep{
+			Token:     common.BytesToAddress(calldata[0x04:0x24]),
+			Recipient: common.BytesToAddress(calldata[0x44:0x64]),
+			AmountMin: new(big.Int).SetBytes(calldata[0x24:0x44]),
+		}, true, nil
+	case unwrapWETH9SenderSigStr:
+		// unwrapWETH9(uint256 amountMinimum)
+		if len(calldata) < 0x24 {
+			return nil, true, ErrInvalidCallData
+		}
+		return commands.UnwrapWETH{
+			Recipient: commands.MSG_SENDER,
+			AmountMin: new(big.Int).SetBytes(calldata[0x04:0x24]),
+		}, true, nil
+	case sweepTokenSenderSigStr:
+		// sweepToken(address token, uint256 amountMinimum)
+		if len(calldata) < 0x44 {
+			return nil, true, ErrInvalidCallData
+		}
+		return commands.Sweep{
+			Token:     common.BytesToAddress(calldata[0x04:0x24]),
+			Recipient: commands.MSG_SENDER,
+			AmountMin: new(big.Int).SetBytes(calldata[0x24:0x44]),
+		}, true, nil
+	case wrapETHSigStr:
+		// wrapETH(uint256 value)
+		if len(calldata) < 0x24 {
+			return nil, true, ErrInvalidCallData
+		}
+		return commands.WrapWETH{
+			Recipient: commands.ADDRESS_THIS,
+			AmountMin: new(big.Int).SetBytes(calldata[0x04:0x24]),
+		}, true, nil
+	}
+	return nil, false, nil
+}
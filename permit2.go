@@ -0,0 +1,30 @@
+package unidecode
+
+import (
+	"bytes"
+
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/permit2"
+)
+
+// isPermit2Sig reports whether sig is one of the Permit2 contract's methods.
+func isPermit2Sig(sig []byte) bool {
+	if len(sig) != 4 {
+		return false
+	}
+	_, err := permit2.Parse(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	return err == nil
+}
+
+// DecodePermit2 decodes calldata sent directly to the Permit2 contract.
+//
+// Unlike the other messages, Permit2 calls have no Universal Router equivalent and are returned as a `permit2.Call`.
+func DecodePermit2(calldata []byte) (permit2.Call, error) {
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+	if hex.MethodSig(calldata) == nil {
+		return nil, ErrInvalidCallData
+	}
+	return permit2.Decode(calldata, 0)
+}
@@ -0,0 +1,370 @@
+package permit2
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+// Permit Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:permit
+type Permit struct {
+	Owner       common.Address         `json:"owner"`
+	Details     commands.PermitDetails `json:"details"`
+	Spender     common.Address         `json:"spender"`
+	SigDeadline *big.Int               `json:"sigDeadline"`
+	Sig         []byte                 `json:"sig"`
+}
+
+func (Permit) Type() Type {
+	return PERMIT
+}
+
+func (p Permit) MarshalJSON() ([]byte, error) {
+	type Alias Permit
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), PERMIT.String()})
+}
+
+func (p Permit) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Owner", Value: p.Owner},
+		field.Struct("Details", p.Details),
+		{Name: "Spender", Value: p.Spender},
+		{Name: "SigDeadline", Value: p.SigDeadline},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
+func DecodePermit(calldata []byte, offset int) (Permit, error) {
+	//        owner    ┌────── PermitDetails ─────────┐ spender deadline sig
+	// permit(address,((address,uint160,uint48,uint48),address,uint256),bytes)
+	var p Permit
+	if err := requireLen(calldata, offset, 0x100); err != nil {
+		return p, err
+	}
+
+	details, err := commands.DecodePermitDetails(calldata, offset+0x20)
+	if err != nil {
+		return p, err
+	}
+	sig, err := hex.Bytes(calldata, offset, 0xe0)
+	if err != nil {
+		return p, fmt.Errorf("invalid signature; %w", err)
+	}
+
+	p = Permit{
+		Owner:       common.BytesToAddress(calldata[offset : offset+0x20]),
+		Details:     details,
+		Spender:     common.BytesToAddress(calldata[offset+0xa0 : offset+0xc0]),
+		SigDeadline: new(big.Int).SetBytes(calldata[offset+0xc0 : offset+0xe0]),
+		Sig:         sig,
+	}
+	return p, nil
+}
+
+// PermitBatch Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:permit
+type PermitBatch struct {
+	Owner       common.Address           `json:"owner"`
+	Details     []commands.PermitDetails `json:"details"`
+	Spender     common.Address           `json:"spender"`
+	SigDeadline *big.Int                 `json:"sigDeadline"`
+	Sig         []byte                   `json:"sig"`
+}
+
+func (PermitBatch) Type() Type {
+	return PERMIT_BATCH
+}
+
+func (p PermitBatch) MarshalJSON() ([]byte, error) {
+	type Alias PermitBatch
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), PERMIT_BATCH.String()})
+}
+
+func (p PermitBatch) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Owner", Value: p.Owner},
+		field.List("Details", p.Details),
+		{Name: "Spender", Value: p.Spender},
+		{Name: "SigDeadline", Value: p.SigDeadline},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
+func DecodePermitBatch(calldata []byte, offset int) (PermitBatch, error) {
+	//        owner    ┌────── PermitDetails ─────────┐   spender deadline sig
+	// permit(address,((address,uint160,uint48,uint48)[],address,uint256),bytes)
+	var p PermitBatch
+	if err := requireLen(calldata, offset, 0x60); err != nil {
+		return p, err
+	}
+
+	batchLoc, err := hex.Int(calldata[offset+0x20 : offset+0x40])
+	if err != nil {
+		return p, fmt.Errorf("invalid PermitBatch location; %w", err)
+	}
+	details, spender, sigDeadline, err := commands.DecodePermitBatch(calldata, offset+batchLoc)
+	if err != nil {
+		return p, err
+	}
+	sig, err := hex.Bytes(calldata, offset, 0x40)
+	if err != nil {
+		return p, fmt.Errorf("invalid signature; %w", err)
+	}
+
+	p = PermitBatch{
+		Owner:       common.BytesToAddress(calldata[offset : offset+0x20]),
+		Details:     details,
+		Spender:     spender,
+		SigDeadline: sigDeadline,
+		Sig:         sig,
+	}
+	return p, nil
+}
+
+// TransferFrom Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:transferFrom
+type TransferFrom struct {
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Amount *big.Int       `json:"amount"`
+	Token  common.Address `json:"token"`
+}
+
+func (TransferFrom) Type() Type {
+	return TRANSFER_FROM
+}
+
+func (t TransferFrom) MarshalJSON() ([]byte, error) {
+	type Alias TransferFrom
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(t), TRANSFER_FROM.String()})
+}
+
+func (t TransferFrom) Fields() []field.Field {
+	return []field.Field{
+		{Name: "From", Value: t.From},
+		{Name: "To", Value: t.To},
+		{Name: "Amount", Value: t.Amount},
+		{Name: "Token", Value: t.Token},
+	}
+}
+
+func DecodeTransferFrom(calldata []byte, offset int) (TransferFrom, error) {
+	var t TransferFrom
+	if err := requireLen(calldata, offset, 0x80); err != nil {
+		return t, err
+	}
+
+	t = TransferFrom{
+		From:   common.BytesToAddress(calldata[offset : offset+0x20]),
+		To:     common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		Amount: new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		Token:  common.BytesToAddress(calldata[offset+0x60 : offset+0x80]),
+	}
+	return t, nil
+}
+
+// TransferFromBatch Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:transferFrom
+type TransferFromBatch struct {
+	Details []commands.AllowanceTransferDetails `json:"details"`
+}
+
+func (TransferFromBatch) Type() Type {
+	return TRANSFER_FROM_BATCH
+}
+
+func (t TransferFromBatch) MarshalJSON() ([]byte, error) {
+	type Alias TransferFromBatch
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(t), TRANSFER_FROM_BATCH.String()})
+}
+
+func (t TransferFromBatch) Fields() []field.Field {
+	return []field.Field{
+		field.List("Details", t.Details),
+	}
+}
+
+func DecodeTransferFromBatch(calldata []byte, offset int) (TransferFromBatch, error) {
+	details, err := commands.DecodeAllowanceTransferDetails(calldata, offset, 0x00)
+	return TransferFromBatch{Details: details}, err
+}
+
+// Approve Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:approve
+type Approve struct {
+	Token      common.Address `json:"token"`
+	Spender    common.Address `json:"spender"`
+	Amount     *big.Int       `json:"amount"`
+	Expiration time.Time      `json:"expiration"`
+}
+
+func (Approve) Type() Type {
+	return APPROVE
+}
+
+func (a Approve) MarshalJSON() ([]byte, error) {
+	type Alias Approve
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(a), APPROVE.String()})
+}
+
+func (a Approve) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: a.Token},
+		{Name: "Spender", Value: a.Spender},
+		{Name: "Amount", Value: a.Amount},
+		{Name: "Expiration", Value: a.Expiration},
+	}
+}
+
+func DecodeApprove(calldata []byte, offset int) (Approve, error) {
+	var a Approve
+	if err := requireLen(calldata, offset, 0x80); err != nil {
+		return a, err
+	}
+
+	epoch, err := hex.Int64(calldata[offset+0x60 : offset+0x80])
+	if err != nil {
+		return a, fmt.Errorf("invalid expiration; %w", err)
+	}
+
+	a = Approve{
+		Token:      common.BytesToAddress(calldata[offset : offset+0x20]),
+		Spender:    common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		Amount:     new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		Expiration: time.Unix(epoch, 0),
+	}
+	return a, nil
+}
+
+// TokenSpenderPair Solidity representation
+//
+// see: permit2/src/interfaces/IAllowanceTransfer.sol
+type TokenSpenderPair struct {
+	Token   common.Address `json:"token"`
+	Spender common.Address `json:"spender"`
+}
+
+func (t TokenSpenderPair) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: t.Token},
+		{Name: "Spender", Value: t.Spender},
+	}
+}
+
+// Lockdown Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:lockdown
+type Lockdown struct {
+	Approvals []TokenSpenderPair `json:"approvals"`
+}
+
+func (Lockdown) Type() Type {
+	return LOCKDOWN
+}
+
+func (l Lockdown) MarshalJSON() ([]byte, error) {
+	type Alias Lockdown
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(l), LOCKDOWN.String()})
+}
+
+func (l Lockdown) Fields() []field.Field {
+	return []field.Field{
+		field.List("Approvals", l.Approvals),
+	}
+}
+
+func DecodeLockdown(calldata []byte, offset int) (Lockdown, error) {
+	var l Lockdown
+	loc, count, err := hex.Array(calldata, offset, 0x00, 0x40)
+	if err != nil {
+		return l, fmt.Errorf("invalid approvals; %w", err)
+	}
+
+	for i := 0; i < count; i++ {
+		o := loc + i*0x40
+		l.Approvals = append(l.Approvals, TokenSpenderPair{
+			Token:   common.BytesToAddress(calldata[o : o+0x20]),
+			Spender: common.BytesToAddress(calldata[o+0x20 : o+0x40]),
+		})
+	}
+	return l, nil
+}
+
+// InvalidateNonces Solidity representation
+//
+// see: permit2/src/AllowanceTransfer.sol:invalidateNonces
+type InvalidateNonces struct {
+	Token    common.Address `json:"token"`
+	Spender  common.Address `json:"spender"`
+	NewNonce uint64         `json:"newNonce"`
+}
+
+func (InvalidateNonces) Type() Type {
+	return INVALIDATE_NONCES
+}
+
+func (n InvalidateNonces) MarshalJSON() ([]byte, error) {
+	type Alias InvalidateNonces
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(n), INVALIDATE_NONCES.String()})
+}
+
+func (n InvalidateNonces) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: n.Token},
+		{Name: "Spender", Value: n.Spender},
+		{Name: "NewNonce", Value: n.NewNonce},
+	}
+}
+
+func DecodeInvalidateNonces(calldata []byte, offset int) (InvalidateNonces, error) {
+	var n InvalidateNonces
+	if err := requireLen(calldata, offset, 0x60); err != nil {
+		return n, err
+	}
+
+	nonce, err := hex.Int64(calldata[offset+0x40 : offset+0x60])
+	if err != nil {
+		return n, fmt.Errorf("invalid nonce; %w", err)
+	}
+
+	n = InvalidateNonces{
+		Token:    common.BytesToAddress(calldata[offset : offset+0x20]),
+		Spender:  common.BytesToAddress(calldata[offset+0x20 : offset+0x40]),
+		NewNonce: uint64(nonce),
+	}
+	return n, nil
+}
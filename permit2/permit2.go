@@ -0,0 +1,75 @@
+package permit2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+)
+
+var ErrInvalidCallData = errors.New("invalid calldata")
+
+// Call is a decoded Permit2 method call.
+type Call interface {
+	Type() Type
+	Fields() []field.Field
+}
+
+// Decode decodes calldata sent directly to the Permit2 contract, where offset is the location of the method selector.
+func Decode(calldata []byte, offset int) (Call, error) {
+	if offset < 0 || offset > len(calldata) {
+		return nil, ErrInvalidCallData
+	}
+	sig := hex.MethodSig(calldata[offset:])
+	if sig == nil {
+		return nil, ErrInvalidCallData
+	}
+	t, err := Parse(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip method signature
+	offset += 0x04
+
+	var c Call
+	switch t {
+	case PERMIT:
+		c, err = DecodePermit(calldata, offset)
+	case PERMIT_BATCH:
+		c, err = DecodePermitBatch(calldata, offset)
+	case TRANSFER_FROM:
+		c, err = DecodeTransferFrom(calldata, offset)
+	case TRANSFER_FROM_BATCH:
+		c, err = DecodeTransferFromBatch(calldata, offset)
+	case APPROVE:
+		c, err = DecodeApprove(calldata, offset)
+	case LOCKDOWN:
+		c, err = DecodeLockdown(calldata, offset)
+	case INVALIDATE_NONCES:
+		c, err = DecodeInvalidateNonces(calldata, offset)
+	case INVALIDATE_UNORDERED_NONCES:
+		c, err = DecodeInvalidateUnorderedNonces(calldata, offset)
+	case PERMIT_TRANSFER_FROM:
+		c, err = DecodePermitTransferFrom(calldata, offset)
+	case PERMIT_BATCH_TRANSFER_FROM:
+		c, err = DecodePermitBatchTransferFrom(calldata, offset)
+	case PERMIT_WITNESS_TRANSFER_FROM:
+		c, err = DecodePermitWitnessTransferFrom(calldata, offset)
+	case PERMIT_BATCH_WITNESS_TRANSFER_FROM:
+		c, err = DecodePermitBatchWitnessTransferFrom(calldata, offset)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s data; %w", t, err)
+	}
+	return c, nil
+}
+
+// requireLen returns ErrInvalidCallData when calldata holds fewer than n bytes from offset.
+func requireLen(calldata []byte, offset, n int) error {
+	if offset < 0 || offset > len(calldata)-n {
+		return fmt.Errorf("%w; expected at least %d bytes but got %d", ErrInvalidCallData, n, len(calldata)-offset)
+	}
+	return nil
+}
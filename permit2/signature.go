@@ -0,0 +1,379 @@
+package permit2
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+// InvalidateUnorderedNonces Solidity representation
+//
+// see: permit2/src/SignatureTransfer.sol:invalidateUnorderedNonces
+type InvalidateUnorderedNonces struct {
+	WordPos *big.Int `json:"wordPos"`
+	Mask    *big.Int `json:"mask"`
+}
+
+func (InvalidateUnorderedNonces) Type() Type {
+	return INVALIDATE_UNORDERED_NONCES
+}
+
+func (n InvalidateUnorderedNonces) MarshalJSON() ([]byte, error) {
+	type Alias InvalidateUnorderedNonces
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(n), INVALIDATE_UNORDERED_NONCES.String()})
+}
+
+func (n InvalidateUnorderedNonces) Fields() []field.Field {
+	return []field.Field{
+		{Name: "WordPos", Value: n.WordPos},
+		{Name: "Mask", Value: (*hexutil.Big)(n.Mask)},
+	}
+}
+
+func DecodeInvalidateUnorderedNonces(calldata []byte, offset int) (InvalidateUnorderedNonces, error) {
+	var n InvalidateUnorderedNonces
+	if err := requireLen(calldata, offset, 0x40); err != nil {
+		return n, err
+	}
+
+	n = InvalidateUnorderedNonces{
+		WordPos: new(big.Int).SetBytes(calldata[offset : offset+0x20]),
+		Mask:    new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+	}
+	return n, nil
+}
+
+// TokenPermissions Solidity representation
+//
+// see: permit2/src/interfaces/ISignatureTransfer.sol
+type TokenPermissions struct {
+	Token  common.Address `json:"token"`
+	Amount *big.Int       `json:"amount"`
+}
+
+func (t TokenPermissions) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: t.Token},
+		{Name: "Amount", Value: t.Amount},
+	}
+}
+
+// SignatureTransferDetails Solidity representation
+//
+// see: permit2/src/interfaces/ISignatureTransfer.sol
+type SignatureTransferDetails struct {
+	To              common.Address `json:"to"`
+	RequestedAmount *big.Int       `json:"requestedAmount"`
+}
+
+func (s SignatureTransferDetails) Fields() []field.Field {
+	return []field.Field{
+		{Name: "To", Value: s.To},
+		{Name: "RequestedAmount", Value: s.RequestedAmount},
+	}
+}
+
+// decodePairs decodes the array of static `(address, uint256)` tuples whose location, relative to start, is stored
+// at start+head.
+func decodePairs(calldata []byte, start, head int) ([]common.Address, []*big.Int, error) {
+	loc, count, err := hex.Array(calldata, start, head, 0x40)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		addrs   []common.Address
+		amounts []*big.Int
+	)
+	for i := 0; i < count; i++ {
+		o := loc + i*0x40
+		addrs = append(addrs, common.BytesToAddress(calldata[o:o+0x20]))
+		amounts = append(amounts, new(big.Int).SetBytes(calldata[o+0x20:o+0x40]))
+	}
+	return addrs, amounts, nil
+}
+
+// PermitTransferFrom Solidity representation
+//
+// see: permit2/src/SignatureTransfer.sol:permitTransferFrom
+type PermitTransferFrom struct {
+	Permitted       TokenPermissions         `json:"permitted"`
+	Nonce           *big.Int                 `json:"nonce"`
+	Deadline        *big.Int                 `json:"deadline"`
+	TransferDetails SignatureTransferDetails `json:"transferDetails"`
+	Owner           common.Address           `json:"owner"`
+	Sig             []byte                   `json:"sig"`
+}
+
+func (PermitTransferFrom) Type() Type {
+	return PERMIT_TRANSFER_FROM
+}
+
+func (p PermitTransferFrom) MarshalJSON() ([]byte, error) {
+	type Alias PermitTransferFrom
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), PERMIT_TRANSFER_FROM.String()})
+}
+
+func (p PermitTransferFrom) Fields() []field.Field {
+	return []field.Field{
+		field.Struct("Permitted", p.Permitted),
+		{Name: "Nonce", Value: p.Nonce},
+		{Name: "Deadline", Value: p.Deadline},
+		field.Struct("TransferDetails", p.TransferDetails),
+		{Name: "Owner", Value: p.Owner},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
+func DecodePermitTransferFrom(calldata []byte, offset int) (PermitTransferFrom, error) {
+	//                     ┌─ TokenPermissions ┐ nonce   deadline ┌─ TransferDetails ┐ owner   sig
+	// permitTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes)
+	var p PermitTransferFrom
+	if err := requireLen(calldata, offset, 0x100); err != nil {
+		return p, err
+	}
+
+	sig, err := hex.Bytes(calldata, offset, 0xe0)
+	if err != nil {
+		return p, fmt.Errorf("invalid signature; %w", err)
+	}
+	p = decodePermitTransferFrom(calldata, offset)
+	p.Sig = sig
+	return p, nil
+}
+
+// decodePermitTransferFrom decodes the static head shared by `permitTransferFrom` and `permitWitnessTransferFrom`.
+func decodePermitTransferFrom(calldata []byte, offset int) PermitTransferFrom {
+	return PermitTransferFrom{
+		Permitted: TokenPermissions{
+			Token:  common.BytesToAddress(calldata[offset : offset+0x20]),
+			Amount: new(big.Int).SetBytes(calldata[offset+0x20 : offset+0x40]),
+		},
+		Nonce:    new(big.Int).SetBytes(calldata[offset+0x40 : offset+0x60]),
+		Deadline: new(big.Int).SetBytes(calldata[offset+0x60 : offset+0x80]),
+		TransferDetails: SignatureTransferDetails{
+			To:              common.BytesToAddress(calldata[offset+0x80 : offset+0xa0]),
+			RequestedAmount: new(big.Int).SetBytes(calldata[offset+0xa0 : offset+0xc0]),
+		},
+		Owner: common.BytesToAddress(calldata[offset+0xc0 : offset+0xe0]),
+	}
+}
+
+// PermitBatchTransferFrom Solidity representation
+//
+// see: permit2/src/SignatureTransfer.sol:permitTransferFrom
+type PermitBatchTransferFrom struct {
+	Permitted       []TokenPermissions         `json:"permitted"`
+	Nonce           *big.Int                   `json:"nonce"`
+	Deadline        *big.Int                   `json:"deadline"`
+	TransferDetails []SignatureTransferDetails `json:"transferDetails"`
+	Owner           common.Address             `json:"owner"`
+	Sig             []byte                     `json:"sig"`
+}
+
+func (PermitBatchTransferFrom) Type() Type {
+	return PERMIT_BATCH_TRANSFER_FROM
+}
+
+func (p PermitBatchTransferFrom) MarshalJSON() ([]byte, error) {
+	type Alias PermitBatchTransferFrom
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(p), PERMIT_BATCH_TRANSFER_FROM.String()})
+}
+
+func (p PermitBatchTransferFrom) Fields() []field.Field {
+	return []field.Field{
+		field.List("Permitted", p.Permitted),
+		{Name: "Nonce", Value: p.Nonce},
+		{Name: "Deadline", Value: p.Deadline},
+		field.List("TransferDetails", p.TransferDetails),
+		{Name: "Owner", Value: p.Owner},
+		{Name: "Sig", Value: p.Sig},
+	}
+}
+
+func DecodePermitBatchTransferFrom(calldata []byte, offset int) (PermitBatchTransferFrom, error) {
+	//                     ┌─ TokenPermissions ─┐ nonce   deadline ┌─ TransferDetails ─┐ owner   sig
+	// permitTransferFrom(((address,uint256)[],uint256,uint256),(address,uint256)[],address,bytes)
+	var p PermitBatchTransferFrom
+	if err := requireLen(calldata, offset, 0x80); err != nil {
+		return p, err
+	}
+
+	p, err := decodePermitBatchTransferFrom(calldata, offset)
+	if err != nil {
+		return p, err
+	}
+	p.Sig, err = hex.Bytes(calldata, offset, 0x60)
+	if err != nil {
+		return p, fmt.Errorf("invalid signature; %w", err)
+	}
+	return p, nil
+}
+
+// decodePermitBatchTransferFrom decodes the head shared by the batch `permitTransferFrom` and
+// `permitWitnessTransferFrom`.
+func decodePermitBatchTransferFrom(calldata []byte, offset int) (PermitBatchTransferFrom, error) {
+	var p PermitBatchTransferFrom
+
+	permitLoc, err := hex.Int(calldata[offset : offset+0x20])
+	if err != nil {
+		return p, fmt.Errorf("invalid PermitBatchTransferFrom location; %w", err)
+	}
+	permitLoc += offset
+	if err := requireLen(calldata, permitLoc, 0x60); err != nil {
+		return p, err
+	}
+
+	tokens, amounts, err := decodePairs(calldata, permitLoc, 0x00)
+	if err != nil {
+		return p, fmt.Errorf("invalid permitted tokens; %w", err)
+	}
+	for i := range tokens {
+		p.Permitted = append(p.Permitted, TokenPermissions{Token: tokens[i], Amount: amounts[i]})
+	}
+	p.Nonce = new(big.Int).SetBytes(calldata[permitLoc+0x20 : permitLoc+0x40])
+	p.Deadline = new(big.Int).SetBytes(calldata[permitLoc+0x40 : permitLoc+0x60])
+
+	recipients, amounts, err := decodePairs(calldata, offset, 0x20)
+	if err != nil {
+		return p, fmt.Errorf("invalid transfer details; %w", err)
+	}
+	for i := range recipients {
+		p.TransferDetails = append(p.TransferDetails, SignatureTransferDetails{To: recipients[i], RequestedAmount: amounts[i]})
+	}
+
+	p.Owner = common.BytesToAddress(calldata[offset+0x40 : offset+0x60])
+	return p, nil
+}
+
+// PermitWitnessTransferFrom Solidity representation
+//
+// see: permit2/src/SignatureTransfer.sol:permitWitnessTransferFrom
+type PermitWitnessTransferFrom struct {
+	PermitTransferFrom
+	Witness           common.Hash `json:"witness"`
+	WitnessTypeString string      `json:"witnessTypeString"`
+}
+
+func (PermitWitnessTransferFrom) Type() Type {
+	return PERMIT_WITNESS_TRANSFER_FROM
+}
+
+func (p PermitWitnessTransferFrom) MarshalJSON() ([]byte, error) {
+	type Alias PermitTransferFrom
+	return jsonx.Marshal(&struct {
+		Alias
+		Witness           common.Hash `json:"witness"`
+		WitnessTypeString string      `json:"witnessTypeString"`
+		Type              string      `json:"type"`
+	}{(Alias)(p.PermitTransferFrom), p.Witness, p.WitnessTypeString,
+		PERMIT_WITNESS_TRANSFER_FROM.String()})
+}
+
+func (p PermitWitnessTransferFrom) Fields() []field.Field {
+	return append(p.PermitTransferFrom.Fields(),
+		field.Field{Name: "Witness", Value: p.Witness},
+		field.Field{Name: "WitnessTypeString", Value: p.WitnessTypeString},
+	)
+}
+
+func DecodePermitWitnessTransferFrom(calldata []byte, offset int) (PermitWitnessTransferFrom, error) {
+	//                            ┌─ TokenPermissions ┐ nonce deadline ┌─ TransferDetails ┐ owner witness typeString sig
+	// permitWitnessTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes32,string,bytes)
+	var p PermitWitnessTransferFrom
+	if err := requireLen(calldata, offset, 0x140); err != nil {
+		return p, err
+	}
+
+	typeString, err := hex.Bytes(calldata, offset, 0x100)
+	if err != nil {
+		return p, fmt.Errorf("invalid witness type string; %w", err)
+	}
+	sig, err := hex.Bytes(calldata, offset, 0x120)
+	if err != nil {
+		return p, fmt.Errorf("invalid signature; %w", err)
+	}
+
+	p = PermitWitnessTransferFrom{
+		PermitTransferFrom: decodePermitTransferFrom(calldata, offset),
+		Witness:            common.BytesToHash(calldata[offset+0xe0 : offset+0x100]),
+		WitnessTypeString:  string(typeString),
+	}
+	p.Sig = sig
+	return p, nil
+}
+
+// PermitBatchWitnessTransferFrom Solidity representation
+//
+// see: permit2/src/SignatureTransfer.sol:permitWitnessTransferFrom
+type PermitBatchWitnessTransferFrom struct {
+	PermitBatchTransferFrom
+	Witness           common.Hash `json:"witness"`
+	WitnessTypeString string      `json:"witnessTypeString"`
+}
+
+func (PermitBatchWitnessTransferFrom) Type() Type {
+	return PERMIT_BATCH_WITNESS_TRANSFER_FROM
+}
+
+func (p PermitBatchWitnessTransferFrom) MarshalJSON() ([]byte, error) {
+	type Alias PermitBatchTransferFrom
+	return jsonx.Marshal(&struct {
+		Alias
+		Witness           common.Hash `json:"witness"`
+		WitnessTypeString string      `json:"witnessTypeString"`
+		Type              string      `json:"type"`
+	}{(Alias)(p.PermitBatchTransferFrom), p.Witness, p.WitnessTypeString,
+		PERMIT_BATCH_WITNESS_TRANSFER_FROM.String()})
+}
+
+func (p PermitBatchWitnessTransferFrom) Fields() []field.Field {
+	return append(p.PermitBatchTransferFrom.Fields(),
+		field.Field{Name: "Witness", Value: p.Witness},
+		field.Field{Name: "WitnessTypeString", Value: p.WitnessTypeString},
+	)
+}
+
+func DecodePermitBatchWitnessTransferFrom(calldata []byte, offset int) (PermitBatchWitnessTransferFrom, error) {
+	//                            ┌─ TokenPermissions ─┐ nonce deadline ┌─ TransferDetails ─┐ owner witness typeString sig
+	// permitWitnessTransferFrom(((address,uint256)[],uint256,uint256),(address,uint256)[],address,bytes32,string,bytes)
+	var p PermitBatchWitnessTransferFrom
+	if err := requireLen(calldata, offset, 0xc0); err != nil {
+		return p, err
+	}
+
+	batch, err := decodePermitBatchTransferFrom(calldata, offset)
+	if err != nil {
+		return p, err
+	}
+	typeString, err := hex.Bytes(calldata, offset, 0x80)
+	if err != nil {
+		return p, fmt.Errorf("invalid witness type string; %w", err)
+	}
+	batch.Sig, err = hex.Bytes(calldata, offset, 0xa0)
+	if err != nil {
+		return p, fmt.Errorf("invalid signature; %w", err)
+	}
+
+	p = PermitBatchWitnessTransferFrom{
+		PermitBatchTransferFrom: batch,
+		Witness:                 common.BytesToHash(calldata[offset+0x60 : offset+0x80]),
+		WitnessTypeString:       string(typeString),
+	}
+	return p, nil
+}
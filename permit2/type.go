@@ -0,0 +1,86 @@
+package permit2
+
+import "fmt"
+
+// Type is the function selector of a Permit2 method.
+type Type int64
+
+const (
+	// Handlers found within `permit2/src/AllowanceTransfer.sol`
+	PERMIT                      Type = 0x2b67b570 // permit(address,((address,uint160,uint48,uint48),address,uint256),bytes)
+	PERMIT_BATCH                Type = 0x2a2d80d1 // permit(address,((address,uint160,uint48,uint48)[],address,uint256),bytes)
+	TRANSFER_FROM               Type = 0x36c78516 // transferFrom(address,address,uint160,address)
+	TRANSFER_FROM_BATCH         Type = 0x0d58b1db // transferFrom((address,address,uint160,address)[])
+	APPROVE                     Type = 0x87517c45 // approve(address,address,uint160,uint48)
+	LOCKDOWN                    Type = 0xcc53287f // lockdown((address,address)[])
+	INVALIDATE_NONCES           Type = 0x65d9723c // invalidateNonces(address,address,uint48)
+	INVALIDATE_UNORDERED_NONCES Type = 0x3ff9dcb1 // invalidateUnorderedNonces(uint256,uint256)
+
+	// Handlers found within `permit2/src/SignatureTransfer.sol`
+	PERMIT_TRANSFER_FROM               Type = 0x30f28b7a // permitTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes)
+	PERMIT_BATCH_TRANSFER_FROM         Type = 0xedd9444b // permitTransferFrom(((address,uint256)[],uint256,uint256),(address,uint256)[],address,bytes)
+	PERMIT_WITNESS_TRANSFER_FROM       Type = 0x137c29fe // permitWitnessTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes32,string,bytes)
+	PERMIT_BATCH_WITNESS_TRANSFER_FROM Type = 0xfe8ec1a7 // permitWitnessTransferFrom(((address,uint256)[],uint256,uint256),(address,uint256)[],address,bytes32,string,bytes)
+)
+
+func (t Type) String() string {
+	switch t {
+	case 0x2b67b570:
+		return "PERMIT"
+	case 0x2a2d80d1:
+		return "PERMIT_BATCH"
+	case 0x36c78516:
+		return "TRANSFER_FROM"
+	case 0x0d58b1db:
+		return "TRANSFER_FROM_BATCH"
+	case 0x87517c45:
+		return "APPROVE"
+	case 0xcc53287f:
+		return "LOCKDOWN"
+	case 0x65d9723c:
+		return "INVALIDATE_NONCES"
+	case 0x3ff9dcb1:
+		return "INVALIDATE_UNORDERED_NONCES"
+	case 0x30f28b7a:
+		return "PERMIT_TRANSFER_FROM"
+	case 0xedd9444b:
+		return "PERMIT_BATCH_TRANSFER_FROM"
+	case 0x137c29fe:
+		return "PERMIT_WITNESS_TRANSFER_FROM"
+	case 0xfe8ec1a7:
+		return "PERMIT_BATCH_WITNESS_TRANSFER_FROM"
+	}
+	return "UNKNOWN"
+}
+
+func Parse(i int64) (t Type, err error) {
+	switch i {
+	case 0x2b67b570:
+		t = PERMIT
+	case 0x2a2d80d1:
+		t = PERMIT_BATCH
+	case 0x36c78516:
+		t = TRANSFER_FROM
+	case 0x0d58b1db:
+		t = TRANSFER_FROM_BATCH
+	case 0x87517c45:
+		t = APPROVE
+	case 0xcc53287f:
+		t = LOCKDOWN
+	case 0x65d9723c:
+		t = INVALIDATE_NONCES
+	case 0x3ff9dcb1:
+		t = INVALIDATE_UNORDERED_NONCES
+	case 0x30f28b7a:
+		t = PERMIT_TRANSFER_FROM
+	case 0xedd9444b:
+		t = PERMIT_BATCH_TRANSFER_FROM
+	case 0x137c29fe:
+		t = PERMIT_WITNESS_TRANSFER_FROM
+	case 0xfe8ec1a7:
+		t = PERMIT_BATCH_WITNESS_TRANSFER_FROM
+	default:
+		err = fmt.Errorf("invalid Permit2 method 0x%x", i)
+	}
+	return
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/juztin/unidecode/field"
 )
 
 type Key struct {
@@ -15,6 +17,16 @@ type Key struct {
 	Hooks       common.Address `json:"hooks"`
 }
 
+func (k Key) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Currency0", Value: k.Currency0},
+		{Name: "Currency1", Value: k.Currency1},
+		{Name: "Fee", Value: k.Fee},
+		{Name: "TickSpacing", Value: k.TickSpacing},
+		{Name: "Hooks", Value: k.Hooks},
+	}
+}
+
 func NewKey(currency0, currency1 common.Address, fee *big.Int, tickSpacing *big.Int, hooks common.Address) Key {
 	if currency0.Cmp(currency1) == 1 {
 		currency0, currency1 = currency1, currency0
@@ -36,3 +48,23 @@ func (k Key) ID() common.Hash {
 	b = append(b, common.LeftPadBytes(k.Hooks[:], 0x20)...)
 	return crypto.Keccak256Hash(b)
 }
+
+// V2PairAddress returns the CREATE2 address of the UniswapV2Pair for tokenA and tokenB.
+func V2PairAddress(factory common.Address, initCodeHash common.Hash, tokenA, tokenB common.Address) common.Address {
+	if tokenA.Cmp(tokenB) == 1 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	salt := crypto.Keccak256Hash(tokenA[:], tokenB[:])
+	return crypto.CreateAddress2(factory, salt, initCodeHash[:])
+}
+
+// V3PoolAddress returns the CREATE2 address of the UniswapV3Pool for tokenA, tokenB and fee.
+func V3PoolAddress(factory common.Address, initCodeHash common.Hash, tokenA, tokenB common.Address, fee uint32) common.Address {
+	if tokenA.Cmp(tokenB) == 1 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	b := common.LeftPadBytes(tokenA[:], 0x20)
+	b = append(b, common.LeftPadBytes(tokenB[:], 0x20)...)
+	b = append(b, common.LeftPadBytes(new(big.Int).SetUint64(uint64(fee)).Bytes(), 0x20)...)
+	return crypto.CreateAddress2(factory, crypto.Keccak256Hash(b), initCodeHash[:])
+}
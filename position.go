@@ -0,0 +1,70 @@
+package unidecode
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/hex"
+)
+
+var (
+	// initializePool((address,address,uint24,int24,address),uint160) | keccak256
+	initializePoolSig = []byte{0xf7, 0x02, 0x04, 0x05}
+
+	initializePoolSigStr                 = fmt.Sprintf("%x", initializePoolSig)
+	modifyLiquiditiesSigStr              = fmt.Sprintf("%x", commands.ModifyLiquiditiesSig)
+	modifyLiquiditiesWithoutUnlockSigStr = fmt.Sprintf("%x", commands.ModifyLiquiditiesWithoutUnlockSig)
+)
+
+// DecodePositionManager decodes calldata sent directly to the V4 PositionManager.
+//
+// Supports `modifyLiquidities`, `modifyLiquiditiesWithoutUnlock` and `initializePool`, along with a `multicall` of
+// them. Each call is returned as its equivalent Universal Router command.
+func DecodePositionManager(calldata []byte) (Execute, error) {
+	var e Execute
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+
+	sig := hex.MethodSig(calldata)
+	if sig == nil {
+		return e, ErrInvalidCallData
+	} else if fmt.Sprintf("%x", sig) != multicallSigStr {
+		c, err := decodePositionManagerCall(calldata)
+		if err != nil {
+			return e, err
+		}
+		e.Commands = append(e.Commands, c)
+		return e, nil
+	}
+
+	calls, err := decodeCalls(calldata, 0x04, 0x00)
+	if err != nil {
+		return e, fmt.Errorf("invalid multicall data; %w", err)
+	}
+	for i, call := range calls {
+		c, err := decodePositionManagerCall(call)
+		if err != nil {
+			return e, fmt.Errorf("invalid multicall data at index %d; %w", i, err)
+		}
+		e.Commands = append(e.Commands, c)
+	}
+	return e, nil
+}
+
+func decodePositionManagerCall(calldata []byte) (commands.Command, error) {
+	sig := hex.MethodSig(calldata)
+	switch fmt.Sprintf("%x", sig) {
+	case modifyLiquiditiesSigStr:
+		return commands.DecodeModifyLiquidities(calldata, 0)
+	case modifyLiquiditiesWithoutUnlockSigStr:
+		return commands.DecodeModifyLiquiditiesWithoutUnlock(calldata, 0)
+	case initializePoolSigStr:
+		if len(calldata) < 0x04+0xc0 {
+			return nil, ErrInvalidCallData
+		}
+		return commands.DecodeV4InitializePool(calldata, 0x04)
+	}
+	return nil, fmt.Errorf("%w; unsupported PositionManager method %x", ErrIncorrectMethodSig, sig)
+}
@@ -0,0 +1,320 @@
+package unidecode
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/events"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/path"
+	"github.com/juztin/unidecode/registry"
+)
+
+// ETH is the token address balance changes use for native ETH.
+var ETH = common.Address{}
+
+// BalanceChange is the net change of an account's token balance over a transaction.
+type BalanceChange struct {
+	Token  common.Address `json:"token"`
+	Amount *big.Int       `json:"amount"`
+}
+
+// SwapOutcome is the realised result of a swap, compared against the limits of its decoded call.
+type SwapOutcome struct {
+	TokenIn   common.Address `json:"tokenIn"`
+	AmountIn  *big.Int       `json:"amountIn"`
+	TokenOut  common.Address `json:"tokenOut"`
+	AmountOut *big.Int       `json:"amountOut"`
+	// Price is AmountOut per AmountIn, in each token's smallest unit.
+	Price *big.Float `json:"price"`
+	// AmountOutMin is the limit of exact input swaps, and AmountInMax that of exact output swaps.
+	AmountOutMin *big.Int `json:"amountOutMin,omitempty"`
+	AmountInMax  *big.Int `json:"amountInMax,omitempty"`
+	// Slippage is the fraction the swap beat its limit by, being how much further the price could have moved before
+	// the swap reverted; (AmountOut-AmountOutMin)/AmountOut for exact input, and (AmountInMax-AmountIn)/AmountIn for
+	// exact output swaps.
+	Slippage *big.Float `json:"slippage,omitempty"`
+}
+
+// HopOutcome is the Swap log of a V2 or V3 pool, attributed to the decoded swap hop through the pool.
+type HopOutcome struct {
+	// Path is the location of the hop within the decoded call, such as commands[0].path[1]. The hops of V2 paths are
+	// located by their first token.
+	Path      string         `json:"path"`
+	Pool      common.Address `json:"pool"`
+	TokenIn   common.Address `json:"tokenIn"`
+	AmountIn  *big.Int       `json:"amountIn"`
+	TokenOut  common.Address `json:"tokenOut"`
+	AmountOut *big.Int       `json:"amountOut"`
+}
+
+// Outcome is a transaction receipt, decoded and reconciled against the transaction's decoded call.
+type Outcome struct {
+	Status   uint64          `json:"status"`
+	GasUsed  uint64          `json:"gasUsed"`
+	Events   []events.Event  `json:"events"`
+	Balances []BalanceChange `json:"balances"`
+	Hops     []HopOutcome    `json:"hops,omitempty"`
+	Swap     *SwapOutcome    `json:"swap,omitempty"`
+}
+
+func (o Outcome) MarshalJSON() ([]byte, error) {
+	type Alias Outcome
+	return jsonx.Marshal((Alias)(o))
+}
+
+// Reconcile decodes the receipt of a transaction sent by account with value wei on the chain of d, and reconciles the
+// balance changes of account against the swap limits of v, the transaction's decoded call. The Swap logs of V2 and V3
+// pools are attributed to the hops of v through them, by the pool addresses derived from d's factories.
+//
+// Native ETH is tracked through value and the withdrawals of d's WETH, or the mainnet WETH9 when it's unset, which are
+// assumed to be unwrapped to account. ETH sent without a log, such as refunds or native V4 takes, isn't observed.
+func Reconcile(v interface{}, receipt *types.Receipt, d registry.Deployment, account common.Address, value *big.Int) (Outcome, error) {
+	o := Outcome{Status: receipt.Status, GasUsed: receipt.GasUsed}
+	weth := d.WETH
+	if weth == (common.Address{}) {
+		weth = actions.WETH9
+	}
+	var err error
+	o.Events, err = events.DecodeLogs(receipt.Logs, weth)
+	if err != nil {
+		return o, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return o, nil
+	}
+
+	o.Balances = balanceChanges(o.Events, weth, account, value)
+	if execute, ok := v.(Execute); ok {
+		o.Hops = swapHops(execute, o.Events, d)
+	}
+
+	var in, out []BalanceChange
+	for _, b := range o.Balances {
+		if b.Amount.Sign() < 0 {
+			in = append(in, b)
+		} else {
+			out = append(out, b)
+		}
+	}
+	if len(in) != 1 || len(out) != 1 {
+		return o, nil
+	}
+
+	s := &SwapOutcome{
+		TokenIn:   in[0].Token,
+		AmountIn:  new(big.Int).Neg(in[0].Amount),
+		TokenOut:  out[0].Token,
+		AmountOut: out[0].Amount,
+	}
+	s.Price = new(big.Float).Quo(new(big.Float).SetInt(s.AmountOut), new(big.Float).SetInt(s.AmountIn))
+
+	if execute, ok := v.(Execute); ok {
+		s.AmountOutMin, s.AmountInMax = swapLimits(execute)
+	}
+	if s.AmountOutMin != nil {
+		s.Slippage = fraction(new(big.Int).Sub(s.AmountOut, s.AmountOutMin), s.AmountOut)
+	} else if s.AmountInMax != nil {
+		s.Slippage = fraction(new(big.Int).Sub(s.AmountInMax, s.AmountIn), s.AmountIn)
+	}
+	o.Swap = s
+	return o, nil
+}
+
+// balanceChanges returns the non-zero net balance changes of account, ordered by token, wrapping and unwrapping ETH
+// only through weth.
+func balanceChanges(evts []events.Event, weth, account common.Address, value *big.Int) []BalanceChange {
+	deltas := make(map[common.Address]*big.Int)
+	add := func(token common.Address, amount *big.Int) {
+		if _, ok := deltas[token]; !ok {
+			deltas[token] = new(big.Int)
+		}
+		deltas[token].Add(deltas[token], amount)
+	}
+
+	if value != nil && value.Sign() > 0 {
+		add(ETH, new(big.Int).Neg(value))
+	}
+	for _, e := range evts {
+		switch e := e.(type) {
+		case events.Transfer:
+			if e.To == account {
+				add(e.Token, e.Value)
+			}
+			if e.From == account {
+				add(e.Token, new(big.Int).Neg(e.Value))
+			}
+		case events.Deposit:
+			if e.Token != weth {
+				continue
+			} else if e.Dst == account {
+				add(e.Token, e.Wad)
+			}
+		case events.Withdrawal:
+			if e.Token != weth {
+				continue
+			} else if e.Src == account {
+				add(e.Token, new(big.Int).Neg(e.Wad))
+			}
+			add(ETH, e.Wad)
+		}
+	}
+
+	var changes []BalanceChange
+	for token, amount := range deltas {
+		if amount.Sign() != 0 {
+			changes = append(changes, BalanceChange{Token: token, Amount: amount})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Token.Bytes(), changes[j].Token.Bytes()) < 0
+	})
+	return changes
+}
+
+// swapLimits sums the output minimums of the exact input swaps, and the input maximums of the exact output swaps, of
+// execute. Routers may instead enforce the minimum when sweeping or unwrapping the output, so the larger of the two is
+// used.
+func swapLimits(execute Execute) (amountOutMin, amountInMax *big.Int) {
+	swapOutMin, sweepOutMin := new(big.Int), new(big.Int)
+	inMax := new(big.Int)
+	var exactIn, exactOut, unknown bool
+
+	Walk(execute, func(_ Path, node interface{}) error {
+		switch n := node.(type) {
+		case commands.V2SwapExactIn:
+			exactIn = true
+			swapOutMin.Add(swapOutMin, n.AmountOutMin)
+		case commands.V3SwapExactIn:
+			exactIn = true
+			swapOutMin.Add(swapOutMin, n.AmountOutMin)
+		case commands.V2SwapExactOut:
+			exactOut = true
+			// Swaps paid with native ETH are limited by the value, which isn't part of calldata
+			if n.AmountInMin == nil {
+				unknown = true
+				break
+			}
+			inMax.Add(inMax, n.AmountInMin)
+		case commands.V3SwapExactOut:
+			exactOut = true
+			inMax.Add(inMax, n.AmountInMin)
+		case commands.Sweep:
+			sweepOutMin.Add(sweepOutMin, n.AmountMin)
+		case commands.UnwrapWETH:
+			sweepOutMin.Add(sweepOutMin, n.AmountMin)
+		case actions.SwapExactIn:
+			exactIn = true
+			swapOutMin.Add(swapOutMin, n.AmountOutMinimum)
+		case actions.SwapExactInSingle:
+			exactIn = true
+			swapOutMin.Add(swapOutMin, n.AmountOutMinimum)
+		case actions.SwapExactOut:
+			exactOut = true
+			inMax.Add(inMax, n.AmountInMaximum)
+		case actions.SwapExactOutSingle:
+			exactOut = true
+			inMax.Add(inMax, n.AmountInMaximum)
+		}
+		return nil
+	})
+
+	// Mixed exact input and output plans have no single limit
+	if unknown {
+		return nil, nil
+	} else if exactIn && !exactOut {
+		if sweepOutMin.Cmp(swapOutMin) > 0 {
+			return sweepOutMin, nil
+		}
+		return swapOutMin, nil
+	} else if exactOut && !exactIn {
+		return nil, inMax
+	}
+	return nil, nil
+}
+
+// hop is a V2 or V3 pool swapped through by a decoded call, located by the path of the hop.
+type hop struct {
+	path           string
+	token0, token1 common.Address
+}
+
+// swapHops attributes the V2 and V3 Swap logs of evts to the hops of execute through the pools which emitted them.
+func swapHops(execute Execute, evts []events.Event, d registry.Deployment) []HopOutcome {
+	pools := make(map[common.Address]hop)
+	add := func(pool common.Address, p Path, tokenA, tokenB common.Address) {
+		if _, ok := pools[pool]; ok {
+			return
+		}
+		tokenA, tokenB = d.Wrapped(tokenA), d.Wrapped(tokenB)
+		if tokenA.Cmp(tokenB) == 1 {
+			tokenA, tokenB = tokenB, tokenA
+		}
+		pools[pool] = hop{p.String(), tokenA, tokenB}
+	}
+	addV2 := func(p Path, tokens []common.Address) {
+		for i := 1; i < len(tokens); i++ {
+			add(d.V2Pair(tokens[i-1], tokens[i]), p.append("path", i-1), tokens[i-1], tokens[i])
+		}
+	}
+
+	Walk(execute, func(p Path, node interface{}) error {
+		switch n := node.(type) {
+		case commands.V2SwapExactIn:
+			addV2(p, n.Path)
+		case commands.V2SwapExactOut:
+			addV2(p, n.Path)
+		case path.V3Hop:
+			if n.Fee != nil && n.Fee.IsUint64() && n.Fee.Uint64() <= math.MaxUint32 {
+				add(d.V3Pool(n.TokenIn, n.TokenOut, uint32(n.Fee.Uint64())), p, n.TokenIn, n.TokenOut)
+			}
+		}
+		return nil
+	})
+
+	var outcomes []HopOutcome
+	for _, evt := range evts {
+		switch e := evt.(type) {
+		case events.V2Swap:
+			h, ok := pools[e.Pair]
+			if !ok {
+				continue
+			}
+			o := HopOutcome{Path: h.path, Pool: e.Pair}
+			if e.Amount0In.Sign() > 0 {
+				o.TokenIn, o.AmountIn, o.TokenOut, o.AmountOut = h.token0, e.Amount0In, h.token1, e.Amount1Out
+			} else {
+				o.TokenIn, o.AmountIn, o.TokenOut, o.AmountOut = h.token1, e.Amount1In, h.token0, e.Amount0Out
+			}
+			outcomes = append(outcomes, o)
+		case events.V3Swap:
+			h, ok := pools[e.Pool]
+			if !ok {
+				continue
+			}
+			// Positive amounts are received by the pool
+			o := HopOutcome{Path: h.path, Pool: e.Pool}
+			if e.Amount0.Sign() > 0 {
+				o.TokenIn, o.AmountIn, o.TokenOut, o.AmountOut = h.token0, e.Amount0, h.token1, new(big.Int).Neg(e.Amount1)
+			} else {
+				o.TokenIn, o.AmountIn, o.TokenOut, o.AmountOut = h.token1, e.Amount1, h.token0, new(big.Int).Neg(e.Amount0)
+			}
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes
+}
+
+func fraction(n, d *big.Int) *big.Float {
+	if d.Sign() == 0 {
+		return nil
+	}
+	return new(big.Float).Quo(new(big.Float).SetInt(n), new(big.Float).SetInt(d))
+}
@@ -0,0 +1,122 @@
+package registry
+
+import "github.com/ethereum/go-ethereum/common"
+
+const (
+	Mainnet  uint64 = 1
+	Optimism uint64 = 10
+	Unichain uint64 = 130
+	Polygon  uint64 = 137
+	Base     uint64 = 8453
+	Arbitrum uint64 = 42161
+)
+
+var (
+	// Permit2 shares the same CREATE2 address on every chain
+	Permit2 = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+
+	// keccak256(type(UniswapV2Pair).creationCode)
+	v2InitCodeHash = common.HexToHash("0x96e8ac4277198ff8b6f785478aa9a39f403cb768dd02cbee326c3e7da348845f")
+	// keccak256(type(UniswapV3Pool).creationCode)
+	v3InitCodeHash = common.HexToHash("0xe34f199b19b2b4f47f68442619d555527d244f78a3297ea89325f843f87b8b54")
+)
+
+var defaults = []Deployment{
+	{
+		ChainID:           Mainnet,
+		Name:              "mainnet",
+		UniversalRouter:   common.HexToAddress("0x66a9893cC07D91D95644AEDD05D03f95e1dBA8Af"),
+		Permit2:           Permit2,
+		PoolManager:       common.HexToAddress("0x000000000004444c5dc75cB358380D2e3dE08A90"),
+		PositionManager:   common.HexToAddress("0xbD216513d74C8cf14cf4747E6AaA6420FF64ee9e"),
+		V3PositionManager: common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88"),
+		SwapRouter02:      common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		V2Router02:        common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"),
+		WETH:              common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		V2Factory:         common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"),
+		V3Factory:         common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"),
+		V2InitCodeHash:    v2InitCodeHash,
+		V3InitCodeHash:    v3InitCodeHash,
+	},
+	{
+		ChainID:           Optimism,
+		Name:              "optimism",
+		UniversalRouter:   common.HexToAddress("0x851116D9223fabED8E56C0E6b8Ad0c31d98B3507"),
+		Permit2:           Permit2,
+		PoolManager:       common.HexToAddress("0x9a13F98Cb987694C9F086b1F5eB990EeA8264Ec3"),
+		PositionManager:   common.HexToAddress("0x3C3Ea4B57a46241e54610e5f022E5c45859A1017"),
+		V3PositionManager: common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88"),
+		SwapRouter02:      common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		V2Router02:        common.HexToAddress("0x4A7b5Da61326A6379179b40d00F57E5bbDC962c2"),
+		WETH:              common.HexToAddress("0x4200000000000000000000000000000000000006"),
+		V2Factory:         common.HexToAddress("0x0c3c1c532F1e39EdF36BE9Fe0bE1410313E074Bf"),
+		V3Factory:         common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"),
+		V2InitCodeHash:    v2InitCodeHash,
+		V3InitCodeHash:    v3InitCodeHash,
+	},
+	{
+		ChainID:           Unichain,
+		Name:              "unichain",
+		UniversalRouter:   common.HexToAddress("0xEf740bf23aCaE26f6492B10de645D6B98dC8Eaf3"),
+		Permit2:           Permit2,
+		PoolManager:       common.HexToAddress("0x1F98400000000000000000000000000000000004"),
+		PositionManager:   common.HexToAddress("0x4529A01c7A0410167c5740C487A8DE60232617bf"),
+		V3PositionManager: common.HexToAddress("0x943e6e07a7E8E791dAFC44083e54041D743C46E9"),
+		SwapRouter02:      common.HexToAddress("0x73855d06DE49d0fe4A9c42636Ba96c62da12FF9C"),
+		V2Router02:        common.HexToAddress("0x284F11109359a7e1306C3e447ef14D38400063FF"),
+		WETH:              common.HexToAddress("0x4200000000000000000000000000000000000006"),
+		V2Factory:         common.HexToAddress("0x1F98400000000000000000000000000000000002"),
+		V3Factory:         common.HexToAddress("0x1F98400000000000000000000000000000000003"),
+		V2InitCodeHash:    v2InitCodeHash,
+		V3InitCodeHash:    v3InitCodeHash,
+	},
+	{
+		ChainID:           Polygon,
+		Name:              "polygon",
+		UniversalRouter:   common.HexToAddress("0x1095692A6237d83C6a72F3F5eFEdb9A670C49223"),
+		Permit2:           Permit2,
+		PoolManager:       common.HexToAddress("0x67366782805870060151383F4BbFF9daB53e5cD6"),
+		PositionManager:   common.HexToAddress("0x1Ec2eBf4F37E7363FDfe3551602425af0B3ceef9"),
+		V3PositionManager: common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88"),
+		SwapRouter02:      common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		V2Router02:        common.HexToAddress("0xedf6066a2b290C185783862C7F4776A2C8077AD1"),
+		// WPOL
+		WETH:           common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"),
+		V2Factory:      common.HexToAddress("0x9e5A52f57b3038F1B8EeE45F28b3C1967e22799C"),
+		V3Factory:      common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"),
+		V2InitCodeHash: v2InitCodeHash,
+		V3InitCodeHash: v3InitCodeHash,
+	},
+	{
+		ChainID:           Base,
+		Name:              "base",
+		UniversalRouter:   common.HexToAddress("0x6fF5693b99212Da76ad316178A184AB56D299b43"),
+		Permit2:           Permit2,
+		PoolManager:       common.HexToAddress("0x498581fF718922c3f8e6A244956aF099B2652b2b"),
+		PositionManager:   common.HexToAddress("0x7C5f5A4bBd8fD63184577525326123B519429bDc"),
+		V3PositionManager: common.HexToAddress("0x03a520b32C04BF3bEEf7BEb72E919cf822Ed34f1"),
+		SwapRouter02:      common.HexToAddress("0x2626664c2603336E57B271c5C0b26F421741e481"),
+		V2Router02:        common.HexToAddress("0x4752ba5DBc23f44D87826276BF6Fd6b1C372aD24"),
+		WETH:              common.HexToAddress("0x4200000000000000000000000000000000000006"),
+		V2Factory:         common.HexToAddress("0x8909Dc15e40173Ff4699343b6eB8132c65e18eC6"),
+		V3Factory:         common.HexToAddress("0x33128a8fC17869897dcE68Ed026d694621f6FDfD"),
+		V2InitCodeHash:    v2InitCodeHash,
+		V3InitCodeHash:    v3InitCodeHash,
+	},
+	{
+		ChainID:           Arbitrum,
+		Name:              "arbitrum",
+		UniversalRouter:   common.HexToAddress("0xA51afAFe0263b40EdaEf0Df8781eA9aa03E381a3"),
+		Permit2:           Permit2,
+		PoolManager:       common.HexToAddress("0x360E68faCcca8cA495c1B759Fd9EEe466db9FB32"),
+		PositionManager:   common.HexToAddress("0xd88F38F930b7952f2DB2432Cb002E7abbF3dD869"),
+		V3PositionManager: common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88"),
+		SwapRouter02:      common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		V2Router02:        common.HexToAddress("0x4752ba5DBc23f44D87826276BF6Fd6b1C372aD24"),
+		WETH:              common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+		V2Factory:         common.HexToAddress("0xf1D7CC64Fb4452F05c498126312eBE29f30Fbcf9"),
+		V3Factory:         common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"),
+		V2InitCodeHash:    v2InitCodeHash,
+		V3InitCodeHash:    v3InitCodeHash,
+	},
+}
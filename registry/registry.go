@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/pool"
+)
+
+// Deployment holds the Uniswap contract addresses deployed on a single chain.
+//
+// see: https://docs.uniswap.org/contracts/v4/deployments
+type Deployment struct {
+	ChainID           uint64         `json:"chainId"`
+	Name              string         `json:"name"`
+	UniversalRouter   common.Address `json:"universalRouter"`
+	Permit2           common.Address `json:"permit2"`
+	PoolManager       common.Address `json:"poolManager"`
+	PositionManager   common.Address `json:"positionManager"`
+	V3PositionManager common.Address `json:"v3PositionManager"`
+	SwapRouter02      common.Address `json:"swapRouter02"`
+	V2Router02        common.Address `json:"v2Router02"`
+	WETH              common.Address `json:"weth"`
+	V2Factory         common.Address `json:"v2Factory"`
+	V3Factory         common.Address `json:"v3Factory"`
+	V2InitCodeHash    common.Hash    `json:"v2InitCodeHash"`
+	V3InitCodeHash    common.Hash    `json:"v3InitCodeHash"`
+}
+
+// Labels returns each non-zero address of the deployment mapped to its contract name.
+func (d Deployment) Labels() map[common.Address]string {
+	m := make(map[common.Address]string)
+	for _, l := range []struct {
+		addr common.Address
+		name string
+	}{
+		{d.UniversalRouter, "UniversalRouter"},
+		{d.Permit2, "Permit2"},
+		{d.PoolManager, "PoolManager"},
+		{d.PositionManager, "PositionManager"},
+		{d.V3PositionManager, "NonfungiblePositionManager"},
+		{d.SwapRouter02, "SwapRouter02"},
+		{d.V2Router02, "UniswapV2Router02"},
+		{d.WETH, "WETH"},
+		{d.V2Factory, "UniswapV2Factory"},
+		{d.V3Factory, "UniswapV3Factory"},
+	} {
+		if l.addr != (common.Address{}) {
+			m[l.addr] = l.name
+		}
+	}
+	return m
+}
+
+// Label returns the contract name of addr within the deployment.
+func (d Deployment) Label(addr common.Address) (string, bool) {
+	name, ok := d.Labels()[addr]
+	return name, ok
+}
+
+// Wrapped returns WETH when currency is the native currency, otherwise currency.
+//
+// V4 pools reference native ETH as the zero address, whereas V2/V3 pools are always WETH.
+func (d Deployment) Wrapped(currency common.Address) common.Address {
+	if currency == (common.Address{}) {
+		return d.WETH
+	}
+	return currency
+}
+
+// V2Pair returns the UniswapV2Pair address for the given tokens.
+func (d Deployment) V2Pair(tokenA, tokenB common.Address) common.Address {
+	return pool.V2PairAddress(d.V2Factory, d.V2InitCodeHash, d.Wrapped(tokenA), d.Wrapped(tokenB))
+}
+
+// V3Pool returns the UniswapV3Pool address for the given tokens and fee.
+func (d Deployment) V3Pool(tokenA, tokenB common.Address, fee uint32) common.Address {
+	return pool.V3PoolAddress(d.V3Factory, d.V3InitCodeHash, d.Wrapped(tokenA), d.Wrapped(tokenB), fee)
+}
+
+// Registry is a set of deployments keyed by chain ID.
+type Registry struct {
+	deployments map[uint64]Deployment
+}
+
+// New returns a registry containing the given deployments.
+func New(deployments ...Deployment) *Registry {
+	r := &Registry{deployments: make(map[uint64]Deployment)}
+	for _, d := range deployments {
+		r.deployments[d.ChainID] = d
+	}
+	return r
+}
+
+// Default returns a registry populated with the known Uniswap deployments.
+func Default() *Registry {
+	return New(defaults...)
+}
+
+// Get returns the deployment for chainID.
+func (r *Registry) Get(chainID uint64) (Deployment, bool) {
+	d, ok := r.deployments[chainID]
+	return d, ok
+}
+
+// Set adds, or replaces, the deployment for d.ChainID.
+func (r *Registry) Set(d Deployment) {
+	r.deployments[d.ChainID] = d
+}
+
+// Chains returns the chain IDs within the registry, in ascending order.
+func (r *Registry) Chains() []uint64 {
+	var ids []uint64
+	for id := range r.deployments {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Label returns the contract name of addr on chainID, or an empty string when unknown.
+func (r *Registry) Label(chainID uint64, addr common.Address) string {
+	d, ok := r.deployments[chainID]
+	if !ok {
+		return ""
+	}
+	name, _ := d.Label(addr)
+	return name
+}
+
+// Load reads a JSON array of deployments from rd.
+//
+// Entries for a chain already within the registry are overlaid onto the existing
+// deployment, so only the addresses being overridden need to be given.
+func (r *Registry) Load(rd io.Reader) error {
+	var entries []json.RawMessage
+	if err := json.NewDecoder(rd).Decode(&entries); err != nil {
+		return fmt.Errorf("invalid registry; %w", err)
+	}
+
+	for i, entry := range entries {
+		var id struct {
+			ChainID *uint64 `json:"chainId"`
+		}
+		if err := json.Unmarshal(entry, &id); err != nil {
+			return fmt.Errorf("invalid registry entry at index %d; %w", i, err)
+		} else if id.ChainID == nil {
+			return fmt.Errorf("invalid registry entry at index %d; missing chainId", i)
+		}
+
+		d, ok := r.deployments[*id.ChainID]
+		if !ok {
+			d = Deployment{V2InitCodeHash: v2InitCodeHash, V3InitCodeHash: v3InitCodeHash}
+		}
+		if err := json.Unmarshal(entry, &d); err != nil {
+			return fmt.Errorf("invalid registry entry at index %d; %w", i, err)
+		}
+		r.deployments[d.ChainID] = d
+	}
+	return nil
+}
+
+// LoadFile reads a JSON array of deployments from the file at path.
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.Load(f)
+}
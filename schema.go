@@ -0,0 +1,8 @@
+package unidecode
+
+//go:generate sh -c "go run ./cmd/unidecode -schema > schema.json"
+
+// SchemaVersion is the version of the JSON schema of decoded output, published as schema.json. The major version is
+// incremented by changes which may break consumers, such as renamed or removed properties, and the minor version by
+// additions.
+const SchemaVersion = "2.0.0"
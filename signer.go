@@ -0,0 +1,42 @@
+package unidecode
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/eip712"
+	"github.com/juztin/unidecode/permit2"
+)
+
+// Signer recovers the address that signed the Permit2 permit held by v, using the Permit2 contract at permit2Addr on
+// chainID for the EIP-712 domain.
+//
+// Supports `commands.Permit2Permit`, `commands.Permit2PermitBatch`, `permit2.Permit` and `permit2.PermitBatch`.
+func Signer(v interface{}, chainID uint64, permit2Addr common.Address) (common.Address, error) {
+	domain := eip712.Permit2Domain(chainID, permit2Addr)
+
+	switch p := v.(type) {
+	case commands.Permit2Permit:
+		return eip712.Recover(domain.Digest(eip712.HashPermitSingle(p.Details, p.Spender, p.SigDeadline)), p.Sig)
+	case commands.Permit2PermitBatch:
+		return eip712.Recover(domain.Digest(eip712.HashPermitBatch(p.Details, p.Spender, p.SigDeadline)), p.Sig)
+	case permit2.Permit:
+		return eip712.Recover(domain.Digest(eip712.HashPermitSingle(p.Details, p.Spender, p.SigDeadline)), p.Sig)
+	case permit2.PermitBatch:
+		return eip712.Recover(domain.Digest(eip712.HashPermitBatch(p.Details, p.Spender, p.SigDeadline)), p.Sig)
+	}
+	return common.Address{}, fmt.Errorf("unsupported permit type %T", v)
+}
+
+// V3PermitSigner recovers the address that signed a NonfungiblePositionManager permit, using the position manager at
+// npm on chainID for the EIP-712 domain.
+//
+// The nonce is the position's nonce prior to the permit being used, as returned by `positions(tokenId)`.
+func V3PermitSigner(p commands.V3PositionManagerPermit, chainID uint64, npm common.Address, nonce *big.Int) (common.Address, error) {
+	domain := eip712.V3PositionsDomain(chainID, npm)
+	digest := domain.Digest(eip712.HashV3Permit(p.Spender, p.Amount, nonce, p.Deadline))
+	return eip712.Recover(digest, p.Sig.Bytes())
+}
@@ -0,0 +1,396 @@
+package unidecode
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/path"
+)
+
+// SwapRouter02
+//
+// see: swap-router-contracts/contracts/SwapRouter02.sol
+var (
+	// exactInputSingle((address,address,uint24,address,uint256,uint256,uint160)) | keccak256
+	exactInputSingleSig = []byte{0x04, 0xe4, 0x5a, 0xaf}
+	// exactInput((bytes,address,uint256,uint256)) | keccak256
+	exactInputSig = []byte{0xb8, 0x58, 0x18, 0x3f}
+	// exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160)) | keccak256
+	exactOutputSingleSig = []byte{0x50, 0x23, 0xb4, 0xdf}
+	// exactOutput((bytes,address,uint256,uint256)) | keccak256
+	exactOutputSig = []byte{0x09, 0xb8, 0x13, 0x46}
+	// swapExactTokensForTokens(uint256,uint256,address[],address) | keccak256
+	v2ExactInSig = []byte{0x47, 0x2b, 0x43, 0xf3}
+	// swapTokensForExactTokens(uint256,uint256,address[],address) | keccak256
+	v2ExactOutSig = []byte{0x42, 0x71, 0x2a, 0x67}
+	// multicall(uint256,bytes[]) | keccak256
+	multicallDeadlineSig = []byte{0x5a, 0xe4, 0x01, 0xdc}
+	// multicall(bytes32,bytes[]) | keccak256
+	multicallBlockHashSig = []byte{0x1f, 0x04, 0x64, 0xd1}
+
+	exactInputSingleSigStr   = fmt.Sprintf("%x", exactInputSingleSig)
+	exactInputSigStr         = fmt.Sprintf("%x", exactInputSig)
+	exactOutputSingleSigStr  = fmt.Sprintf("%x", exactOutputSingleSig)
+	exactOutputSigStr        = fmt.Sprintf("%x", exactOutputSig)
+	v2ExactInSigStr          = fmt.Sprintf("%x", v2ExactInSig)
+	v2ExactOutSigStr         = fmt.Sprintf("%x", v2ExactOutSig)
+	multicallDeadlineSigStr  = fmt.Sprintf("%x", multicallDeadlineSig)
+	multicallBlockHashSigStr = fmt.Sprintf("%x", multicallBlockHashSig)
+)
+
+// UniswapV2Router02
+//
+// see: v2-periphery/contracts/UniswapV2Router02.sol
+var (
+	// swapExactTokensForTokens(uint256,uint256,address[],address,uint256) | keccak256
+	swapExactTokensForTokensSig = []byte{0x38, 0xed, 0x17, 0x39}
+	// swapTokensForExactTokens(uint256,uint256,address[],address,uint256) | keccak256
+	swapTokensForExactTokensSig = []byte{0x88, 0x03, 0xdb, 0xee}
+	// swapExactETHForTokens(uint256,address[],address,uint256) | keccak256
+	swapExactETHForTokensSig = []byte{0x7f, 0xf3, 0x6a, 0xb5}
+	// swapTokensForExactETH(uint256,uint256,address[],address,uint256) | keccak256
+	swapTokensForExactETHSig = []byte{0x4a, 0x25, 0xd9, 0x4a}
+	// swapExactTokensForETH(uint256,uint256,address[],address,uint256) | keccak256
+	swapExactTokensForETHSig = []byte{0x18, 0xcb, 0xaf, 0xe5}
+	// swapETHForExactTokens(uint256,address[],address,uint256) | keccak256
+	swapETHForExactTokensSig = []byte{0xfb, 0x3b, 0xdb, 0x41}
+	// swapExactTokensForTokensSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256) | keccak256
+	swapExactTokensForTokensFeeSig = []byte{0x5c, 0x11, 0xd7, 0x95}
+	// swapExactETHForTokensSupportingFeeOnTransferTokens(uint256,address[],address,uint256) | keccak256
+	swapExactETHForTokensFeeSig = []byte{0xb6, 0xf9, 0xde, 0x95}
+	// swapExactTokensForETHSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256) | keccak256
+	swapExactTokensForETHFeeSig = []byte{0x79, 0x1a, 0xc9, 0x47}
+
+	swapExactTokensForTokensSigStr    = fmt.Sprintf("%x", swapExactTokensForTokensSig)
+	swapTokensForExactTokensSigStr    = fmt.Sprintf("%x", swapTokensForExactTokensSig)
+	swapExactETHForTokensSigStr       = fmt.Sprintf("%x", swapExactETHForTokensSig)
+	swapTokensForExactETHSigStr       = fmt.Sprintf("%x", swapTokensForExactETHSig)
+	swapExactTokensForETHSigStr       = fmt.Sprintf("%x", swapExactTokensForETHSig)
+	swapETHForExactTokensSigStr       = fmt.Sprintf("%x", swapETHForExactTokensSig)
+	swapExactTokensForTokensFeeSigStr = fmt.Sprintf("%x", swapExactTokensForTokensFeeSig)
+	swapExactETHForTokensFeeSigStr    = fmt.Sprintf("%x", swapExactETHForTokensFeeSig)
+	swapExactTokensForETHFeeSigStr    = fmt.Sprintf("%x", swapExactTokensForETHFeeSig)
+)
+
+// isSwapRouter02Sig reports whether sig is one of the SwapRouter02's swap methods.
+func isSwapRouter02Sig(sig string) bool {
+	switch sig {
+	case exactInputSingleSigStr, exactInputSigStr, exactOutputSingleSigStr, exactOutputSigStr,
+		v2ExactInSigStr, v2ExactOutSigStr, multicallDeadlineSigStr, multicallBlockHashSigStr:
+		return true
+	}
+	return false
+}
+
+// isV2RouterSig reports whether sig is one of the UniswapV2Router02's swap methods.
+func isV2RouterSig(sig string) bool {
+	switch sig {
+	case swapExactTokensForTokensSigStr, swapTokensForExactTokensSigStr, swapExactETHForTokensSigStr,
+		swapTokensForExactETHSigStr, swapExactTokensForETHSigStr, swapETHForExactTokensSigStr,
+		swapExactTokensForTokensFeeSigStr, swapExactETHForTokensFeeSigStr, swapExactTokensForETHFeeSigStr:
+		return true
+	}
+	return false
+}
+
+// DecodeSwapRouter02 decodes calldata sent to the V3 SwapRouter02.
+//
+// Supports the `exactInput*`/`exactOutput*` and V2 `swap*` methods, each `multicall` variant and the extended
+// payment methods. Each call is returned as its equivalent Universal Router command.
+func DecodeSwapRouter02(calldata []byte) (Execute, error) {
+	var e Execute
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+
+	sig := hex.MethodSig(calldata)
+	if sig == nil {
+		return e, ErrInvalidCallData
+	}
+
+	var (
+		calls [][]byte
+		err   error
+	)
+	switch fmt.Sprintf("%x", sig) {
+	case multicallSigStr:
+		calls, err = decodeCalls(calldata, 0x04, 0x00)
+	case multicallDeadlineSigStr:
+		if len(calldata) < 0x44 {
+			return e, ErrInvalidCallData
+		}
+		e.Deadline = decodeDeadline(calldata[0x04:0x24])
+		calls, err = decodeCalls(calldata, 0x04, 0x20)
+	case multicallBlockHashSigStr:
+		// The previous block hash is only checked on-chain
+		calls, err = decodeCalls(calldata, 0x04, 0x20)
+	default:
+		c, err := decodeSwapRouter02Call(calldata)
+		if err != nil {
+			return e, err
+		}
+		e.Commands = append(e.Commands, c)
+		return e, nil
+	}
+	if err != nil {
+		return e, fmt.Errorf("invalid multicall data; %w", err)
+	}
+
+	for i, call := range calls {
+		c, err := decodeSwapRouter02Call(call)
+		if err != nil {
+			return e, fmt.Errorf("invalid multicall data at index %d; %w", i, err)
+		}
+		e.Commands = append(e.Commands, c)
+	}
+	return e, nil
+}
+
+func decodeSwapRouter02Call(calldata []byte) (commands.Command, error) {
+	sig := hex.MethodSig(calldata)
+	switch fmt.Sprintf("%x", sig) {
+	case exactInputSingleSigStr:
+		// exactInputSingle((tokenIn, tokenOut, fee, recipient, amountIn, amountOutMinimum, sqrtPriceLimitX96))
+		if len(calldata) < 0xe4 {
+			return nil, ErrInvalidCallData
+		}
+		amountIn := new(big.Int).SetBytes(calldata[0x84:0xa4])
+		return commands.V3SwapExactIn{
+			Recipient:    common.BytesToAddress(calldata[0x64:0x84]),
+			AmountIn:     amountIn,
+			AmountOutMin: new(big.Int).SetBytes(calldata[0xa4:0xc4]),
+			Path: []path.V3Hop{{
+				TokenIn:  common.BytesToAddress(calldata[0x04:0x24]),
+				Fee:      new(big.Int).SetBytes(calldata[0x44:0x64]),
+				TokenOut: common.BytesToAddress(calldata[0x24:0x44]),
+			}},
+			// An amountIn of zero swaps the router's own balance
+			PayerIsUser:       amountIn.Sign() != 0,
+			SqrtPriceLimitX96: new(big.Int).SetBytes(calldata[0xc4:0xe4]),
+		}, nil
+	case exactOutputSingleSigStr:
+		// exactOutputSingle((tokenIn, tokenOut, fee, recipient, amountOut, amountInMaximum, sqrtPriceLimitX96))
+		if len(calldata) < 0xe4 {
+			return nil, ErrInvalidCallData
+		}
+		return commands.V3SwapExactOut{
+			Recipient:   common.BytesToAddress(calldata[0x64:0x84]),
+			AmountOut:   new(big.Int).SetBytes(calldata[0x84:0xa4]),
+			AmountInMin: new(big.Int).SetBytes(calldata[0xa4:0xc4]),
+			// Encoded in reverse to match exact output paths
+			Path: []path.V3Hop{{
+				TokenIn:  common.BytesToAddress(calldata[0x24:0x44]),
+				Fee:      new(big.Int).SetBytes(calldata[0x44:0x64]),
+				TokenOut: common.BytesToAddress(calldata[0x04:0x24]),
+			}},
+			PayerIsUser:       true,
+			SqrtPriceLimitX96: new(big.Int).SetBytes(calldata[0xc4:0xe4]),
+		}, nil
+	case exactInputSigStr:
+		// exactInput((path, recipient, amountIn, amountOutMinimum))
+		start, hops, err := decodeExactPath(calldata)
+		if err != nil {
+			return nil, err
+		}
+		amountIn := new(big.Int).SetBytes(calldata[start+0x40 : start+0x60])
+		return commands.V3SwapExactIn{
+			Recipient:    common.BytesToAddress(calldata[start+0x20 : start+0x40]),
+			AmountIn:     amountIn,
+			AmountOutMin: new(big.Int).SetBytes(calldata[start+0x60 : start+0x80]),
+			Path:         hops,
+			PayerIsUser:  amountIn.Sign() != 0,
+		}, nil
+	case exactOutputSigStr:
+		// exactOutput((path, recipient, amountOut, amountInMaximum))
+		start, hops, err := decodeExactPath(calldata)
+		if err != nil {
+			return nil, err
+		}
+		return commands.V3SwapExactOut{
+			Recipient:   common.BytesToAddress(calldata[start+0x20 : start+0x40]),
+			AmountOut:   new(big.Int).SetBytes(calldata[start+0x40 : start+0x60]),
+			AmountInMin: new(big.Int).SetBytes(calldata[start+0x60 : start+0x80]),
+			Path:        hops,
+			PayerIsUser: true,
+		}, nil
+	case v2ExactInSigStr:
+		// swapExactTokensForTokens(amountIn, amountOutMin, path, to)
+		tokens, err := decodeV2RouterPath(calldata, 0x40, 0x80)
+		if err != nil {
+			return nil, err
+		}
+		amountIn := new(big.Int).SetBytes(calldata[0x04:0x24])
+		return commands.V2SwapExactIn{
+			Recipient:    common.BytesToAddress(calldata[0x64:0x84]),
+			AmountIn:     amountIn,
+			AmountOutMin: new(big.Int).SetBytes(calldata[0x24:0x44]),
+			Path:         tokens,
+			PayerIsUser:  amountIn.Sign() != 0,
+		}, nil
+	case v2ExactOutSigStr:
+		// swapTokensForExactTokens(amountOut, amountInMax, path, to)
+		tokens, err := decodeV2RouterPath(calldata, 0x40, 0x80)
+		if err != nil {
+			return nil, err
+		}
+		return commands.V2SwapExactOut{
+			Recipient:   common.BytesToAddress(calldata[0x64:0x84]),
+			AmountOut:   new(big.Int).SetBytes(calldata[0x04:0x24]),
+			AmountInMin: new(big.Int).SetBytes(calldata[0x24:0x44]),
+			Path:        tokens,
+			PayerIsUser: true,
+		}, nil
+	}
+
+	c, ok, err := decodePeripheryPayment(calldata)
+	if ok {
+		return c, err
+	}
+	return nil, fmt.Errorf("%w; unsupported SwapRouter02 method %x", ErrIncorrectMethodSig, sig)
+}
+
+// decodeExactPath decodes the `bytes path` of an `exactInput`/`exactOutput` params tuple, returning the tuple's
+// location along with the path.
+func decodeExactPath(calldata []byte) (int, []path.V3Hop, error) {
+	if len(calldata) < 0x24 {
+		return 0, nil, ErrInvalidCallData
+	}
+	loc, err := hex.Int(calldata[0x04:0x24])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid params location; %w", err)
+	}
+	if loc > len(calldata)-0x84 {
+		return 0, nil, fmt.Errorf("%w; params exceed calldata bounds", ErrInvalidCallData)
+	}
+	start := 0x04 + loc
+
+	b, err := hex.Bytes(calldata, start, 0x00)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid path; %w", err)
+	}
+	hops, err := path.DecodeV3(b)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid path; %w", err)
+	}
+	return start, hops, nil
+}
+
+// decodeV2RouterPath decodes the `address[] path` whose location is stored at head, after ensuring the calldata
+// holds at least size bytes of parameters.
+func decodeV2RouterPath(calldata []byte, head, size int) ([]common.Address, error) {
+	if len(calldata) < 0x04+size {
+		return nil, ErrInvalidCallData
+	}
+	loc, err := hex.Int(calldata[0x04+head : 0x04+head+0x20])
+	if err != nil {
+		return nil, fmt.Errorf("invalid path location; %w", err)
+	} else if loc > len(calldata)-0x24 {
+		return nil, fmt.Errorf("%w; path exceeds calldata bounds", ErrInvalidCallData)
+	}
+	tokens, err := path.DecodeV2(calldata, 0x04+loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path; %w", err)
+	}
+	return tokens, nil
+}
+
+// DecodeV2Router decodes calldata sent to the UniswapV2Router02.
+//
+// Each `swap*` method is returned as its equivalent Universal Router commands. Methods paid with native ETH wrap the
+// transaction value with WRAP_ETH before swapping, so their WRAP_ETH amount and any amount paid with ETH is nil as it's
+// the value, which isn't part of calldata. Methods paid out in native ETH swap to the router, then UNWRAP_WETH to the
+// recipient.
+func DecodeV2Router(calldata []byte) (Execute, error) {
+	var e Execute
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+
+	sig := hex.MethodSig(calldata)
+	if sig == nil {
+		return e, ErrInvalidCallData
+	}
+
+	switch sigStr := fmt.Sprintf("%x", sig); sigStr {
+	case swapExactTokensForTokensSigStr, swapExactTokensForETHSigStr,
+		swapExactTokensForTokensFeeSigStr, swapExactTokensForETHFeeSigStr:
+		// (amountIn, amountOutMin, path, to, deadline)
+		tokens, err := decodeV2RouterPath(calldata, 0x40, 0xa0)
+		if err != nil {
+			return e, err
+		}
+		e.Deadline = decodeDeadline(calldata[0x84:0xa4])
+		swap := commands.V2SwapExactIn{
+			Recipient:    common.BytesToAddress(calldata[0x64:0x84]),
+			AmountIn:     new(big.Int).SetBytes(calldata[0x04:0x24]),
+			AmountOutMin: new(big.Int).SetBytes(calldata[0x24:0x44]),
+			Path:         tokens,
+			PayerIsUser:  true,
+		}
+		if sigStr != swapExactTokensForETHSigStr && sigStr != swapExactTokensForETHFeeSigStr {
+			e.Commands = append(e.Commands, swap)
+			break
+		}
+		swap.Recipient = commands.ADDRESS_THIS
+		e.Commands = append(e.Commands, swap, commands.UnwrapWETH{
+			Recipient: common.BytesToAddress(calldata[0x64:0x84]),
+			AmountMin: swap.AmountOutMin,
+		})
+	case swapTokensForExactTokensSigStr, swapTokensForExactETHSigStr:
+		// (amountOut, amountInMax, path, to, deadline)
+		tokens, err := decodeV2RouterPath(calldata, 0x40, 0xa0)
+		if err != nil {
+			return e, err
+		}
+		e.Deadline = decodeDeadline(calldata[0x84:0xa4])
+		swap := commands.V2SwapExactOut{
+			Recipient:   common.BytesToAddress(calldata[0x64:0x84]),
+			AmountOut:   new(big.Int).SetBytes(calldata[0x04:0x24]),
+			AmountInMin: new(big.Int).SetBytes(calldata[0x24:0x44]),
+			Path:        tokens,
+			PayerIsUser: true,
+		}
+		if sigStr != swapTokensForExactETHSigStr {
+			e.Commands = append(e.Commands, swap)
+			break
+		}
+		swap.Recipient = commands.ADDRESS_THIS
+		e.Commands = append(e.Commands, swap, commands.UnwrapWETH{
+			Recipient: common.BytesToAddress(calldata[0x64:0x84]),
+			AmountMin: swap.AmountOut,
+		})
+	case swapExactETHForTokensSigStr, swapExactETHForTokensFeeSigStr:
+		// (amountOutMin, path, to, deadline)
+		tokens, err := decodeV2RouterPath(calldata, 0x20, 0x80)
+		if err != nil {
+			return e, err
+		}
+		e.Deadline = decodeDeadline(calldata[0x64:0x84])
+		e.Commands = append(e.Commands, commands.WrapWETH{Recipient: commands.ADDRESS_THIS}, commands.V2SwapExactIn{
+			Recipient:    common.BytesToAddress(calldata[0x44:0x64]),
+			AmountOutMin: new(big.Int).SetBytes(calldata[0x04:0x24]),
+			Path:         tokens,
+		})
+	case swapETHForExactTokensSigStr:
+		// (amountOut, path, to, deadline)
+		tokens, err := decodeV2RouterPath(calldata, 0x20, 0x80)
+		if err != nil {
+			return e, err
+		}
+		e.Deadline = decodeDeadline(calldata[0x64:0x84])
+		// Any of the value left after the swap is refunded to the sender
+		e.Commands = append(e.Commands, commands.WrapWETH{Recipient: commands.ADDRESS_THIS}, commands.V2SwapExactOut{
+			Recipient: common.BytesToAddress(calldata[0x44:0x64]),
+			AmountOut: new(big.Int).SetBytes(calldata[0x04:0x24]),
+			Path:      tokens,
+		})
+	default:
+		return e, fmt.Errorf("%w; unsupported UniswapV2Router02 method %x", ErrIncorrectMethodSig, sig)
+	}
+	return e, nil
+}
@@ -0,0 +1,170 @@
+package unidecode
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/path"
+	"github.com/juztin/unidecode/pool"
+)
+
+// Tokens returns each token referenced by the commands, including those of sub-plans, and their actions, of e in order
+// of first reference, where native ETH is the zero address.
+//
+// WRAP_ETH and UNWRAP_WETH commands reference WETH implicitly, so it's only included when referenced elsewhere.
+func (e Execute) Tokens() []common.Address {
+	var tokens []common.Address
+	seen := make(map[common.Address]bool)
+	add := func(addrs ...common.Address) {
+		for _, a := range addrs {
+			if !seen[a] {
+				seen[a] = true
+				tokens = append(tokens, a)
+			}
+		}
+	}
+
+	for _, cmd := range commands.Flatten(e.Commands) {
+		switch c := cmd.(type) {
+		case commands.V2SwapExactIn:
+			add(c.Path...)
+		case commands.V2SwapExactOut:
+			add(c.Path...)
+		case commands.V3SwapExactIn:
+			for _, hop := range c.Path {
+				add(hop.TokenIn, hop.TokenOut)
+			}
+		case commands.V3SwapExactOut:
+			for _, hop := range c.Path {
+				add(hop.TokenIn, hop.TokenOut)
+			}
+		case commands.V4InitializePool:
+			add(c.Key.Currency0, c.Key.Currency1)
+		case commands.Sweep:
+			add(c.Token)
+		case commands.Transfer:
+			add(c.Token)
+		case commands.PayPortion:
+			add(c.Token)
+		case commands.BalanceCheckERC20:
+			add(c.Token)
+		case commands.Permit2Permit:
+			add(c.Details.Token)
+		case commands.Permit2PermitBatch:
+			for _, d := range c.Details {
+				add(d.Token)
+			}
+		case commands.Permit2TransferFrom:
+			add(c.Token)
+		case commands.Permit2TransferFromBatch:
+			for _, d := range c.Details {
+				add(d.Token)
+			}
+		}
+
+		for _, action := range cmd.Actions() {
+			switch a := action.(type) {
+			case actions.SwapExactIn:
+				add(a.CurrencyIn)
+				for _, k := range a.Path {
+					add(k.IntermediateCurrency)
+				}
+			case actions.SwapExactOut:
+				for _, k := range a.Path {
+					add(k.IntermediateCurrency)
+				}
+				add(a.CurrencyOut)
+			case actions.SwapExactInSingle:
+				add(a.PoolKey.Currency0, a.PoolKey.Currency1)
+			case actions.SwapExactOutSingle:
+				add(a.PoolKey.Currency0, a.PoolKey.Currency1)
+			case actions.MintPosition:
+				add(a.PoolKey.Currency0, a.PoolKey.Currency1)
+			case actions.MintPositionFromDeltas:
+				add(a.PoolKey.Currency0, a.PoolKey.Currency1)
+			case actions.Settle:
+				add(a.Currency)
+			case actions.SettleAll:
+				add(a.Currency)
+			case actions.SettlePair:
+				add(a.Currency0, a.Currency1)
+			case actions.Take:
+				add(a.Currency)
+			case actions.TakeAll:
+				add(a.Currency)
+			case actions.TakePortion:
+				add(a.Currency)
+			case actions.TakePair:
+				add(a.Currency0, a.Currency1)
+			case actions.CloseCurrency:
+				add(a.Currency)
+			case actions.ClearOrTake:
+				add(a.Currency)
+			case actions.Sweep:
+				add(a.Currency)
+			case actions.Wrap:
+				add(a.Currency, a.Wrapped)
+			case actions.Unwrap:
+				add(a.Wrapped, a.Currency)
+			case actions.V3Mint:
+				add(a.Token0, a.Token1)
+			case actions.V3CreateAndInitializePool:
+				add(a.Token0, a.Token1)
+			case actions.V3SelfPermit:
+				add(a.Token)
+			case actions.V3SelfPermitAllowed:
+				add(a.Token)
+			}
+		}
+	}
+	return tokens
+}
+
+// PoolIDs returns the ID of each V4 pool referenced by e, in order of first reference.
+//
+// The pools of multi-hop swaps are resolved from each swap's path.
+func (e Execute) PoolIDs() []common.Hash {
+	var ids []common.Hash
+	seen := make(map[common.Hash]bool)
+	add := func(k pool.Key) {
+		if id := k.ID(); !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, cmd := range commands.Flatten(e.Commands) {
+		if c, ok := cmd.(commands.V4InitializePool); ok {
+			add(c.Key)
+		}
+		for _, action := range cmd.Actions() {
+			switch a := action.(type) {
+			case actions.SwapExactIn:
+				currency := a.CurrencyIn
+				for _, k := range a.Path {
+					key, _ := path.PoolAndSwapDirection(k, currency)
+					add(key)
+					currency = k.IntermediateCurrency
+				}
+			case actions.SwapExactOut:
+				// Exact output paths are walked backwards from the currency bought
+				currency := a.CurrencyOut
+				for i := len(a.Path) - 1; i >= 0; i-- {
+					key, _ := path.PoolAndSwapDirection(a.Path[i], currency)
+					add(key)
+					currency = a.Path[i].IntermediateCurrency
+				}
+			case actions.SwapExactInSingle:
+				add(a.PoolKey)
+			case actions.SwapExactOutSingle:
+				add(a.PoolKey)
+			case actions.MintPosition:
+				add(a.PoolKey)
+			case actions.MintPositionFromDeltas:
+				add(a.PoolKey)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,119 @@
+package unidecode
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+// CallFrame is a frame of the callTracer, as returned by `debug_traceTransaction`.
+//
+// see: https://geth.ethereum.org/docs/developers/evm-tracing/built-in-tracers#call-tracer
+type CallFrame struct {
+	Type   string          `json:"type"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to,omitempty"`
+	Value  *hexutil.Big    `json:"value,omitempty"`
+	Input  hexutil.Bytes   `json:"input"`
+	Output hexutil.Bytes   `json:"output,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Calls  []CallFrame     `json:"calls,omitempty"`
+}
+
+// TracedCall is a decoded call to a known contract, found within a call trace.
+type TracedCall struct {
+	// Path is the index of the frame within each of its parents, starting below the top-level call.
+	Path     []int          `json:"path"`
+	Depth    int            `json:"depth"`
+	CallType string         `json:"callType"`
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Value    *big.Int       `json:"value,omitempty"`
+	Contract string         `json:"contract"`
+	Message  string         `json:"message"`
+	Call     interface{}    `json:"call,omitempty"`
+	// DecodeError is set when the calldata of a known message couldn't be decoded.
+	DecodeError string `json:"decodeError,omitempty"`
+	// Error is set when the call reverted, with its decoded revert data when known.
+	Error string `json:"error,omitempty"`
+}
+
+func (c TracedCall) MarshalJSON() ([]byte, error) {
+	type Alias TracedCall
+	return jsonx.Marshal((Alias)(c))
+}
+
+// DecodeCall decodes calldata sent to any of the supported contracts.
+func DecodeCall(calldata []byte) (interface{}, error) {
+	switch MessageType(calldata) {
+	case UnknownMessage:
+		return nil, ErrIncorrectMethodSig
+	case Permit2Message:
+		return DecodePermit2(calldata)
+	case UniswapXMessage:
+		return DecodeUniswapX(calldata)
+	case WalletMessage:
+		return DecodeWallet(calldata)
+	}
+	return Decode(calldata)
+}
+
+// DecodeTrace walks the call tree of root, decoding each call made to one of contracts, which maps each known
+// contract address to its name.
+func DecodeTrace(root CallFrame, contracts map[common.Address]string) []TracedCall {
+	var calls []TracedCall
+	var walk func(f CallFrame, path []int)
+	walk = func(f CallFrame, path []int) {
+		if f.To != nil {
+			if name, ok := contracts[*f.To]; ok {
+				if c, ok := decodeFrame(f, path, name); ok {
+					calls = append(calls, c)
+				}
+			}
+		}
+		for i, child := range f.Calls {
+			walk(child, append(append([]int{}, path...), i))
+		}
+	}
+	walk(root, []int{})
+	return calls
+}
+
+// decodeFrame decodes the call of f, returning false when it isn't one of the supported messages.
+func decodeFrame(f CallFrame, path []int, contract string) (TracedCall, bool) {
+	t := MessageType(f.Input)
+	if t == UnknownMessage {
+		return TracedCall{}, false
+	}
+
+	c := TracedCall{
+		Path:     path,
+		Depth:    len(path),
+		CallType: strings.ToUpper(f.Type),
+		From:     f.From,
+		To:       *f.To,
+		Contract: contract,
+		Message:  t.String(),
+	}
+	if f.Value != nil {
+		c.Value = f.Value.ToInt()
+	}
+	call, err := DecodeCall(f.Input)
+	if err != nil {
+		c.DecodeError = err.Error()
+	} else {
+		c.Call = call
+	}
+	if f.Error != "" {
+		c.Error = f.Error
+		if len(f.Output) > 0 {
+			c.Error = fmt.Sprintf("%s: %s", f.Error, DecodeRevert(f.Output))
+		}
+	}
+	return c, true
+}
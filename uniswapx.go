@@ -0,0 +1,31 @@
+package unidecode
+
+import (
+	"bytes"
+
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/uniswapx"
+)
+
+// isUniswapXSig reports whether sig is one of the UniswapX reactors' `execute*` methods.
+func isUniswapXSig(sig []byte) bool {
+	if len(sig) != 4 {
+		return false
+	}
+	_, err := uniswapx.Parse(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	return err == nil
+}
+
+// DecodeUniswapX decodes calldata sent to a UniswapX reactor.
+//
+// Supports `execute`, `executeWithCallback`, `executeBatch` and `executeBatchWithCallback` of Dutch, Exclusive Dutch,
+// V2 Dutch and Priority orders.
+func DecodeUniswapX(calldata []byte) (uniswapx.Execute, error) {
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+	if hex.MethodSig(calldata) == nil {
+		return uniswapx.Execute{}, ErrInvalidCallData
+	}
+	return uniswapx.Decode(calldata, 0)
+}
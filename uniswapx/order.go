@@ -0,0 +1,505 @@
+package uniswapx
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+// Order is a decoded UniswapX order.
+type Order interface {
+	Type() OrderType
+	Fields() []field.Field
+}
+
+// OrderInfo Solidity representation
+//
+// see: UniswapX/src/base/ReactorStructs.sol
+type OrderInfo struct {
+	Reactor                      common.Address `json:"reactor"`
+	Swapper                      common.Address `json:"swapper"`
+	Nonce                        *big.Int       `json:"nonce"`
+	Deadline                     time.Time      `json:"deadline"`
+	AdditionalValidationContract common.Address `json:"additionalValidationContract"`
+	AdditionalValidationData     []byte         `json:"additionalValidationData"`
+}
+
+func (i OrderInfo) MarshalJSON() ([]byte, error) {
+	type Alias OrderInfo
+	return jsonx.Marshal((Alias)(i))
+}
+
+func (i OrderInfo) Fields() []field.Field {
+	fields := []field.Field{
+		{Name: "Reactor", Value: i.Reactor},
+		{Name: "Swapper", Value: i.Swapper},
+		{Name: "Nonce", Value: i.Nonce},
+		{Name: "Deadline", Value: i.Deadline},
+	}
+	if i.AdditionalValidationContract != (common.Address{}) {
+		fields = append(fields,
+			field.Field{Name: "AdditionalValidationContract", Value: i.AdditionalValidationContract},
+			field.Field{Name: "AdditionalValidationData", Value: i.AdditionalValidationData},
+		)
+	}
+	return fields
+}
+
+// DutchInput Solidity representation, decaying linearly from StartAmount to EndAmount
+//
+// see: UniswapX/src/lib/DutchOrderLib.sol
+type DutchInput struct {
+	Token       common.Address `json:"token"`
+	StartAmount *big.Int       `json:"startAmount"`
+	EndAmount   *big.Int       `json:"endAmount"`
+}
+
+func (d DutchInput) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: d.Token},
+		{Name: "StartAmount", Value: d.StartAmount},
+		{Name: "EndAmount", Value: d.EndAmount},
+	}
+}
+
+// DutchOutput Solidity representation, decaying linearly from StartAmount to EndAmount
+//
+// see: UniswapX/src/lib/DutchOrderLib.sol
+type DutchOutput struct {
+	Token       common.Address `json:"token"`
+	StartAmount *big.Int       `json:"startAmount"`
+	EndAmount   *big.Int       `json:"endAmount"`
+	Recipient   common.Address `json:"recipient"`
+}
+
+func (d DutchOutput) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: d.Token},
+		{Name: "StartAmount", Value: d.StartAmount},
+		{Name: "EndAmount", Value: d.EndAmount},
+		{Name: "Recipient", Value: d.Recipient},
+	}
+}
+
+// DutchOrder Solidity representation
+//
+// see: UniswapX/src/lib/DutchOrderLib.sol
+type DutchOrder struct {
+	Info           OrderInfo     `json:"info"`
+	DecayStartTime time.Time     `json:"decayStartTime"`
+	DecayEndTime   time.Time     `json:"decayEndTime"`
+	Input          DutchInput    `json:"input"`
+	Outputs        []DutchOutput `json:"outputs"`
+}
+
+func (DutchOrder) Type() OrderType {
+	return DUTCH
+}
+
+func (o DutchOrder) MarshalJSON() ([]byte, error) {
+	type Alias DutchOrder
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(o), DUTCH.String()})
+}
+
+func (o DutchOrder) Fields() []field.Field {
+	return []field.Field{
+		field.Struct("Info", o.Info),
+		{Name: "DecayStartTime", Value: o.DecayStartTime},
+		{Name: "DecayEndTime", Value: o.DecayEndTime},
+		field.Struct("Input", o.Input),
+		field.List("Outputs", o.Outputs),
+	}
+}
+
+func DecodeDutchOrder(b []byte, offset int) (DutchOrder, error) {
+	var (
+		o   DutchOrder
+		err error
+	)
+	if offset+0xe0 > len(b) {
+		return o, fmt.Errorf("order exceeds data bounds")
+	}
+
+	if o.Info, err = decodeOrderInfo(b, offset); err != nil {
+		return o, err
+	}
+	o.DecayStartTime = decodeTime(b, offset+0x20)
+	o.DecayEndTime = decodeTime(b, offset+0x40)
+	o.Input = decodeDutchInput(b, offset+0x60)
+	o.Outputs, err = decodeDutchOutputs(b, offset, 0xc0)
+	return o, err
+}
+
+// ExclusiveDutchOrder Solidity representation
+//
+// see: UniswapX/src/lib/ExclusiveDutchOrderLib.sol
+type ExclusiveDutchOrder struct {
+	Info                   OrderInfo      `json:"info"`
+	DecayStartTime         time.Time      `json:"decayStartTime"`
+	DecayEndTime           time.Time      `json:"decayEndTime"`
+	ExclusiveFiller        common.Address `json:"exclusiveFiller"`
+	ExclusivityOverrideBps *big.Int       `json:"exclusivityOverrideBps"`
+	Input                  DutchInput     `json:"input"`
+	Outputs                []DutchOutput  `json:"outputs"`
+}
+
+func (ExclusiveDutchOrder) Type() OrderType {
+	return EXCLUSIVE_DUTCH
+}
+
+func (o ExclusiveDutchOrder) MarshalJSON() ([]byte, error) {
+	type Alias ExclusiveDutchOrder
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(o), EXCLUSIVE_DUTCH.String()})
+}
+
+func (o ExclusiveDutchOrder) Fields() []field.Field {
+	return []field.Field{
+		field.Struct("Info", o.Info),
+		{Name: "DecayStartTime", Value: o.DecayStartTime},
+		{Name: "DecayEndTime", Value: o.DecayEndTime},
+		{Name: "ExclusiveFiller", Value: o.ExclusiveFiller},
+		{Name: "ExclusivityOverrideBps", Value: o.ExclusivityOverrideBps},
+		field.Struct("Input", o.Input),
+		field.List("Outputs", o.Outputs),
+	}
+}
+
+func DecodeExclusiveDutchOrder(b []byte, offset int) (ExclusiveDutchOrder, error) {
+	var (
+		o   ExclusiveDutchOrder
+		err error
+	)
+	if offset+0x120 > len(b) {
+		return o, fmt.Errorf("order exceeds data bounds")
+	}
+
+	if o.Info, err = decodeOrderInfo(b, offset); err != nil {
+		return o, err
+	}
+	o.DecayStartTime = decodeTime(b, offset+0x20)
+	o.DecayEndTime = decodeTime(b, offset+0x40)
+	o.ExclusiveFiller = common.BytesToAddress(b[offset+0x60 : offset+0x80])
+	o.ExclusivityOverrideBps = new(big.Int).SetBytes(b[offset+0x80 : offset+0xa0])
+	o.Input = decodeDutchInput(b, offset+0xa0)
+	o.Outputs, err = decodeDutchOutputs(b, offset, 0x100)
+	return o, err
+}
+
+// V2CosignerData Solidity representation
+//
+// see: UniswapX/src/lib/V2DutchOrderLib.sol
+type V2CosignerData struct {
+	DecayStartTime         time.Time      `json:"decayStartTime"`
+	DecayEndTime           time.Time      `json:"decayEndTime"`
+	ExclusiveFiller        common.Address `json:"exclusiveFiller"`
+	ExclusivityOverrideBps *big.Int       `json:"exclusivityOverrideBps"`
+	InputOverride          *big.Int       `json:"inputOverride"`
+	OutputOverrides        []*big.Int     `json:"outputOverrides"`
+}
+
+func (v V2CosignerData) Fields() []field.Field {
+	return []field.Field{
+		{Name: "DecayStartTime", Value: v.DecayStartTime},
+		{Name: "DecayEndTime", Value: v.DecayEndTime},
+		{Name: "ExclusiveFiller", Value: v.ExclusiveFiller},
+		{Name: "ExclusivityOverrideBps", Value: v.ExclusivityOverrideBps},
+		{Name: "InputOverride", Value: v.InputOverride},
+		field.Values("OutputOverrides", v.OutputOverrides),
+	}
+}
+
+// V2DutchOrder Solidity representation
+//
+// see: UniswapX/src/lib/V2DutchOrderLib.sol
+type V2DutchOrder struct {
+	Info         OrderInfo      `json:"info"`
+	Cosigner     common.Address `json:"cosigner"`
+	BaseInput    DutchInput     `json:"baseInput"`
+	BaseOutputs  []DutchOutput  `json:"baseOutputs"`
+	CosignerData V2CosignerData `json:"cosignerData"`
+	Cosignature  []byte         `json:"cosignature"`
+}
+
+func (V2DutchOrder) Type() OrderType {
+	return V2_DUTCH
+}
+
+func (o V2DutchOrder) MarshalJSON() ([]byte, error) {
+	type Alias V2DutchOrder
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(o), V2_DUTCH.String()})
+}
+
+func (o V2DutchOrder) Fields() []field.Field {
+	return []field.Field{
+		field.Struct("Info", o.Info),
+		{Name: "Cosigner", Value: o.Cosigner},
+		field.Struct("BaseInput", o.BaseInput),
+		field.List("BaseOutputs", o.BaseOutputs),
+		field.Struct("CosignerData", o.CosignerData),
+		{Name: "Cosignature", Value: o.Cosignature},
+	}
+}
+
+func DecodeV2DutchOrder(b []byte, offset int) (V2DutchOrder, error) {
+	var (
+		o   V2DutchOrder
+		err error
+	)
+	if offset+0x100 > len(b) {
+		return o, fmt.Errorf("order exceeds data bounds")
+	}
+
+	if o.Info, err = decodeOrderInfo(b, offset); err != nil {
+		return o, err
+	}
+	o.Cosigner = common.BytesToAddress(b[offset+0x20 : offset+0x40])
+	o.BaseInput = decodeDutchInput(b, offset+0x40)
+	if o.BaseOutputs, err = decodeDutchOutputs(b, offset, 0xa0); err != nil {
+		return o, err
+	}
+
+	loc, err := hex.Int(b[offset+0xc0 : offset+0xe0])
+	if err != nil {
+		return o, fmt.Errorf("invalid cosigner data location; %w", err)
+	} else if loc > len(b)-offset-0xc0 {
+		return o, fmt.Errorf("cosigner data exceeds data bounds")
+	}
+	start := offset + loc
+	d := V2CosignerData{
+		ExclusiveFiller:        common.BytesToAddress(b[start+0x40 : start+0x60]),
+		ExclusivityOverrideBps: new(big.Int).SetBytes(b[start+0x60 : start+0x80]),
+		InputOverride:          new(big.Int).SetBytes(b[start+0x80 : start+0xa0]),
+	}
+	d.DecayStartTime = decodeTime(b, start)
+	d.DecayEndTime = decodeTime(b, start+0x20)
+	overrides, count, err := hex.Array(b, start, 0xa0, 0x20)
+	if err != nil {
+		return o, fmt.Errorf("invalid output overrides; %w", err)
+	}
+	for i := 0; i < count; i++ {
+		d.OutputOverrides = append(d.OutputOverrides, new(big.Int).SetBytes(b[overrides+i*0x20:overrides+i*0x20+0x20]))
+	}
+	o.CosignerData = d
+
+	if o.Cosignature, err = hex.Bytes(b, offset, 0xe0); err != nil {
+		return o, fmt.Errorf("invalid cosignature; %w", err)
+	}
+	return o, nil
+}
+
+// PriorityInput Solidity representation
+//
+// see: UniswapX/src/lib/PriorityOrderLib.sol
+type PriorityInput struct {
+	Token                common.Address `json:"token"`
+	Amount               *big.Int       `json:"amount"`
+	MpsPerPriorityFeeWei *big.Int       `json:"mpsPerPriorityFeeWei"`
+}
+
+func (p PriorityInput) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Amount", Value: p.Amount},
+		{Name: "MpsPerPriorityFeeWei", Value: p.MpsPerPriorityFeeWei},
+	}
+}
+
+// PriorityOutput Solidity representation
+//
+// see: UniswapX/src/lib/PriorityOrderLib.sol
+type PriorityOutput struct {
+	Token                common.Address `json:"token"`
+	Amount               *big.Int       `json:"amount"`
+	MpsPerPriorityFeeWei *big.Int       `json:"mpsPerPriorityFeeWei"`
+	Recipient            common.Address `json:"recipient"`
+}
+
+func (p PriorityOutput) Fields() []field.Field {
+	return []field.Field{
+		{Name: "Token", Value: p.Token},
+		{Name: "Amount", Value: p.Amount},
+		{Name: "MpsPerPriorityFeeWei", Value: p.MpsPerPriorityFeeWei},
+		{Name: "Recipient", Value: p.Recipient},
+	}
+}
+
+// PriorityOrder Solidity representation
+//
+// see: UniswapX/src/lib/PriorityOrderLib.sol
+type PriorityOrder struct {
+	Info                   OrderInfo        `json:"info"`
+	Cosigner               common.Address   `json:"cosigner"`
+	AuctionStartBlock      *big.Int         `json:"auctionStartBlock"`
+	BaselinePriorityFeeWei *big.Int         `json:"baselinePriorityFeeWei"`
+	Input                  PriorityInput    `json:"input"`
+	Outputs                []PriorityOutput `json:"outputs"`
+	// PriorityCosignerData.auctionTargetBlock
+	AuctionTargetBlock *big.Int `json:"auctionTargetBlock"`
+	Cosignature        []byte   `json:"cosignature"`
+}
+
+func (PriorityOrder) Type() OrderType {
+	return PRIORITY
+}
+
+func (o PriorityOrder) MarshalJSON() ([]byte, error) {
+	type Alias PriorityOrder
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(o), PRIORITY.String()})
+}
+
+func (o PriorityOrder) Fields() []field.Field {
+	return []field.Field{
+		field.Struct("Info", o.Info),
+		{Name: "Cosigner", Value: o.Cosigner},
+		{Name: "AuctionStartBlock", Value: o.AuctionStartBlock},
+		{Name: "BaselinePriorityFeeWei", Value: o.BaselinePriorityFeeWei},
+		field.Struct("Input", o.Input),
+		field.List("Outputs", o.Outputs),
+		{Name: "AuctionTargetBlock", Value: o.AuctionTargetBlock},
+		{Name: "Cosignature", Value: o.Cosignature},
+	}
+}
+
+func DecodePriorityOrder(b []byte, offset int) (PriorityOrder, error) {
+	var (
+		o   PriorityOrder
+		err error
+	)
+	if offset+0x140 > len(b) {
+		return o, fmt.Errorf("order exceeds data bounds")
+	}
+
+	if o.Info, err = decodeOrderInfo(b, offset); err != nil {
+		return o, err
+	}
+	o.Cosigner = common.BytesToAddress(b[offset+0x20 : offset+0x40])
+	o.AuctionStartBlock = new(big.Int).SetBytes(b[offset+0x40 : offset+0x60])
+	o.BaselinePriorityFeeWei = new(big.Int).SetBytes(b[offset+0x60 : offset+0x80])
+	o.Input = PriorityInput{
+		Token:                common.BytesToAddress(b[offset+0x80 : offset+0xa0]),
+		Amount:               new(big.Int).SetBytes(b[offset+0xa0 : offset+0xc0]),
+		MpsPerPriorityFeeWei: new(big.Int).SetBytes(b[offset+0xc0 : offset+0xe0]),
+	}
+
+	loc, count, err := hex.Array(b, offset, 0xe0, 0x80)
+	if err != nil {
+		return o, fmt.Errorf("invalid outputs; %w", err)
+	}
+	for i := 0; i < count; i++ {
+		p := loc + i*0x80
+		o.Outputs = append(o.Outputs, PriorityOutput{
+			Token:                common.BytesToAddress(b[p : p+0x20]),
+			Amount:               new(big.Int).SetBytes(b[p+0x20 : p+0x40]),
+			MpsPerPriorityFeeWei: new(big.Int).SetBytes(b[p+0x40 : p+0x60]),
+			Recipient:            common.BytesToAddress(b[p+0x60 : p+0x80]),
+		})
+	}
+
+	o.AuctionTargetBlock = new(big.Int).SetBytes(b[offset+0x100 : offset+0x120])
+	if o.Cosignature, err = hex.Bytes(b, offset, 0x120); err != nil {
+		return o, fmt.Errorf("invalid cosignature; %w", err)
+	}
+	return o, nil
+}
+
+// DecodeOrder decodes an ABI encoded order, as found within SignedOrder.order.
+func DecodeOrder(b []byte) (Order, error) {
+	if len(b) < 0x40 {
+		return nil, fmt.Errorf("order exceeds data bounds")
+	}
+	loc, err := hex.Int(b[:0x20])
+	if err != nil || loc != 0x20 {
+		return nil, fmt.Errorf("invalid order location")
+	}
+
+	// The order type is identified by the location of OrderInfo, its first dynamic member
+	infoLoc, err := hex.Int(b[0x20:0x40])
+	if err != nil {
+		return nil, fmt.Errorf("invalid order info location; %w", err)
+	}
+	switch t := OrderType(infoLoc); t {
+	case DUTCH:
+		return DecodeDutchOrder(b, 0x20)
+	case EXCLUSIVE_DUTCH:
+		return DecodeExclusiveDutchOrder(b, 0x20)
+	case V2_DUTCH:
+		return DecodeV2DutchOrder(b, 0x20)
+	case PRIORITY:
+		return DecodePriorityOrder(b, 0x20)
+	}
+	return nil, fmt.Errorf("unsupported order type; order info at 0x%x", infoLoc)
+}
+
+func decodeOrderInfo(b []byte, offset int) (OrderInfo, error) {
+	var i OrderInfo
+	loc, err := hex.Int(b[offset : offset+0x20])
+	if err != nil {
+		return i, fmt.Errorf("invalid order info location; %w", err)
+	} else if loc > len(b)-offset-0xc0 {
+		return i, fmt.Errorf("order info exceeds data bounds")
+	}
+	start := offset + loc
+
+	i = OrderInfo{
+		Reactor:                      common.BytesToAddress(b[start : start+0x20]),
+		Swapper:                      common.BytesToAddress(b[start+0x20 : start+0x40]),
+		Nonce:                        new(big.Int).SetBytes(b[start+0x40 : start+0x60]),
+		AdditionalValidationContract: common.BytesToAddress(b[start+0x80 : start+0xa0]),
+	}
+	i.Deadline = decodeTime(b, start+0x60)
+	if i.AdditionalValidationData, err = hex.Bytes(b, start, 0xa0); err != nil {
+		return i, fmt.Errorf("invalid additional validation data; %w", err)
+	}
+	return i, nil
+}
+
+func decodeDutchInput(b []byte, offset int) DutchInput {
+	return DutchInput{
+		Token:       common.BytesToAddress(b[offset : offset+0x20]),
+		StartAmount: new(big.Int).SetBytes(b[offset+0x20 : offset+0x40]),
+		EndAmount:   new(big.Int).SetBytes(b[offset+0x40 : offset+0x60]),
+	}
+}
+
+// decodeDutchOutputs decodes the `DutchOutput[]` whose location, relative to start, is stored at start+head.
+func decodeDutchOutputs(b []byte, start, head int) ([]DutchOutput, error) {
+	loc, count, err := hex.Array(b, start, head, 0x80)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outputs; %w", err)
+	}
+
+	var outputs []DutchOutput
+	for i := 0; i < count; i++ {
+		o := loc + i*0x80
+		outputs = append(outputs, DutchOutput{
+			Token:       common.BytesToAddress(b[o : o+0x20]),
+			StartAmount: new(big.Int).SetBytes(b[o+0x20 : o+0x40]),
+			EndAmount:   new(big.Int).SetBytes(b[o+0x40 : o+0x60]),
+			Recipient:   common.BytesToAddress(b[o+0x60 : o+0x80]),
+		})
+	}
+	return outputs, nil
+}
+
+// decodeTime decodes a uint256 epoch, capping values that overflow an int64.
+func decodeTime(b []byte, offset int) time.Time {
+	return hex.Time(b[offset : offset+0x20])
+}
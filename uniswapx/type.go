@@ -0,0 +1,71 @@
+package uniswapx
+
+import "fmt"
+
+// Type is the function selector of a reactor method.
+type Type int64
+
+const (
+	// Handlers found within `UniswapX/src/reactors/BaseReactor.sol`
+	EXECUTE                     Type = 0x3f62192e // execute((bytes,bytes))
+	EXECUTE_WITH_CALLBACK       Type = 0x0d335884 // executeWithCallback((bytes,bytes),bytes)
+	EXECUTE_BATCH               Type = 0x0d7a16c3 // executeBatch((bytes,bytes)[])
+	EXECUTE_BATCH_WITH_CALLBACK Type = 0x13fb72c7 // executeBatchWithCallback((bytes,bytes)[],bytes)
+)
+
+func (t Type) String() string {
+	switch t {
+	case 0x3f62192e:
+		return "EXECUTE"
+	case 0x0d335884:
+		return "EXECUTE_WITH_CALLBACK"
+	case 0x0d7a16c3:
+		return "EXECUTE_BATCH"
+	case 0x13fb72c7:
+		return "EXECUTE_BATCH_WITH_CALLBACK"
+	}
+	return "UNKNOWN"
+}
+
+func Parse(i int64) (t Type, err error) {
+	switch i {
+	case 0x3f62192e:
+		t = EXECUTE
+	case 0x0d335884:
+		t = EXECUTE_WITH_CALLBACK
+	case 0x0d7a16c3:
+		t = EXECUTE_BATCH
+	case 0x13fb72c7:
+		t = EXECUTE_BATCH_WITH_CALLBACK
+	default:
+		err = fmt.Errorf("invalid reactor method 0x%x", i)
+	}
+	return
+}
+
+// OrderType identifies the reactor an order is filled by.
+//
+// Orders don't carry their type, but each encodes OrderInfo as its first dynamic member, so the order's head size, and
+// therefore its OrderInfo location, is unique to the order type.
+type OrderType int
+
+const (
+	DUTCH           OrderType = 0xe0  // DutchOrderReactor
+	V2_DUTCH        OrderType = 0x100 // V2DutchOrderReactor
+	EXCLUSIVE_DUTCH OrderType = 0x120 // ExclusiveDutchOrderReactor
+	PRIORITY        OrderType = 0x140 // PriorityOrderReactor
+)
+
+func (t OrderType) String() string {
+	switch t {
+	case 0xe0:
+		return "DUTCH"
+	case 0x100:
+		return "V2_DUTCH"
+	case 0x120:
+		return "EXCLUSIVE_DUTCH"
+	case 0x140:
+		return "PRIORITY"
+	}
+	return "UNKNOWN"
+}
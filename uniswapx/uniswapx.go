@@ -0,0 +1,127 @@
+package uniswapx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/juztin/unidecode/field"
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+var ErrInvalidCallData = errors.New("invalid calldata")
+
+// SignedOrder Solidity representation
+//
+// see: UniswapX/src/base/ReactorStructs.sol
+type SignedOrder struct {
+	Order Order  `json:"order"`
+	Sig   []byte `json:"sig"`
+}
+
+func (o SignedOrder) MarshalJSON() ([]byte, error) {
+	type Alias SignedOrder
+	return jsonx.Marshal((Alias)(o))
+}
+
+func (o SignedOrder) Fields() []field.Field {
+	return append(o.Order.Fields(), field.Field{Name: "Sig", Value: o.Sig})
+}
+
+// Execute is a decoded reactor `execute*` call.
+type Execute struct {
+	Method       Type          `json:"-"`
+	Orders       []SignedOrder `json:"orders"`
+	CallbackData []byte        `json:"callbackData,omitempty"`
+}
+
+func (e Execute) MarshalJSON() ([]byte, error) {
+	type Alias Execute
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(e), e.Method.String()})
+}
+
+// Decode decodes calldata sent to a UniswapX reactor, where offset is the location of the method selector.
+func Decode(calldata []byte, offset int) (Execute, error) {
+	var e Execute
+	if offset < 0 || offset > len(calldata) {
+		return e, ErrInvalidCallData
+	}
+	sig := hex.MethodSig(calldata[offset:])
+	if sig == nil {
+		return e, ErrInvalidCallData
+	}
+	t, err := Parse(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	if err != nil {
+		return e, err
+	}
+	e.Method = t
+
+	// Skip method signature
+	offset += 0x04
+
+	switch t {
+	case EXECUTE, EXECUTE_WITH_CALLBACK:
+		if offset+0x20 > len(calldata) {
+			return e, ErrInvalidCallData
+		}
+		loc, err := hex.Int(calldata[offset : offset+0x20])
+		if err != nil {
+			return e, fmt.Errorf("invalid order location; %w", err)
+		} else if loc > len(calldata)-offset {
+			return e, fmt.Errorf("%w; order exceeds calldata bounds", ErrInvalidCallData)
+		}
+		o, err := decodeSignedOrder(calldata, offset+loc)
+		if err != nil {
+			return e, err
+		}
+		e.Orders = append(e.Orders, o)
+	case EXECUTE_BATCH, EXECUTE_BATCH_WITH_CALLBACK:
+		loc, count, err := hex.Array(calldata, offset, 0x00, 0x20)
+		if err != nil {
+			return e, fmt.Errorf("invalid orders; %w", err)
+		}
+		for i := 0; i < count; i++ {
+			orderLoc, err := hex.Int(calldata[loc+i*0x20 : loc+i*0x20+0x20])
+			if err != nil {
+				return e, fmt.Errorf("invalid order location at index %d; %w", i, err)
+			} else if orderLoc > len(calldata)-loc {
+				return e, fmt.Errorf("%w; order %d exceeds calldata bounds", ErrInvalidCallData, i)
+			}
+			o, err := decodeSignedOrder(calldata, loc+orderLoc)
+			if err != nil {
+				return e, fmt.Errorf("invalid order at index %d; %w", i, err)
+			}
+			e.Orders = append(e.Orders, o)
+		}
+	}
+
+	if t == EXECUTE_WITH_CALLBACK || t == EXECUTE_BATCH_WITH_CALLBACK {
+		if e.CallbackData, err = hex.Bytes(calldata, offset, 0x20); err != nil {
+			return e, fmt.Errorf("invalid callback data; %w", err)
+		}
+	}
+	return e, nil
+}
+
+// decodeSignedOrder decodes the `(bytes order, bytes sig)` tuple located at start.
+func decodeSignedOrder(calldata []byte, start int) (SignedOrder, error) {
+	var o SignedOrder
+	if start < 0 || start > len(calldata)-0x40 {
+		return o, fmt.Errorf("%w; signed order exceeds calldata bounds", ErrInvalidCallData)
+	}
+
+	b, err := hex.Bytes(calldata, start, 0x00)
+	if err != nil {
+		return o, fmt.Errorf("invalid order; %w", err)
+	}
+	if o.Order, err = DecodeOrder(b); err != nil {
+		return o, fmt.Errorf("invalid order; %w", err)
+	}
+	if o.Sig, err = hex.Bytes(calldata, start, 0x20); err != nil {
+		return o, fmt.Errorf("invalid order signature; %w", err)
+	}
+	return o, nil
+}
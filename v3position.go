@@ -0,0 +1,92 @@
+package unidecode
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/hex"
+)
+
+var v3PermitSigStr = fmt.Sprintf("%x", commands.V3PermitSig)
+
+// isV3PositionManagerSig reports whether sig is one of the NonfungiblePositionManager's position methods.
+//
+// The `selfPermit` methods aren't, as SwapRouter02 shares them.
+func isV3PositionManagerSig(sig []byte) bool {
+	t, ok := v3PositionManagerType(sig)
+	if !ok {
+		return false
+	}
+	switch t {
+	case actions.V3_SELF_PERMIT,
+		actions.V3_SELF_PERMIT_IF_NECESSARY,
+		actions.V3_SELF_PERMIT_ALLOWED,
+		actions.V3_SELF_PERMIT_ALLOWED_IF_NECESSARY:
+		return false
+	}
+	return true
+}
+
+// v3PositionManagerType returns the action type of a NonfungiblePositionManager method signature.
+func v3PositionManagerType(sig []byte) (actions.Type, bool) {
+	if len(sig) != 4 {
+		return 0, false
+	}
+	t, err := actions.ParseV3(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	return t, err == nil
+}
+
+// DecodeV3PositionManager decodes calldata sent directly to the V3 NonfungiblePositionManager.
+//
+// Supports `mint`, `increaseLiquidity`, `decreaseLiquidity`, `collect`, `burn`, `permit`,
+// `createAndInitializePoolIfNecessary` and the `selfPermit` methods, along with a `multicall` of them and the
+// `refundETH`, `unwrapWETH9` and `sweepToken` payment methods. Each call is returned as its equivalent
+// Universal Router command.
+func DecodeV3PositionManager(calldata []byte) (Execute, error) {
+	var e Execute
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+
+	sig := hex.MethodSig(calldata)
+	if sig == nil {
+		return e, ErrInvalidCallData
+	} else if fmt.Sprintf("%x", sig) != multicallSigStr {
+		c, err := decodeV3PositionManagerCall(calldata)
+		if err != nil {
+			return e, err
+		}
+		e.Commands = append(e.Commands, c)
+		return e, nil
+	}
+
+	calls, err := decodeCalls(calldata, 0x04, 0x00)
+	if err != nil {
+		return e, fmt.Errorf("invalid multicall data; %w", err)
+	}
+	for i, call := range calls {
+		c, err := decodeV3PositionManagerCall(call)
+		if err != nil {
+			return e, fmt.Errorf("invalid multicall data at index %d; %w", i, err)
+		}
+		e.Commands = append(e.Commands, c)
+	}
+	return e, nil
+}
+
+func decodeV3PositionManagerCall(calldata []byte) (commands.Command, error) {
+	sig := hex.MethodSig(calldata)
+	if _, ok := v3PositionManagerType(sig); ok {
+		return commands.DecodeV3Call(calldata, 0)
+	} else if fmt.Sprintf("%x", sig) == v3PermitSigStr {
+		return commands.DecodeV3Permit(calldata, 0)
+	}
+
+	c, ok, err := decodePeripheryPayment(calldata)
+	if ok {
+		return c, err
+	}
+	return nil, fmt.Errorf("%w; unsupported NonfungiblePositionManager method %x", ErrIncorrectMethodSig, sig)
+}
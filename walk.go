@@ -0,0 +1,172 @@
+package unidecode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+	"github.com/juztin/unidecode/path"
+)
+
+var (
+	// SkipChildren is returned by a WalkFunc to skip the actions, keys and hops of the node visited.
+	SkipChildren = errors.New("skip children")
+	// SkipAll is returned by a WalkFunc to stop walking, without Walk returning an error.
+	SkipAll = errors.New("skip all")
+)
+
+// Step is an element of a Path, the JSON name of a field and, when the field is a list, the index within it.
+type Step struct {
+	Name  string
+	Index int // -1 when the field isn't a list
+}
+
+// Path is the position of a node visited by Walk, from the Execute to the node.
+type Path []Step
+
+// String returns the path in the form commands[1].actions[0].poolKey.
+func (p Path) String() string {
+	steps := make([]string, len(p))
+	for i, s := range p {
+		if s.Index < 0 {
+			steps[i] = s.Name
+		} else {
+			steps[i] = fmt.Sprintf("%s[%d]", s.Name, s.Index)
+		}
+	}
+	return strings.Join(steps, ".")
+}
+
+// append returns a copy of p with the step name, so paths passed to a WalkFunc may be retained.
+func (p Path) append(name string, index int) Path {
+	return append(p[:len(p):len(p)], Step{name, index})
+}
+
+// WalkFunc is called by Walk for each node, a commands.Command, actions.Action, pool.Key, path.Key, path.V3Hop or the
+// common.Address of a V2 path, along with its path. Returning SkipChildren skips the children of the node, SkipAll
+// stops walking and any other error stops walking and is returned by Walk.
+type WalkFunc func(path Path, node interface{}) error
+
+// Walk visits each command of e in order, followed by its pool key, the tokens or hops of its path, its actions, or the
+// commands of its sub-plan. Each action is followed by its pool key, or the keys of its path, so actions of the
+// position manager calls are visited along with swaps. Paths follow the JSON of e, so commands of a sub-plan are
+// visited with paths such as commands[1].commands[0], and the action of a V3 position manager call as
+// commands[0].action.
+func Walk(e Execute, fn WalkFunc) error {
+	err := walkCommands(nil, e.Commands, fn)
+	if err == SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walkCommands(p Path, cmds []commands.Command, fn WalkFunc) error {
+	for i, cmd := range cmds {
+		cmdPath := p.append("commands", i)
+		if err := fn(cmdPath, cmd); err == SkipChildren {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		switch c := cmd.(type) {
+		case commands.V4InitializePool:
+			if err := walkLeaf(cmdPath.append("key", -1), c.Key, fn); err != nil {
+				return err
+			}
+		case commands.ExecuteSubPlan:
+			if err := walkCommands(cmdPath, c.Commands, fn); err != nil {
+				return err
+			}
+		case commands.V2SwapExactIn:
+			if err := walkV2Path(cmdPath, c.Path, fn); err != nil {
+				return err
+			}
+		case commands.V2SwapExactOut:
+			if err := walkV2Path(cmdPath, c.Path, fn); err != nil {
+				return err
+			}
+		case commands.V3SwapExactIn:
+			if err := walkV3Path(cmdPath, c.Path, fn); err != nil {
+				return err
+			}
+		case commands.V3SwapExactOut:
+			if err := walkV3Path(cmdPath, c.Path, fn); err != nil {
+				return err
+			}
+		case commands.V3PositionManagerCall:
+			if err := walkAction(cmdPath.append("action", -1), c.Action, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		for j, action := range cmd.Actions() {
+			if err := walkAction(cmdPath.append("actions", j), action, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func walkAction(p Path, action actions.Action, fn WalkFunc) error {
+	if err := fn(p, action); err == SkipChildren {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	switch a := action.(type) {
+	case actions.SwapExactIn:
+		for i, k := range a.Path {
+			if err := walkLeaf(p.append("path", i), k, fn); err != nil {
+				return err
+			}
+		}
+	case actions.SwapExactOut:
+		for i, k := range a.Path {
+			if err := walkLeaf(p.append("path", i), k, fn); err != nil {
+				return err
+			}
+		}
+	case actions.SwapExactInSingle:
+		return walkLeaf(p.append("poolKey", -1), a.PoolKey, fn)
+	case actions.SwapExactOutSingle:
+		return walkLeaf(p.append("poolKey", -1), a.PoolKey, fn)
+	case actions.MintPosition:
+		return walkLeaf(p.append("poolKey", -1), a.PoolKey, fn)
+	case actions.MintPositionFromDeltas:
+		return walkLeaf(p.append("poolKey", -1), a.PoolKey, fn)
+	}
+	return nil
+}
+
+func walkV2Path(p Path, tokens []common.Address, fn WalkFunc) error {
+	for i, token := range tokens {
+		if err := walkLeaf(p.append("path", i), token, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkV3Path(p Path, hops []path.V3Hop, fn WalkFunc) error {
+	for i, h := range hops {
+		if err := walkLeaf(p.append("path", i), h, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkLeaf visits a node without children, for which SkipChildren is ignored.
+func walkLeaf(p Path, node interface{}, fn WalkFunc) error {
+	if err := fn(p, node); err != SkipChildren {
+		return err
+	}
+	return nil
+}
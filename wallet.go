@@ -0,0 +1,129 @@
+package unidecode
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+	"github.com/juztin/unidecode/wallet"
+)
+
+// maxWalletDepth limits how many wallet layers are unwrapped.
+const maxWalletDepth = 8
+
+// isWalletSig reports whether sig is a Safe, MultiSend, ERC-4337 EntryPoint, smart account or Multicall3 method that
+// wraps other calls.
+func isWalletSig(sig []byte) bool {
+	if len(sig) != 4 {
+		return false
+	}
+	_, err := wallet.Parse(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	return err == nil
+}
+
+// WalletCall is a supported call found within the layers of a wallet call.
+type WalletCall struct {
+	// Path is the index of the call within each layer.
+	Path []int `json:"path"`
+	// Via is the method of each layer the call is wrapped by, starting with the outermost.
+	Via       []string `json:"via"`
+	Operation string   `json:"operation"`
+	// From is the account making the call, or nil when it's the target of the outermost call, such as a Safe.
+	From    *common.Address `json:"from,omitempty"`
+	To      common.Address  `json:"to"`
+	Value   *big.Int        `json:"value"`
+	Message string          `json:"message"`
+	Call    interface{}     `json:"call,omitempty"`
+	// DecodeError is set when the calldata of a supported message, or of a wallet layer, couldn't be decoded.
+	DecodeError string `json:"decodeError,omitempty"`
+}
+
+func (c WalletCall) MarshalJSON() ([]byte, error) {
+	type Alias WalletCall
+	return jsonx.Marshal((Alias)(c))
+}
+
+// Wallet is a decoded wallet call, with the supported calls found within its layers.
+type Wallet struct {
+	Method wallet.Type  `json:"-"`
+	Calls  []WalletCall `json:"calls"`
+}
+
+func (w Wallet) MarshalJSON() ([]byte, error) {
+	type Alias Wallet
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(w), w.Method.String()})
+}
+
+// DecodeWallet decodes calldata sent to a Safe (`execTransaction`), MultiSend, ERC-4337 EntryPoint (`handleOps`),
+// smart account (`execute*`) or Multicall3 (`aggregate*`), unwrapping each layer down to the Universal Router, and
+// other supported, calls it makes.
+//
+// Calls to unsupported contracts, such as token approvals, are omitted.
+func DecodeWallet(calldata []byte) (Wallet, error) {
+	if bytes.HasPrefix(calldata, hexPrefix) {
+		calldata = calldata[0x02:]
+	}
+	if hex.MethodSig(calldata) == nil {
+		return Wallet{}, ErrInvalidCallData
+	}
+	w, err := wallet.Decode(calldata, 0)
+	if err != nil {
+		return Wallet{Method: w.Method}, err
+	}
+	d := Wallet{Method: w.Method}
+	d.Calls = unwrapCalls(w, nil, []int{}, []string{w.Method.String()}, 1)
+	return d, nil
+}
+
+// unwrapCalls decodes the calls of w, made by from, descending into those which are themselves wallet calls.
+func unwrapCalls(w wallet.Wrapped, from *common.Address, path []int, via []string, depth int) []WalletCall {
+	var calls []WalletCall
+	for i, c := range w.Calls {
+		p := append(append([]int{}, path...), i)
+		t := MessageType(c.Data)
+		if t == WalletMessage && depth < maxWalletDepth {
+			inner, err := wallet.Decode(c.Data, 0)
+			if err == nil {
+				// A delegate call runs in the context of its caller
+				caller := from
+				if c.Operation == wallet.CALL {
+					to := c.To
+					caller = &to
+				}
+				calls = append(calls, unwrapCalls(inner, caller, p, append(append([]string{}, via...), inner.Method.String()), depth+1)...)
+				continue
+			}
+			calls = append(calls, walletCall(c, from, p, via, t, nil, err))
+			continue
+		} else if t == UnknownMessage || t == WalletMessage {
+			continue
+		}
+		call, err := DecodeCall(c.Data)
+		calls = append(calls, walletCall(c, from, p, via, t, call, err))
+	}
+	return calls
+}
+
+func walletCall(c wallet.Call, from *common.Address, path []int, via []string, t messageType, call interface{}, err error) WalletCall {
+	wc := WalletCall{
+		Path:      path,
+		Via:       via,
+		Operation: c.Operation.String(),
+		From:      from,
+		To:        c.To,
+		Value:     c.Value,
+		Message:   t.String(),
+	}
+	if err != nil {
+		wc.DecodeError = err.Error()
+	} else {
+		wc.Call = call
+	}
+	return wc
+}
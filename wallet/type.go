@@ -0,0 +1,127 @@
+package wallet
+
+import "fmt"
+
+// Type is the function selector of a wallet, bundler or multicall method that wraps other calls.
+type Type int64
+
+const (
+	// Handlers found within `safe-smart-account/contracts/Safe.sol`
+	EXEC_TRANSACTION Type = 0x6a761202 // execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)
+
+	// Handlers found within `safe-smart-account/contracts/libraries/MultiSend.sol` and `MultiSendCallOnly.sol`
+	MULTI_SEND Type = 0x8d80ff0a // multiSend(bytes)
+
+	// Handlers found within `account-abstraction/contracts/core/EntryPoint.sol`
+	HANDLE_OPS        Type = 0x1fad948c // handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address)
+	HANDLE_PACKED_OPS Type = 0x765e827f // handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[],address)
+
+	// Handlers of ERC-4337 smart accounts, such as `account-abstraction/contracts/samples/SimpleAccount.sol` and
+	// `safe-modules/modules/4337/contracts/Safe4337Module.sol`
+	EXECUTE                           Type = 0xb61d27f6 // execute(address,uint256,bytes)
+	EXECUTE_BATCH                     Type = 0x47e1da2a // executeBatch(address[],uint256[],bytes[])
+	EXECUTE_BATCH_NO_VALUE            Type = 0x18dfb3c7 // executeBatch(address[],bytes[])
+	EXECUTE_BATCH_CALLS               Type = 0x34fcd5be // executeBatch((address,uint256,bytes)[])
+	EXECUTE_USER_OP                   Type = 0x7bb37428 // executeUserOp(address,uint256,bytes,uint8)
+	EXECUTE_USER_OP_WITH_ERROR_STRING Type = 0x541d63c8 // executeUserOpWithErrorString(address,uint256,bytes,uint8)
+
+	// Handlers found within `multicall/src/Multicall3.sol`
+	AGGREGATE        Type = 0x252dba42 // aggregate((address,bytes)[])
+	TRY_AGGREGATE    Type = 0xbce38bd7 // tryAggregate(bool,(address,bytes)[])
+	AGGREGATE3       Type = 0x82ad56cb // aggregate3((address,bool,bytes)[])
+	AGGREGATE3_VALUE Type = 0x174dea71 // aggregate3Value((address,bool,uint256,bytes)[])
+)
+
+func (t Type) String() string {
+	switch t {
+	case 0x6a761202:
+		return "EXEC_TRANSACTION"
+	case 0x8d80ff0a:
+		return "MULTI_SEND"
+	case 0x1fad948c:
+		return "HANDLE_OPS"
+	case 0x765e827f:
+		return "HANDLE_PACKED_OPS"
+	case 0xb61d27f6:
+		return "EXECUTE"
+	case 0x47e1da2a:
+		return "EXECUTE_BATCH"
+	case 0x18dfb3c7:
+		return "EXECUTE_BATCH_NO_VALUE"
+	case 0x34fcd5be:
+		return "EXECUTE_BATCH_CALLS"
+	case 0x7bb37428:
+		return "EXECUTE_USER_OP"
+	case 0x541d63c8:
+		return "EXECUTE_USER_OP_WITH_ERROR_STRING"
+	case 0x252dba42:
+		return "AGGREGATE"
+	case 0xbce38bd7:
+		return "TRY_AGGREGATE"
+	case 0x82ad56cb:
+		return "AGGREGATE3"
+	case 0x174dea71:
+		return "AGGREGATE3_VALUE"
+	}
+	return "UNKNOWN"
+}
+
+func Parse(i int64) (t Type, err error) {
+	switch i {
+	case 0x6a761202:
+		t = EXEC_TRANSACTION
+	case 0x8d80ff0a:
+		t = MULTI_SEND
+	case 0x1fad948c:
+		t = HANDLE_OPS
+	case 0x765e827f:
+		t = HANDLE_PACKED_OPS
+	case 0xb61d27f6:
+		t = EXECUTE
+	case 0x47e1da2a:
+		t = EXECUTE_BATCH
+	case 0x18dfb3c7:
+		t = EXECUTE_BATCH_NO_VALUE
+	case 0x34fcd5be:
+		t = EXECUTE_BATCH_CALLS
+	case 0x7bb37428:
+		t = EXECUTE_USER_OP
+	case 0x541d63c8:
+		t = EXECUTE_USER_OP_WITH_ERROR_STRING
+	case 0x252dba42:
+		t = AGGREGATE
+	case 0xbce38bd7:
+		t = TRY_AGGREGATE
+	case 0x82ad56cb:
+		t = AGGREGATE3
+	case 0x174dea71:
+		t = AGGREGATE3_VALUE
+	default:
+		err = fmt.Errorf("invalid wallet method 0x%x", i)
+	}
+	return
+}
+
+// Operation is the kind of call a Safe makes.
+//
+// see: safe-smart-account/contracts/libraries/Enum.sol
+type Operation uint8
+
+const (
+	CALL         Operation = 0
+	DELEGATECALL Operation = 1
+)
+
+func (o Operation) String() string {
+	switch o {
+	case CALL:
+		return "CALL"
+	case DELEGATECALL:
+		return "DELEGATECALL"
+	}
+	return "UNKNOWN"
+}
+
+func (o Operation) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
@@ -0,0 +1,287 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/hex"
+	"github.com/juztin/unidecode/internal/jsonx"
+)
+
+var ErrInvalidCallData = errors.New("invalid calldata")
+
+// Call is a call wrapped by a wallet method.
+type Call struct {
+	Operation Operation      `json:"operation"`
+	To        common.Address `json:"to"`
+	Value     *big.Int       `json:"value"`
+	Data      []byte         `json:"data"`
+	// AllowFailure is only set by Multicall3 `tryAggregate` and `aggregate3*` calls
+	AllowFailure bool `json:"allowFailure,omitempty"`
+}
+
+func (c Call) MarshalJSON() ([]byte, error) {
+	type Alias Call
+	return jsonx.Marshal((Alias)(c))
+}
+
+// Wrapped is a decoded wallet method and the calls it makes.
+//
+// The calls of `handleOps` are those the EntryPoint makes to each UserOperation's sender, with the operation's
+// callData.
+type Wrapped struct {
+	Method Type   `json:"-"`
+	Calls  []Call `json:"calls"`
+}
+
+func (w Wrapped) MarshalJSON() ([]byte, error) {
+	type Alias Wrapped
+	return jsonx.Marshal(&struct {
+		Alias
+		Type string `json:"type"`
+	}{(Alias)(w), w.Method.String()})
+}
+
+// Decode decodes calldata of a wallet method, where offset is the location of the method selector.
+func Decode(calldata []byte, offset int) (Wrapped, error) {
+	var w Wrapped
+	if offset < 0 || offset > len(calldata) {
+		return w, ErrInvalidCallData
+	}
+	sig := hex.MethodSig(calldata[offset:])
+	if sig == nil {
+		return w, ErrInvalidCallData
+	}
+	t, err := Parse(int64(sig[0])<<24 | int64(sig[1])<<16 | int64(sig[2])<<8 | int64(sig[3]))
+	if err != nil {
+		return w, err
+	}
+	w.Method = t
+
+	// Skip method signature
+	offset += 0x04
+
+	switch t {
+	case EXEC_TRANSACTION, EXECUTE_USER_OP, EXECUTE_USER_OP_WITH_ERROR_STRING:
+		c, err := decodeCall(calldata, offset, true)
+		if err != nil {
+			return w, err
+		}
+		w.Calls = append(w.Calls, c)
+	case EXECUTE:
+		c, err := decodeCall(calldata, offset, false)
+		if err != nil {
+			return w, err
+		}
+		w.Calls = append(w.Calls, c)
+	case MULTI_SEND:
+		b, err := hex.Bytes(calldata, offset, 0x00)
+		if err != nil {
+			return w, fmt.Errorf("invalid transactions; %w", err)
+		}
+		w.Calls, err = decodeMultiSend(b)
+		if err != nil {
+			return w, err
+		}
+	case HANDLE_OPS, HANDLE_PACKED_OPS:
+		// Both UserOperation and PackedUserOperation begin with `address sender, uint256 nonce, bytes initCode,
+		// bytes callData`
+		w.Calls, err = decodeTuples(calldata, offset, 0x00, func(loc int) (Call, error) {
+			if loc > len(calldata)-0x80 {
+				return Call{}, fmt.Errorf("%w; user operation exceeds calldata bounds", ErrInvalidCallData)
+			}
+			data, err := hex.Bytes(calldata, loc, 0x60)
+			if err != nil {
+				return Call{}, fmt.Errorf("invalid callData; %w", err)
+			}
+			return Call{To: common.BytesToAddress(calldata[loc : loc+0x20]), Value: new(big.Int), Data: data}, nil
+		})
+	case EXECUTE_BATCH, EXECUTE_BATCH_NO_VALUE:
+		w.Calls, err = decodeBatch(calldata, offset, t == EXECUTE_BATCH)
+	case EXECUTE_BATCH_CALLS:
+		w.Calls, err = decodeTuples(calldata, offset, 0x00, func(loc int) (Call, error) {
+			return decodeCall(calldata, loc, false)
+		})
+	case AGGREGATE:
+		w.Calls, err = decodeTuples(calldata, offset, 0x00, func(loc int) (Call, error) {
+			return decodeCall3(calldata, loc, false, false)
+		})
+	case TRY_AGGREGATE:
+		if offset+0x20 > len(calldata) {
+			return w, ErrInvalidCallData
+		}
+		requireSuccess, err := hex.Bool(calldata[offset : offset+0x20])
+		if err != nil {
+			return w, fmt.Errorf("invalid requireSuccess value; %w", err)
+		}
+		w.Calls, err = decodeTuples(calldata, offset, 0x20, func(loc int) (Call, error) {
+			c, err := decodeCall3(calldata, loc, false, false)
+			c.AllowFailure = !requireSuccess
+			return c, err
+		})
+		if err != nil {
+			return w, err
+		}
+	case AGGREGATE3, AGGREGATE3_VALUE:
+		w.Calls, err = decodeTuples(calldata, offset, 0x00, func(loc int) (Call, error) {
+			return decodeCall3(calldata, loc, true, t == AGGREGATE3_VALUE)
+		})
+	}
+	return w, err
+}
+
+// decodeCall decodes the `address to, uint256 value, bytes data` head at start, followed by `uint8 operation` when
+// withOperation is set.
+func decodeCall(calldata []byte, start int, withOperation bool) (Call, error) {
+	var c Call
+	if start < 0 || start > len(calldata)-0x80 {
+		return c, fmt.Errorf("%w; call exceeds calldata bounds", ErrInvalidCallData)
+	}
+	c.To = common.BytesToAddress(calldata[start : start+0x20])
+	c.Value = new(big.Int).SetBytes(calldata[start+0x20 : start+0x40])
+	if withOperation {
+		op, err := hex.Int(calldata[start+0x60 : start+0x80])
+		if err != nil || op > int(DELEGATECALL) {
+			return c, fmt.Errorf("invalid operation: 0x%x", calldata[start+0x60:start+0x80])
+		}
+		c.Operation = Operation(op)
+	}
+	var err error
+	if c.Data, err = hex.Bytes(calldata, start, 0x40); err != nil {
+		return c, fmt.Errorf("invalid call data; %w", err)
+	}
+	return c, nil
+}
+
+// decodeCall3 decodes a Multicall3 `Call`, `Call3` or `Call3Value` tuple at start.
+//
+// see: multicall/src/Multicall3.sol
+func decodeCall3(calldata []byte, start int, withAllowFailure, withValue bool) (Call, error) {
+	c := Call{Value: new(big.Int)}
+	if start < 0 || start > len(calldata)-0x80 {
+		return c, fmt.Errorf("%w; call exceeds calldata bounds", ErrInvalidCallData)
+	}
+	c.To = common.BytesToAddress(calldata[start : start+0x20])
+	head := 0x20
+	if withAllowFailure {
+		allowFailure, err := hex.Bool(calldata[start+head : start+head+0x20])
+		if err != nil {
+			return c, fmt.Errorf("invalid allowFailure value; %w", err)
+		}
+		c.AllowFailure = allowFailure
+		head += 0x20
+	}
+	if withValue {
+		c.Value = new(big.Int).SetBytes(calldata[start+head : start+head+0x20])
+		head += 0x20
+	}
+	var err error
+	if c.Data, err = hex.Bytes(calldata, start, head); err != nil {
+		return c, fmt.Errorf("invalid call data; %w", err)
+	}
+	return c, nil
+}
+
+// decodeTuples decodes each element of the dynamic tuple array whose location, relative to start, is stored at
+// start+head, using decode with the location of the element.
+func decodeTuples(calldata []byte, start, head int, decode func(loc int) (Call, error)) ([]Call, error) {
+	loc, count, err := hex.Array(calldata, start, head, 0x20)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calls; %w", err)
+	}
+	var calls []Call
+	for i := 0; i < count; i++ {
+		elemLoc, err := hex.Int(calldata[loc+i*0x20 : loc+i*0x20+0x20])
+		if err != nil {
+			return calls, fmt.Errorf("invalid call location at index %d; %w", i, err)
+		} else if elemLoc > len(calldata)-loc {
+			return calls, fmt.Errorf("%w; call %d exceeds calldata bounds", ErrInvalidCallData, i)
+		}
+		c, err := decode(loc + elemLoc)
+		if err != nil {
+			return calls, fmt.Errorf("invalid call at index %d; %w", i, err)
+		}
+		calls = append(calls, c)
+	}
+	return calls, nil
+}
+
+// decodeBatch decodes the `address[] dest, uint256[] value, bytes[] func` parameters of `executeBatch` at start, where
+// the values are omitted unless withValue is set.
+func decodeBatch(calldata []byte, start int, withValue bool) ([]Call, error) {
+	destLoc, count, err := hex.Array(calldata, start, 0x00, 0x20)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destinations; %w", err)
+	}
+	dataHead := 0x20
+	var valueLoc int
+	if withValue {
+		var valueCount int
+		valueLoc, valueCount, err = hex.Array(calldata, start, 0x20, 0x20)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values; %w", err)
+		}
+		// SimpleAccount allows an empty value array, meaning no value is sent
+		if valueCount != 0 && valueCount != count {
+			return nil, fmt.Errorf("values length mismatch; got %d but expected %d", valueCount, count)
+		} else if valueCount == 0 {
+			withValue = false
+		}
+		dataHead = 0x40
+	}
+	dataLoc, dataCount, err := hex.Array(calldata, start, dataHead, 0x20)
+	if err != nil {
+		return nil, fmt.Errorf("invalid call data; %w", err)
+	} else if dataCount != count {
+		return nil, fmt.Errorf("call data length mismatch; got %d but expected %d", dataCount, count)
+	}
+
+	var calls []Call
+	for i := 0; i < count; i++ {
+		c := Call{
+			To:    common.BytesToAddress(calldata[destLoc+i*0x20 : destLoc+i*0x20+0x20]),
+			Value: new(big.Int),
+		}
+		if withValue {
+			c.Value.SetBytes(calldata[valueLoc+i*0x20 : valueLoc+i*0x20+0x20])
+		}
+		// Elements of the `bytes[]` are located relative to its first element
+		if c.Data, err = hex.Bytes(calldata, dataLoc, i*0x20); err != nil {
+			return calls, fmt.Errorf("invalid call data at index %d; %w", i, err)
+		}
+		calls = append(calls, c)
+	}
+	return calls, nil
+}
+
+// decodeMultiSend decodes the packed transactions of `multiSend`, each being `uint8 operation, address to,
+// uint256 value, uint256 dataLength, bytes data`.
+//
+// see: safe-smart-account/contracts/libraries/MultiSend.sol
+func decodeMultiSend(b []byte) ([]Call, error) {
+	var calls []Call
+	for i := 0; i < len(b); {
+		if i+0x55 > len(b) {
+			return calls, fmt.Errorf("%w; transaction %d exceeds transactions bounds", ErrInvalidCallData, len(calls))
+		}
+		if b[i] > uint8(DELEGATECALL) {
+			return calls, fmt.Errorf("invalid operation %d for transaction %d", b[i], len(calls))
+		}
+		c := Call{
+			Operation: Operation(b[i]),
+			To:        common.BytesToAddress(b[i+0x01 : i+0x15]),
+			Value:     new(big.Int).SetBytes(b[i+0x15 : i+0x35]),
+		}
+		length, err := hex.Int(b[i+0x35 : i+0x55])
+		if err != nil || length > len(b)-i-0x55 {
+			return calls, fmt.Errorf("%w; transaction %d data exceeds transactions bounds", ErrInvalidCallData, len(calls))
+		}
+		c.Data = b[i+0x55 : i+0x55+length]
+		calls = append(calls, c)
+		i += 0x55 + length
+	}
+	return calls, nil
+}
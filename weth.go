@@ -0,0 +1,39 @@
+package unidecode
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/juztin/unidecode/actions"
+	"github.com/juztin/unidecode/commands"
+)
+
+// SetWETH sets the wrapped currency of the Wrap and Unwrap actions within v, a call returned by DecodeCall, to weth.
+//
+// Calldata doesn't identify the chain it's sent on, so the decoders use the mainnet actions.WETH9. The actions of v are
+// changed in place, so SetWETH must be called before v is shared.
+func SetWETH(v interface{}, weth common.Address) {
+	switch v := v.(type) {
+	case Execute:
+		setWETH(v.Commands, weth)
+	case Wallet:
+		for _, c := range v.Calls {
+			SetWETH(c.Call, weth)
+		}
+	}
+}
+
+func setWETH(cmds []commands.Command, weth common.Address) {
+	for _, cmd := range commands.Flatten(cmds) {
+		acts := cmd.Actions()
+		for i, action := range acts {
+			switch a := action.(type) {
+			case actions.Wrap:
+				a.Wrapped = weth
+				acts[i] = a
+			case actions.Unwrap:
+				a.Wrapped = weth
+				acts[i] = a
+			}
+		}
+	}
+}